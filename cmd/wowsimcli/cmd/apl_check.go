@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/apltext"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	aplCheckPlayer string
+	aplCheckText   bool
+	aplCheckStrict bool
+)
+
+var aplCheckCmd = &cobra.Command{
+	Use:   "aplcheck [rotation file]",
+	Short: "check an APL rotation for mistakes",
+	Long: `check an APL rotation for mistakes without running the sim: unknown spells and auras, type mismatches, conditions that are always true or false, unreachable entries, and sequences that are never reset.
+
+The rotation is read from the given file (APLRotation in protojson format, or the text action list format if the file does not end in .json), or from the player if no file is given.
+Exits with an error if any errors are found, or any issues at all with --strict.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: aplCheckMain,
+	// Problems in the rotation are reported as errors, which shouldn't print the usage.
+	SilenceUsage: true,
+}
+
+func init() {
+	aplCheckCmd.Flags().StringVar(&aplCheckPlayer, "player", "", "location of player file (Player in protojson format)")
+	aplCheckCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	aplCheckCmd.Flags().BoolVar(&aplCheckText, "text", false, "write one line per issue instead of APLCheckResult JSON")
+	aplCheckCmd.Flags().BoolVar(&aplCheckStrict, "strict", false, "fail on warnings as well as errors")
+	aplCheckCmd.MarkFlagRequired("player")
+}
+
+func aplCheckMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(aplCheckPlayer)
+	if err != nil {
+		return fmt.Errorf("failed to load player file %q: %w", aplCheckPlayer, err)
+	}
+	player := &proto.Player{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, player); err != nil {
+		return fmt.Errorf("failed to parse player json: %w", err)
+	}
+
+	rotationFile := aplCheckPlayer
+	if len(args) == 1 {
+		rotationFile = args[0]
+		player.Rotation, err = loadAPLRotation(rotationFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	result := core.CheckAPL(&proto.APLCheckRequest{Player: player})
+	if result.ErrorResult != "" {
+		return errors.New(result.ErrorResult)
+	}
+
+	var output []byte
+	if aplCheckText {
+		var sb strings.Builder
+		for _, issue := range result.Issues {
+			fmt.Fprintf(&sb, "%s: %s: %s [%s]\n", rotationFile, aplCheckIssueLocation(issue), issue.Message, issue.Check)
+		}
+		output = []byte(sb.String())
+	} else {
+		output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		output = append(output, '\n')
+	}
+	if err := writeOutput(output); err != nil {
+		return err
+	}
+
+	numFailures := 0
+	for _, issue := range result.Issues {
+		if aplCheckStrict || issue.Severity == proto.APLCheckSeverity_APLCheckSeverityError {
+			numFailures++
+		}
+	}
+	if numFailures > 0 {
+		return fmt.Errorf("%s: %d problem(s) found", rotationFile, numFailures)
+	}
+	return nil
+}
+
+func loadAPLRotation(file string) (*proto.APLRotation, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rotation file %q: %w", file, err)
+	}
+	if filepath.Ext(file) != ".json" {
+		rot, err := apltext.Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		return rot, nil
+	}
+	rot := &proto.APLRotation{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, rot); err != nil {
+		return nil, fmt.Errorf("failed to parse rotation json: %w", err)
+	}
+	return rot, nil
+}
+
+func aplCheckIssueLocation(issue *proto.APLCheckIssue) string {
+	severity := "warning"
+	if issue.Severity == proto.APLCheckSeverity_APLCheckSeverityError {
+		severity = "error"
+	}
+
+	list := "priority list"
+	if issue.Prepull {
+		list = "prepull"
+	} else if issue.ListName != "" {
+		list = fmt.Sprintf("list '%s'", issue.ListName)
+	}
+	if issue.EntryIndex < 0 {
+		return fmt.Sprintf("%s: %s", severity, list)
+	}
+	return fmt.Sprintf("%s: %s entry %d", severity, list, issue.EntryIndex)
+}
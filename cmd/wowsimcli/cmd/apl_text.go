@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core/apltext"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var aplToTextCmd = &cobra.Command{
+	Use:   "apl2text [file]",
+	Short: "convert an APL rotation from JSON to text",
+	Long:  "convert an APL rotation (APLRotation in protojson format, e.g. ui/*/apls/*.apl.json) to the text action list format",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to load rotation file %q: %w", args[0], err)
+		}
+		rot := &proto.APLRotation{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, rot); err != nil {
+			return fmt.Errorf("failed to parse rotation json: %w", err)
+		}
+		return writeOutput([]byte(apltext.Format(rot)))
+	},
+}
+
+var textToAPLCmd = &cobra.Command{
+	Use:   "text2apl [file]",
+	Short: "convert an APL rotation from text to JSON",
+	Long:  "convert an APL rotation in the text action list format to APLRotation in protojson format",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to load rotation file %q: %w", args[0], err)
+		}
+		rot, err := apltext.Parse(string(data))
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		output, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(rot)
+		if err != nil {
+			return fmt.Errorf("failed to marshal rotation: %w", err)
+		}
+		return writeOutput(append(output, '\n'))
+	},
+}
+
+func init() {
+	aplToTextCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	textToAPLCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+}
+
+func writeOutput(output []byte) error {
+	if outfile == "" {
+		_, err := os.Stdout.Write(output)
+		return err
+	}
+	if err := os.WriteFile(outfile, output, 0666); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
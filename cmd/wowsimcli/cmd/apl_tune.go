@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/apltext"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var aplTuneText bool
+
+var aplTuneCmd = &cobra.Command{
+	Use:   "apltune",
+	Short: "tune constants and entry order of an APL rotation",
+	Long:  "search for the best values of marked constants in a player's APL rotation, and optionally the best order of some priority list entries",
+	RunE:  aplTuneMain,
+}
+
+func init() {
+	aplTuneCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (APLTuneRequest in protojson format)")
+	aplTuneCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	aplTuneCmd.Flags().BoolVar(&aplTuneText, "text", false, "write the best rotation in text format followed by a sensitivity table, instead of APLTuneResult JSON")
+	aplTuneCmd.MarkFlagRequired("infile")
+}
+
+func aplTuneMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", infile, err)
+	}
+	input := &proto.APLTuneRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return fmt.Errorf("failed to load input json file: %w", err)
+	}
+
+	result := core.TuneAPL(input)
+	if result.ErrorResult != "" {
+		return errors.New(result.ErrorResult)
+	}
+
+	if !aplTuneText {
+		output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal final results: %w", err)
+		}
+		return writeOutput(output)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Base DPS: %.2f\n# Best DPS: %.2f\n", result.BaseDps, result.BestDps)
+	sb.WriteString(apltext.Format(result.BestRotation))
+	for _, sensitivity := range result.Sensitivity {
+		fmt.Fprintf(&sb, "\n%s (best %g)\n", sensitivity.Name, sensitivity.BestValue)
+		w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "value\tdps\tdelta\t")
+		for i, value := range sensitivity.Values {
+			fmt.Fprintf(w, "%g\t%.2f\t%+.2f\t\n", value, sensitivity.Dps[i], sensitivity.Dps[i]-result.BestDps)
+		}
+		w.Flush()
+	}
+	return writeOutput([]byte(sb.String()))
+}
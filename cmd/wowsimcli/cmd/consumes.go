@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	consumesPrices     string
+	consumesIterations int32
+	consumesText       bool
+)
+
+var consumesCmd = &cobra.Command{
+	Use:   "consumes",
+	Short: "find the best consumables for a player",
+	Long: `sim each flask, elixir, food, potion and explosive in the consumable catalog one category at a time,
+and rank them by DPS gain. Consumables from the player's database are included.
+
+The input is a ConsumableOptimizerRequest. --prices is a JSON object of gold per use keyed by consumable name,
+e.g. {"Flask of Endless Rage": 30, "Global Thermal Sapper Charge": 12}, and adds DPS per gold to the results.`,
+	RunE: consumesMain,
+}
+
+func init() {
+	consumesCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (ConsumableOptimizerRequest in protojson format)")
+	consumesCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	consumesCmd.Flags().StringVar(&consumesPrices, "prices", "", "location of a JSON price list, in gold per use")
+	consumesCmd.Flags().Int32Var(&consumesIterations, "iterations", 0, "iterations per sim, overriding the input")
+	consumesCmd.Flags().BoolVar(&consumesText, "text", false, "write a table per category instead of ConsumableOptimizerResult JSON")
+	consumesCmd.MarkFlagRequired("infile")
+}
+
+func consumesMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", infile, err)
+	}
+	input := &proto.ConsumableOptimizerRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return fmt.Errorf("failed to load input json file: %w", err)
+	}
+
+	if consumesPrices != "" {
+		data, err := os.ReadFile(consumesPrices)
+		if err != nil {
+			return fmt.Errorf("failed to load price list %q: %w", consumesPrices, err)
+		}
+		prices := map[string]float64{}
+		if err := json.Unmarshal(data, &prices); err != nil {
+			return fmt.Errorf("failed to parse price list: %w", err)
+		}
+		input.Prices = prices
+	}
+	if cmd.Flags().Changed("iterations") {
+		input.IterationsPerCombo = consumesIterations
+	}
+
+	result := core.RunConsumableOptimizer(input)
+	if result.ErrorResult != "" {
+		return errors.New(result.ErrorResult)
+	}
+
+	if !consumesText {
+		output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal final results: %w", err)
+		}
+		return writeOutput(output)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Current DPS: %.2f\n", result.BaseDps)
+	fmt.Fprintf(&sb, "# Best DPS: %.2f with %s\n", result.BestDps, protojson.Format(result.BestConsumes))
+	for _, category := range result.Categories {
+		fmt.Fprintf(&sb, "\n%s\n", category.Name)
+		w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "option\tdps\tdelta\tprice\tdps/gold\t")
+		for _, option := range category.Options {
+			price, perGold := "", ""
+			if option.Price > 0 {
+				price = fmt.Sprintf("%.2f", option.Price)
+				perGold = fmt.Sprintf("%.3f", option.DpsPerGold)
+			}
+			fmt.Fprintf(w, "%s\t%.2f\t%+.2f\t%s\t%s\t\n", option.Name, option.Dps, option.DpsDelta, price, perGold)
+		}
+		w.Flush()
+	}
+	return writeOutput([]byte(sb.String()))
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/assets/database"
+	"github.com/wowsims/wotlk/sim/core"
+)
+
+var (
+	coverageMaxPhase int32
+	coverageJSON     bool
+)
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "list items whose effects are not implemented",
+	Long: `list every item, gem and enchant in the database with an Equip, Use or Chance on hit effect that the sim doesn't implement, grouped by slot and phase.
+
+Only the stats of these are simmed, so results for gear using them should not be trusted.`,
+	Args: cobra.NoArgs,
+	RunE: coverageMain,
+}
+
+func init() {
+	coverageCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	coverageCmd.Flags().Int32Var(&coverageMaxPhase, "max-phase", 0, "ignore items from later phases")
+	coverageCmd.Flags().BoolVar(&coverageJSON, "json", false, "write a JSON array instead of a table")
+}
+
+func coverageMain(cmd *cobra.Command, args []string) error {
+	db := database.Load()
+	if len(db.Items) == 0 {
+		return fmt.Errorf("the embedded item database is empty, generate it with make items")
+	}
+
+	var missing []core.UnimplementedEffect
+	for _, effect := range core.FindUnimplementedEffects(db) {
+		if coverageMaxPhase == 0 || effect.Phase <= coverageMaxPhase {
+			missing = append(missing, effect)
+		}
+	}
+
+	if coverageJSON {
+		output, err := json.MarshalIndent(missing, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+		return writeOutput(append(output, '\n'))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %d items, gems and enchants with unimplemented effects\n", len(missing))
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	lastGroup := ""
+	for _, effect := range missing {
+		group := effect.Kind
+		if effect.Kind != "gem" {
+			group += " " + strings.TrimPrefix(effect.Type.String(), "ItemType")
+		}
+		if group != lastGroup {
+			w.Flush()
+			fmt.Fprintf(&sb, "\n%s\n", group)
+			fmt.Fprintln(w, "phase\tid\tname\t")
+			lastGroup = group
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t\n", effect.Phase, effect.ID, effect.Name)
+	}
+	w.Flush()
+	return writeOutput([]byte(sb.String()))
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/assets/database"
+	"github.com/wowsims/wotlk/sim/core/importer"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	importTemplate string
+	importFormat   string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import a character exported in-game",
+	Long: `import gear, talents, glyphs and professions from the WowSimsExporter addon JSON, or gear from a list of item links.
+
+The imported character replaces those settings of the first player in --template, a RaidSimRequest,
+and the result is written as a RaidSimRequest ready to sim. Anything not found in the database is left out and listed.`,
+	Args: cobra.NoArgs,
+	RunE: importMain,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&infile, "infile", "", "location of the exported character")
+	importCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	importCmd.Flags().StringVar(&importTemplate, "template", "", "location of a RaidSimRequest in protojson format to import into")
+	importCmd.Flags().StringVar(&importFormat, "format", "auto", "format of the export: addon, links or auto")
+	importCmd.MarkFlagRequired("infile")
+	importCmd.MarkFlagRequired("template")
+}
+
+func importMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load export %q: %w", infile, err)
+	}
+
+	templateData, err := os.ReadFile(importTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to load template %q: %w", importTemplate, err)
+	}
+	request := &proto.RaidSimRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(templateData, request); err != nil {
+		return fmt.Errorf("failed to load template: %w", err)
+	}
+	if len(request.GetRaid().GetParties()) == 0 || len(request.Raid.Parties[0].Players) == 0 {
+		return fmt.Errorf("template has no players")
+	}
+
+	db := database.Load()
+	if len(db.Items) == 0 {
+		return fmt.Errorf("the embedded item database is empty, generate it with make items")
+	}
+	imp := importer.New(db)
+
+	format := importFormat
+	if format == "auto" {
+		format = "links"
+		if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+			format = "addon"
+		}
+	}
+
+	var result *importer.Import
+	switch format {
+	case "addon":
+		result, err = imp.AddonJSON(data)
+	case "links":
+		result, err = imp.ItemLinks(string(data))
+	default:
+		return fmt.Errorf("unknown format %q, expected addon, links or auto", importFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, unknown := range result.Unknown {
+		fmt.Fprintf(os.Stderr, "not in the database, skipped: %s\n", unknown)
+	}
+
+	if err := result.ApplyTo(request.Raid.Parties[0].Players[0]); err != nil {
+		return err
+	}
+
+	output, err := protojson.MarshalOptions{Indent: "  "}.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return writeOutput(append(output, '\n'))
+}
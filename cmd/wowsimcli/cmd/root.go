@@ -5,12 +5,25 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
 )
 
+var itemEffectsFile string
+
 var rootCmd = &cobra.Command{
 	Use:   "wowsimcli",
 	Short: "wowsims command line tool",
 	Long:  "wowsims command line tool",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if itemEffectsFile == "" {
+			return nil
+		}
+		return core.LoadItemEffectConfigsFile(itemEffectsFile)
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&itemEffectsFile, "item-effects", "", "JSON file of extra item effects to load")
 }
 
 func Execute(version string) {
@@ -18,6 +31,15 @@ func Execute(version string) {
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(aplToTextCmd)
+	rootCmd.AddCommand(textToAPLCmd)
+	rootCmd.AddCommand(aplTuneCmd)
+	rootCmd.AddCommand(aplCheckCmd)
+	rootCmd.AddCommand(upgradesCmd)
+	rootCmd.AddCommand(coverageCmd)
+	rootCmd.AddCommand(consumesCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(setsCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	setsIterations int32
+	setsText       bool
+)
+
+var setsCmd = &cobra.Command{
+	Use:   "sets",
+	Short: "compare set bonus configurations for a player",
+	Long: `sim each placement of set pieces which reaches a set bonus configuration, filling the other slots
+with the best non-set candidates, and rank the configurations by DPS.
+
+The input is a SetAnalysisRequest.`,
+	RunE: setsMain,
+}
+
+func init() {
+	setsCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (SetAnalysisRequest in protojson format)")
+	setsCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	setsCmd.Flags().Int32Var(&setsIterations, "iterations", 0, "iterations per sim, overriding the input")
+	setsCmd.Flags().BoolVar(&setsText, "text", false, "write a table of configurations instead of SetAnalysisResult JSON")
+	setsCmd.MarkFlagRequired("infile")
+}
+
+func setsMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", infile, err)
+	}
+	input := &proto.SetAnalysisRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return fmt.Errorf("failed to load input json file: %w", err)
+	}
+	if cmd.Flags().Changed("iterations") {
+		if input.BulkSettings == nil {
+			input.BulkSettings = &proto.BulkSettings{}
+		}
+		input.BulkSettings.IterationsPerCombo = setsIterations
+	}
+
+	result := core.RunSetAnalysis(input)
+	if result.ErrorResult != "" {
+		return errors.New(result.ErrorResult)
+	}
+
+	if !setsText {
+		output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal final results: %w", err)
+		}
+		return writeOutput(output)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Equipped DPS: %.2f\n", result.EquippedDps)
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "bonuses\tdps\tdelta\tplacements\tbest placement\t")
+	for _, configuration := range result.Configurations {
+		var bonuses []string
+		for _, bonus := range configuration.Bonuses {
+			bonuses = append(bonuses, fmt.Sprintf("%dpc %s", bonus.NumPieces, bonus.SetName))
+		}
+		var items []string
+		if len(configuration.Placements) > 0 {
+			for _, item := range configuration.Placements[0].Items {
+				items = append(items, fmt.Sprintf("%s=%d", strings.TrimPrefix(item.Slot.String(), "ItemSlot"), item.Item.Id))
+			}
+		}
+		fmt.Fprintf(w, "%s\t%.2f\t%+.2f\t%d\t%s\t\n", strings.Join(bonuses, ", "), configuration.Dps, configuration.DpsDelta, len(configuration.Placements), strings.Join(items, " "))
+	}
+	w.Flush()
+	return writeOutput([]byte(sb.String()))
+}
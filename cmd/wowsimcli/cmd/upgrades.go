@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	upgradesZone       int32
+	upgradesDifficulty string
+	upgradesMaxPhase   int32
+	upgradesText       bool
+)
+
+var upgradesCmd = &cobra.Command{
+	Use:   "upgrades",
+	Short: "find upgrades from each boss in a zone",
+	Long: `sim every usable drop from a zone as a single-slot swap, and list the upgrades from each boss.
+
+The input is an UpgradeFinderRequest; --zone, --difficulty and --max-phase override its filters.
+Difficulties use the DungeonDifficulty names, with or without the Difficulty prefix, e.g. raid25h.`,
+	RunE: upgradesMain,
+}
+
+func init() {
+	upgradesCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (UpgradeFinderRequest in protojson format)")
+	upgradesCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	upgradesCmd.Flags().Int32Var(&upgradesZone, "zone", 0, "zone ID to find drops from")
+	upgradesCmd.Flags().StringVar(&upgradesDifficulty, "difficulty", "", "difficulty of the drops, e.g. raid10 or raid25h")
+	upgradesCmd.Flags().Int32Var(&upgradesMaxPhase, "max-phase", 0, "ignore items from later phases")
+	upgradesCmd.Flags().BoolVar(&upgradesText, "text", false, "write a table of upgrades per boss instead of UpgradeFinderResult JSON")
+	upgradesCmd.MarkFlagRequired("infile")
+}
+
+func upgradesMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", infile, err)
+	}
+	input := &proto.UpgradeFinderRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return fmt.Errorf("failed to load input json file: %w", err)
+	}
+
+	if cmd.Flags().Changed("zone") {
+		input.ZoneId = upgradesZone
+	}
+	if cmd.Flags().Changed("difficulty") {
+		input.Difficulty, err = parseDifficulty(upgradesDifficulty)
+		if err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("max-phase") {
+		input.MaxPhase = upgradesMaxPhase
+	}
+
+	result := core.RunUpgradeFinder(input)
+	if result.ErrorResult != "" {
+		return errors.New(result.ErrorResult)
+	}
+
+	if !upgradesText {
+		output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal final results: %w", err)
+		}
+		return writeOutput(output)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Equipped DPS: %.2f\n", result.EquippedGearResult.GetDps().GetAvg())
+	for _, boss := range result.Bosses {
+		fmt.Fprintf(&sb, "\n%s (%s)\n", boss.Name, boss.ZoneName)
+		w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "item\tslot\tdifficulty\tdps\tdelta\t")
+		for _, upgrade := range boss.Upgrades {
+			name := core.ItemsByID[upgrade.Item.Item.Id].Name
+			if name == "" {
+				name = fmt.Sprintf("%d", upgrade.Item.Item.Id)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%.2f\t%+.2f\t\n", name, strings.TrimPrefix(upgrade.Item.Slot.String(), "ItemSlot"),
+				strings.TrimPrefix(upgrade.Difficulty.String(), "Difficulty"), upgrade.Dps, upgrade.DpsDelta)
+		}
+		w.Flush()
+	}
+	return writeOutput([]byte(sb.String()))
+}
+
+func parseDifficulty(name string) (proto.DungeonDifficulty, error) {
+	for value, valueName := range proto.DungeonDifficulty_name {
+		if strings.EqualFold(name, valueName) || strings.EqualFold(name, strings.TrimPrefix(valueName, "Difficulty")) {
+			return proto.DungeonDifficulty(value), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown difficulty %q", name)
+}
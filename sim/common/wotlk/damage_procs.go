@@ -16,30 +16,13 @@ type ProcDamageEffect struct {
 }
 
 func newProcDamageEffect(config ProcDamageEffect) {
-	core.NewItemEffect(config.ID, func(agent core.Agent) {
-		character := agent.GetCharacter()
-
-		minDmg := config.MinDmg
-		maxDmg := config.MaxDmg
-		damageSpell := character.RegisterSpell(core.SpellConfig{
-			ActionID:    core.ActionID{ItemID: config.ID},
-			SpellSchool: config.School,
-			ProcMask:    core.ProcMaskEmpty,
-
-			DamageMultiplier: 1,
-			CritMultiplier:   character.DefaultSpellCritMultiplier(),
-			ThreatMultiplier: 1,
-
-			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				spell.CalcAndDealDamage(sim, target, sim.Roll(minDmg, maxDmg), spell.OutcomeMagicHitAndCrit)
-			},
-		})
-
-		triggerConfig := config.Trigger
-		triggerConfig.Handler = func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
-			damageSpell.Cast(sim, character.CurrentTarget)
-		}
-		core.MakeProcTriggerAura(&character.Unit, triggerConfig)
+	core.NewItemEffectFromConfig(core.ItemEffectConfig{
+		Name:      config.Trigger.Name,
+		ItemID:    config.ID,
+		Trigger:   config.Trigger,
+		School:    config.School,
+		MinDamage: config.MinDmg,
+		MaxDamage: config.MaxDmg,
 	})
 }
 
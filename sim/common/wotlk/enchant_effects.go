@@ -341,7 +341,7 @@ func init() {
 			},
 		})
 
-		character.ItemSwap.RegisterOnSwapItemForEffect(4047, aura)
+		character.ItemSwap.RegisterOnSwapItemForEnchantEffect(4047, aura)
 	})
 
 	core.AddWeaponEffect(3843, func(agent core.Agent, _ proto.ItemSlot) {
@@ -26,30 +26,11 @@ type ProcStatBonusEffect struct {
 }
 
 func newProcStatBonusEffect(config ProcStatBonusEffect) {
-	core.NewItemEffect(config.ID, func(agent core.Agent) {
-		character := agent.GetCharacter()
-
-		procID := core.ActionID{SpellID: config.AuraID}
-		if procID.IsEmptyAction() {
-			procID = core.ActionID{ItemID: config.ID}
-		}
-		procAura := character.NewTemporaryStatsAura(config.Name+" Proc", procID, config.Bonus, config.Duration)
-
-		handler := func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
-			procAura.Activate(sim)
-		}
-		if config.IgnoreSpellID != 0 {
-			ignoreSpellID := config.IgnoreSpellID
-			handler = func(sim *core.Simulation, spell *core.Spell, _ *core.SpellResult) {
-				if !spell.IsSpellAction(ignoreSpellID) {
-					procAura.Activate(sim)
-				}
-			}
-		}
-
-		triggerAura := core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
-			ActionID:   core.ActionID{ItemID: config.ID},
-			Name:       config.Name,
+	core.NewItemEffectFromConfig(core.ItemEffectConfig{
+		Name:   config.Name,
+		ItemID: config.ID,
+		AuraID: config.AuraID,
+		Trigger: core.ProcTrigger{
 			Callback:   config.Callback,
 			ProcMask:   config.ProcMask,
 			Outcome:    config.Outcome,
@@ -57,9 +38,10 @@ func newProcStatBonusEffect(config ProcStatBonusEffect) {
 			ProcChance: config.ProcChance,
 			PPM:        config.PPM,
 			ICD:        config.ICD,
-			Handler:    handler,
-		})
-		procAura.Icd = triggerAura.Icd
+		},
+		IgnoreSpellID: config.IgnoreSpellID,
+		Bonus:         config.Bonus,
+		Duration:      config.Duration,
 	})
 }
 
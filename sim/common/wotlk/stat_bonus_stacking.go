@@ -23,37 +23,21 @@ type StackingStatBonusEffect struct {
 }
 
 func newStackingStatBonusEffect(config StackingStatBonusEffect) {
-	core.NewItemEffect(config.ID, func(agent core.Agent) {
-		character := agent.GetCharacter()
-
-		auraID := core.ActionID{SpellID: config.AuraID}
-		if auraID.IsEmptyAction() {
-			auraID = core.ActionID{ItemID: config.ID}
-		}
-		procAura := core.MakeStackingAura(character, core.StackingStatAura{
-			Aura: core.Aura{
-				Label:     config.Name + " Proc",
-				ActionID:  auraID,
-				Duration:  config.Duration,
-				MaxStacks: config.MaxStacks,
-			},
-			BonusPerStack: config.Bonus,
-		})
-
-		core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
-			ActionID:   core.ActionID{ItemID: config.ID},
-			Name:       config.Name,
+	core.NewItemEffectFromConfig(core.ItemEffectConfig{
+		Name:   config.Name,
+		ItemID: config.ID,
+		AuraID: config.AuraID,
+		Trigger: core.ProcTrigger{
 			Callback:   config.Callback,
 			ProcMask:   config.ProcMask,
 			SpellFlags: config.SpellFlags,
 			Outcome:    config.Outcome,
 			Harmful:    config.Harmful,
 			ProcChance: config.ProcChance,
-			Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
-				procAura.Activate(sim)
-				procAura.AddStack(sim)
-			},
-		})
+		},
+		Bonus:     config.Bonus,
+		Duration:  config.Duration,
+		MaxStacks: config.MaxStacks,
 	})
 }
 
@@ -75,67 +59,27 @@ type StackingStatBonusCD struct {
 }
 
 func newStackingStatBonusCD(config StackingStatBonusCD) {
-	core.NewItemEffect(config.ID, func(agent core.Agent) {
-		character := agent.GetCharacter()
-
-		auraID := core.ActionID{SpellID: config.AuraID}
-		if auraID.IsEmptyAction() {
-			auraID = core.ActionID{ItemID: config.ID}
-		}
-		buffAura := core.MakeStackingAura(character, core.StackingStatAura{
-			Aura: core.Aura{
-				Label:     config.Name + " Aura",
-				ActionID:  auraID,
-				Duration:  config.Duration,
-				MaxStacks: config.MaxStacks,
-			},
-			BonusPerStack: config.Bonus,
-		})
-
-		core.ApplyProcTriggerCallback(&character.Unit, buffAura, core.ProcTrigger{
-			Name:       config.Name,
+	sharedCD := core.ItemSharedCDOffensiveTrinket
+	if config.IsDefensive {
+		sharedCD = core.ItemSharedCDDefensiveTrinket
+	}
+	core.NewItemEffectFromConfig(core.ItemEffectConfig{
+		Name:   config.Name,
+		ItemID: config.ID,
+		AuraID: config.AuraID,
+		Trigger: core.ProcTrigger{
 			Callback:   config.Callback,
 			ProcMask:   config.ProcMask,
 			SpellFlags: config.SpellFlags,
 			Outcome:    config.Outcome,
 			Harmful:    config.Harmful,
 			ProcChance: config.ProcChance,
-			Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
-				buffAura.AddStack(sim)
-			},
-		})
-
-		var sharedTimer *core.Timer
-		if config.IsDefensive {
-			sharedTimer = character.GetDefensiveTrinketCD()
-		} else {
-			sharedTimer = character.GetOffensiveTrinketCD()
-		}
-
-		spell := character.RegisterSpell(core.SpellConfig{
-			ActionID: core.ActionID{ItemID: config.ID},
-			Flags:    core.SpellFlagNoOnCastComplete,
-
-			Cast: core.CastConfig{
-				CD: core.Cooldown{
-					Timer:    character.NewTimer(),
-					Duration: config.CD,
-				},
-				SharedCD: core.Cooldown{
-					Timer:    sharedTimer,
-					Duration: config.Duration,
-				},
-			},
-
-			ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
-				buffAura.Activate(sim)
-			},
-		})
-
-		character.AddMajorCooldown(core.MajorCooldown{
-			Spell: spell,
-			Type:  core.CooldownTypeDPS,
-		})
+		},
+		Cooldown:  config.CD,
+		SharedCD:  sharedCD,
+		Bonus:     config.Bonus,
+		Duration:  config.Duration,
+		MaxStacks: config.MaxStacks,
 	})
 }
 
@@ -60,3 +60,53 @@ func RunBulkSim(request *proto.BulkSimRequest) *proto.BulkSimResult {
 func RunBulkSimAsync(ctx context.Context, request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics) {
 	go BulkSim(ctx, request, progress)
 }
+
+/**
+ * Searches for the best values of marked constants in a player's APL rotation.
+ */
+func RunAPLTune(request *proto.APLTuneRequest) *proto.APLTuneResult {
+	return TuneAPL(request)
+}
+
+/**
+ * Reports problems in a player's APL rotation that can be found without running the sim.
+ */
+func RunAPLCheck(request *proto.APLCheckRequest) *proto.APLCheckResult {
+	return CheckAPL(request)
+}
+
+/**
+ * Sims each usable drop from a zone as a single-slot swap, and ranks the upgrades by boss.
+ */
+func RunUpgradeFinder(request *proto.UpgradeFinderRequest) *proto.UpgradeFinderResult {
+	return UpgradeFinder(context.Background(), request, nil)
+}
+
+/**
+ * Sims each consumable option one category at a time, and ranks them by DPS gain.
+ */
+func RunConsumableOptimizer(request *proto.ConsumableOptimizerRequest) *proto.ConsumableOptimizerResult {
+	return ConsumableOptimizer(context.Background(), request, nil)
+}
+
+/**
+ * Sims each placement of set pieces which reaches a set bonus configuration, and ranks the configurations by DPS.
+ */
+func RunSetAnalysis(request *proto.SetAnalysisRequest) *proto.SetAnalysisResult {
+	return SetAnalysis(context.Background(), request, nil)
+}
+
+/**
+ * Step-based environment in which an external agent controls one player.
+ */
+func EnvReset(request *proto.EnvResetRequest) *proto.EnvResetResult {
+	return resetInteractiveEnv(request)
+}
+
+func EnvStep(request *proto.EnvStepRequest) *proto.EnvStepResult {
+	return stepInteractiveEnv(request)
+}
+
+func EnvClose(request *proto.EnvCloseRequest) *proto.EnvCloseResult {
+	return closeInteractiveEnv(request)
+}
@@ -13,6 +13,17 @@ type APLRotation struct {
 	prepullActions []*APLAction
 	priorityList   []*APLAction
 
+	// Named sub-lists, entered with Call List / Run List.
+	actionLists       []*aplActionList
+	actionListsByName map[string]*aplActionList
+
+	// Variables assigned by Set Variable actions, keyed by name.
+	variables      map[string]*aplVariable
+	variableValues map[*proto.APLActionSetVariable]aplVariableValue
+
+	// Set Variable entries reached while selecting the current action, see commitVariables.
+	stagedVariables []*APLAction
+
 	// Action currently controlling this rotation (only used for certain actions, such as StrictSequence).
 	controllingActions []APLActionImpl
 
@@ -26,9 +37,25 @@ type APLRotation struct {
 	// Used inside of actions/value to determine whether they will occur during the prepull or regular rotation.
 	parsingPrepull bool
 
+	// Name of the action list currently being parsed, or "" for the prepull and main lists.
+	parsingList string
+
+	// Used while declaring variables, to parse each value as prepull or not.
+	variablePrepullConfigs map[*proto.APLActionSetVariable]bool
+
 	// Used to avoid recursive APL loops.
 	inLoop bool
 
+	// Number of actions performed this iteration.
+	numExecutions int
+
+	// Entry statistics, see apl_profile.go.
+	profiling          bool
+	entryProfiles      []*aplEntryProfile
+	profiledIterations int32
+	waitingProfile     *aplEntryProfile
+	waitStart          time.Duration
+
 	// Validation warnings that occur during proto parsing.
 	// We return these back to the user for display in the UI.
 	curWarnings          []string
@@ -73,10 +100,14 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 
 	rotation := &APLRotation{
 		unit:                 unit,
+		actionListsByName:    make(map[string]*aplActionList),
 		prepullWarnings:      make([][]string, len(config.PrepullActions)),
 		priorityListWarnings: make([][]string, len(config.PriorityList)),
 	}
 
+	// Variable types must be known before any action or value which reads them is parsed.
+	rotation.declareVariables(config)
+
 	// Parse prepull actions
 	for i, prepullItem := range config.PrepullActions {
 		prepullIdx := i // Save to local variable for correct lambda capture behavior
@@ -113,12 +144,47 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 				action := rotation.newAPLAction(aplItem.Action)
 				if action != nil {
 					rotation.priorityList = append(rotation.priorityList, action)
+					rotation.newEntryProfile(action, "", i)
 					configIdxs = append(configIdxs, i)
 				}
 			}
 		})
 	}
 
+	// Parse named action lists
+	for _, listConfig := range config.ActionLists {
+		list := &aplActionList{
+			name:         listConfig.Name,
+			itemWarnings: make([][]string, len(listConfig.Items)),
+		}
+		rotation.actionLists = append(rotation.actionLists, list)
+
+		rotation.doAndRecordWarnings(&list.warnings, false, func() {
+			if list.name == "" {
+				rotation.ValidationWarning("Action lists must have a name")
+			} else if _, ok := rotation.actionListsByName[list.name]; ok {
+				rotation.ValidationWarning("Duplicate action list name: '%s'", list.name)
+			} else {
+				rotation.actionListsByName[list.name] = list
+			}
+		})
+
+		rotation.parsingList = list.name
+		for i, aplItem := range listConfig.Items {
+			rotation.doAndRecordWarnings(&list.itemWarnings[i], false, func() {
+				if !aplItem.Hide {
+					action := rotation.newAPLAction(aplItem.Action)
+					if action != nil {
+						list.actions = append(list.actions, action)
+						list.itemConfigIdxs = append(list.itemConfigIdxs, i)
+						rotation.newEntryProfile(action, list.name, i)
+					}
+				}
+			})
+		}
+		rotation.parsingList = ""
+	}
+
 	// Finalize
 	for i, action := range rotation.prepullActions {
 		rotation.doAndRecordWarnings(&rotation.prepullWarnings[i], true, func() {
@@ -130,6 +196,13 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 			action.Finalize(rotation)
 		})
 	}
+	for _, list := range rotation.actionLists {
+		for i, action := range list.actions {
+			rotation.doAndRecordWarnings(&list.itemWarnings[list.itemConfigIdxs[i]], false, func() {
+				action.Finalize(rotation)
+			})
+		}
+	}
 
 	// Remove MCDs that are referenced by APL actions, so that the Autocast Other Cooldowns
 	// action does not include them.
@@ -168,12 +241,23 @@ func (rot *APLRotation) getStats() *proto.APLStats {
 	return &proto.APLStats{
 		PrepullActions: MapSlice(rot.prepullWarnings, func(warnings []string) *proto.APLActionStats { return &proto.APLActionStats{Warnings: warnings} }),
 		PriorityList:   MapSlice(rot.priorityListWarnings, func(warnings []string) *proto.APLActionStats { return &proto.APLActionStats{Warnings: warnings} }),
+		ActionLists: MapSlice(rot.actionLists, func(list *aplActionList) *proto.APLActionListStats {
+			return &proto.APLActionListStats{
+				Warnings: list.warnings,
+				Items:    MapSlice(list.itemWarnings, func(warnings []string) *proto.APLActionStats { return &proto.APLActionStats{Warnings: warnings} }),
+			}
+		}),
 	}
 }
 
-// Returns all action objects as an unstructured list. Used for easily finding specific actions.
+// Returns all action objects as an unstructured list, including those in named action lists.
+// Used for easily finding specific actions.
 func (rot *APLRotation) allAPLActions() []*APLAction {
-	return Flatten(MapSlice(rot.priorityList, func(action *APLAction) []*APLAction { return action.GetAllActions() }))
+	actions := append([]*APLAction{}, rot.priorityList...)
+	for _, list := range rot.actionLists {
+		actions = append(actions, list.actions...)
+	}
+	return Flatten(MapSlice(actions, func(action *APLAction) []*APLAction { return action.GetAllActions() }))
 }
 
 // Returns all action objects from the prepull as an unstructured list. Used for easily finding specific actions.
@@ -182,13 +266,20 @@ func (rot *APLRotation) allPrepullActions() []*APLAction {
 }
 
 func (rot *APLRotation) reset(sim *Simulation) {
+	rot.profiling = sim.Options.ProfileApl
+	rot.waitingProfile = nil
 	rot.controllingActions = nil
 	rot.inLoop = false
+	rot.numExecutions = 0
 	rot.interruptChannelIf = nil
 	rot.allowChannelRecastOnInterrupt = false
 	for _, action := range rot.allAPLActions() {
 		action.impl.Reset(sim)
 	}
+	for _, variable := range rot.variables {
+		variable.reset()
+	}
+	rot.stagedVariables = rot.stagedVariables[:0]
 }
 
 // We intentionally try to mimic the behavior of simc APL to avoid confusion
@@ -215,8 +306,23 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 			panic(fmt.Sprintf("[USER_ERROR] Infinite loop detected, current action:\n%s", nextAction))
 		}
 
+		// Something became available while the player was idle; they act once they notice.
+		if i == 0 && apl.unit.latency != nil && apl.unit.latency.shouldDelayAction() {
+			apl.discardVariables()
+			apl.inLoop = false
+			apl.unit.scheduleReaction(sim)
+			return
+		}
+
+		apl.commitVariables(sim)
+		if mistake, ok := nextAction.impl.(*APLActionMistake); ok && !mistake.press(sim) {
+			continue
+		}
 		nextAction.Execute(sim)
+		apl.numExecutions++
+		apl.recordExecution(sim, nextAction)
 	}
+	apl.commitVariables(sim)
 	apl.inLoop = false
 
 	if sim.Log != nil && i == 0 {
@@ -224,6 +330,9 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 	}
 
 	gcdReady := apl.unit.GCD.IsReady(sim)
+	if apl.unit.latency != nil {
+		apl.unit.latency.idle = gcdReady
+	}
 	if gcdReady {
 		apl.unit.WaitUntil(sim, sim.CurrentTime+time.Millisecond*50)
 	}
@@ -233,14 +342,58 @@ func (apl *APLRotation) getNextAction(sim *Simulation) *APLAction {
 	if len(apl.controllingActions) != 0 {
 		return apl.controllingActions[len(apl.controllingActions)-1].GetNextAction(sim)
 	}
+	apl.finishWait(sim)
+
+	nextAction, _ := apl.firstReadyAction(sim, apl.priorityList)
+	return nextAction
+}
 
-	for _, action := range apl.priorityList {
-		if action.IsReady(sim) {
-			return action
+// Returns the first ready action in actions, entering any Call/Run Lists along the way
+// and staging variable assignments as they are reached. The second return value is true if a
+// Run List was entered, in which case the caller should not look any further.
+func (apl *APLRotation) firstReadyAction(sim *Simulation, actions []*APLAction) (*APLAction, bool) {
+	for _, action := range actions {
+		profile := apl.profileFor(action)
+		if profile != nil {
+			profile.evaluations.add(sim)
+		}
+		if action.condition != nil && !action.condition.GetBool(sim) {
+			continue
+		}
+		if profile != nil {
+			profile.conditionTrue.add(sim)
+		}
+
+		switch impl := action.impl.(type) {
+		case *APLActionSetVariable:
+			impl.stage(sim, apl, action)
+			continue
+		case *APLActionCallList:
+			if impl.list == nil {
+				continue
+			}
+			nextAction, stop := apl.firstReadyAction(sim, impl.list.actions)
+			if nextAction != nil {
+				return nextAction, false
+			}
+			if profile != nil {
+				profile.blocked.add(sim)
+			}
+			if stop || impl.isRunList {
+				return nil, true
+			}
+			continue
+		}
+
+		if action.impl.IsReady(sim) {
+			return action, false
+		}
+		if profile != nil {
+			profile.blocked.add(sim)
 		}
 	}
 
-	return nil
+	return nil, false
 }
 
 func (apl *APLRotation) pushControllingAction(ca APLActionImpl) {
@@ -269,6 +422,7 @@ func (apl *APLRotation) shouldInterruptChannel(sim *Simulation) bool {
 
 	// Allow next action to interrupt the channel, but if the action is the same action then it still needs to continue.
 	nextAction := apl.getNextAction(sim)
+	apl.discardVariables()
 	if nextAction == nil {
 		return false
 	}
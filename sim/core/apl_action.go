@@ -9,6 +9,9 @@ import (
 type APLAction struct {
 	condition APLValue
 	impl      APLActionImpl
+
+	// Only set for priority list and action list entries.
+	profile *aplEntryProfile
 }
 
 func (action *APLAction) Finalize(rot *APLRotation) {
@@ -166,6 +169,16 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 	case *proto.APLAction_StrictSequence:
 		return rot.newActionStrictSequence(config.GetStrictSequence())
 
+	// Variables and action lists
+	case *proto.APLAction_SetVariable:
+		return rot.newActionSetVariable(config.GetSetVariable())
+	case *proto.APLAction_CallList:
+		return rot.newActionCallList(config.GetCallList())
+	case *proto.APLAction_RunList:
+		return rot.newActionRunList(config.GetRunList())
+	case *proto.APLAction_Mistake:
+		return rot.newActionMistake(config.GetMistake())
+
 	// Misc
 	case *proto.APLAction_ChangeTarget:
 		return rot.newActionChangeTarget(config.GetChangeTarget())
@@ -178,6 +191,12 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 	case *proto.APLAction_ItemSwap:
 		return rot.newActionItemSwap(config.GetItemSwap())
 
+	// Pets
+	case *proto.APLAction_SummonPet:
+		return rot.newActionSummonPet(config.GetSummonPet())
+	case *proto.APLAction_DismissPet:
+		return rot.newActionDismissPet(config.GetDismissPet())
+
 	case *proto.APLAction_CustomRotation:
 		return rot.newActionCustomRotation(config.GetCustomRotation())
 
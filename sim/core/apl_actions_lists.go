@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// A named sub-list of the rotation, see proto.APLActionList.
+type aplActionList struct {
+	name    string
+	actions []*APLAction
+
+	// Index of the config item each action was parsed from.
+	itemConfigIdxs []int
+
+	warnings     []string
+	itemWarnings [][]string
+}
+
+// Implements both Call List and Run List. In the main priority list, a Run List whose
+// list has nothing ready stops evaluation (see APLRotation.firstReadyAction). When nested
+// inside other actions, e.g. sequences, both behave like Call List.
+type APLActionCallList struct {
+	defaultAPLActionImpl
+	rot       *APLRotation
+	listName  string
+	fromList  string
+	isRunList bool
+
+	list *aplActionList
+}
+
+func (rot *APLRotation) newActionCallList(config *proto.APLActionCallList) APLActionImpl {
+	return rot.newActionCallOrRunList(config.ListName, false)
+}
+func (rot *APLRotation) newActionRunList(config *proto.APLActionRunList) APLActionImpl {
+	return rot.newActionCallOrRunList(config.ListName, true)
+}
+func (rot *APLRotation) newActionCallOrRunList(listName string, isRunList bool) APLActionImpl {
+	if listName == "" {
+		rot.ValidationWarning("Call/Run List must provide a list name")
+		return nil
+	}
+	return &APLActionCallList{
+		rot:       rot,
+		listName:  listName,
+		fromList:  rot.parsingList,
+		isRunList: isRunList,
+	}
+}
+func (action *APLActionCallList) Finalize(rot *APLRotation) {
+	list := rot.actionListsByName[action.listName]
+	if list == nil {
+		rot.ValidationWarning("No action list with name: '%s'", action.listName)
+		return
+	}
+	if action.fromList != "" && rot.actionListReaches(action.listName, action.fromList, make(map[string]bool)) {
+		rot.ValidationWarning("Recursive reference to action list '%s', ignoring this action", action.listName)
+		return
+	}
+	action.list = list
+}
+func (action *APLActionCallList) IsReady(sim *Simulation) bool {
+	if action.list == nil {
+		return false
+	}
+	next, _ := action.rot.firstReadyAction(sim, action.list.actions)
+	return next != nil
+}
+func (action *APLActionCallList) Execute(sim *Simulation) {
+	if action.list == nil {
+		return
+	}
+	// Variables in the list were already assigned when it was checked for readiness.
+	next, _ := action.rot.firstReadyAction(sim, action.list.actions)
+	action.rot.discardVariables()
+	if next != nil {
+		next.Execute(sim)
+	}
+}
+func (action *APLActionCallList) String() string {
+	if action.isRunList {
+		return fmt.Sprintf("Run List(%s)", action.listName)
+	}
+	return fmt.Sprintf("Call List(%s)", action.listName)
+}
+
+// Whether the list named from can enter the list named target, directly or through other lists.
+func (rot *APLRotation) actionListReaches(from string, target string, visited map[string]bool) bool {
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+
+	list := rot.actionListsByName[from]
+	if list == nil {
+		return false
+	}
+	for _, listAction := range list.actions {
+		for _, action := range listAction.GetAllActions() {
+			if callList, ok := action.impl.(*APLActionCallList); ok {
+				if callList.listName == target || rot.actionListReaches(callList.listName, target, visited) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func expectNextWait(t *testing.T, sim *Simulation, rot *APLRotation, expected time.Duration) {
+	t.Helper()
+	action := rot.getNextAction(sim)
+	if expected == 0 {
+		if action != nil {
+			t.Fatalf("Expected no action, got %s", action)
+		}
+		return
+	}
+	if action == nil {
+		t.Fatalf("Expected Wait(%s), got no action", expected)
+	}
+	wait, ok := action.impl.(*APLActionWait)
+	if !ok || wait.duration.GetDuration(sim) != expected {
+		t.Fatalf("Expected Wait(%s), got %s", expected, action)
+	}
+}
+
+func TestAPLVariablesAndActionLists(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	rot := fa.Unit.newAPLRotation(APLRotationFromJsonString(`{
+		"type": "TypeAPL",
+		"priorityList": [
+			{"action":{"setVariable":{"name":"counter","value":{"math":{"op":"OpAdd","lhs":{"variable":{"name":"counter"}},"rhs":{"const":{"val":"1"}}}}}}},
+			{"action":{"setVariable":{"name":"late","value":{"cmp":{"op":"OpGt","lhs":{"variable":{"name":"counter"}},"rhs":{"const":{"val":"2"}}}}}}},
+			{"action":{"callList":{"listName":"opener"}}},
+			{"action":{"condition":{"variable":{"name":"late"}},"runList":{"listName":"filler"}}},
+			{"action":{"wait":{"duration":{"const":{"val":"9s"}}}}},
+			{"action":{"callList":{"listName":"missing"}}}
+		],
+		"actionLists": [
+			{"name":"opener","items":[
+				{"action":{"condition":{"cmp":{"op":"OpEq","lhs":{"variable":{"name":"counter"}},"rhs":{"const":{"val":"1"}}}},"wait":{"duration":{"const":{"val":"1s"}}}}}
+			]},
+			{"name":"filler","items":[
+				{"action":{"condition":{"cmp":{"op":"OpEq","lhs":{"variable":{"name":"counter"}},"rhs":{"const":{"val":"3"}}}},"wait":{"duration":{"const":{"val":"2s"}}}}}
+			]},
+			{"name":"loop","items":[
+				{"action":{"callList":{"listName":"loop"}}}
+			]}
+		]
+	}`))
+
+	if rot.variables["late"].valType != proto.APLValueType_ValueTypeBool {
+		t.Fatalf("Variable 'late' should be a bool, got %s", rot.variables["late"].valType)
+	}
+
+	stats := rot.getStats()
+	if warnings := stats.PriorityList[5].Warnings; len(warnings) != 1 || !strings.Contains(warnings[0], "No action list") {
+		t.Fatalf("Expected missing list warning, got %v", warnings)
+	}
+	if warnings := stats.ActionLists[2].Items[0].Warnings; len(warnings) != 1 || !strings.Contains(warnings[0], "Recursive") {
+		t.Fatalf("Expected recursive list warning, got %v", warnings)
+	}
+
+	rot.reset(sim)
+	expectNextWait(t, sim, rot, time.Second*1) // counter = 1, from the called list.
+	expectNextWait(t, sim, rot, time.Second*9) // counter = 2, falls through the called list.
+	expectNextWait(t, sim, rot, time.Second*2) // counter = 3, from the run list.
+	expectNextWait(t, sim, rot, 0)             // counter = 4, the run list stops evaluation.
+
+	// Variables are cleared between iterations.
+	rot.reset(sim)
+	expectNextWait(t, sim, rot, time.Second*1)
+}
+
+func TestAPLVariablesOnlyAssignedWhenActing(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	rot := fa.Unit.newAPLRotation(APLRotationFromJsonString(`{
+		"type": "TypeAPL",
+		"priorityList": [
+			{"action":{"setVariable":{"name":"counter","value":{"math":{"op":"OpAdd","lhs":{"variable":{"name":"counter"}},"rhs":{"const":{"val":"1"}}}}}}},
+			{"action":{"callList":{"listName":"check"}}},
+			{"action":{"wait":{"duration":{"const":{"val":"1s"}}}}}
+		],
+		"actionLists": [
+			{"name":"check","items":[
+				{"action":{"setVariable":{"name":"checked","value":{"const":{"val":"true"}}}}}
+			]}
+		]
+	}`))
+	fa.Unit.Rotation = rot
+	rot.reset(sim)
+	counter := rot.variables["counter"]
+	checked := rot.variables["checked"]
+
+	// Checking whether to interrupt a channel selects the next action, but doesn't act on it.
+	fa.Dot.Apply(sim)
+	fa.Unit.ChanneledDot = fa.Dot
+	rot.interruptChannelIf = rot.newValueConst(&proto.APLValueConst{Val: "true"})
+	if !rot.shouldInterruptChannel(sim) {
+		t.Fatalf("Expected the wait to interrupt the channel")
+	}
+	if counter.current.floatVal != 0 || checked.current.boolVal {
+		t.Fatalf("Checking the channel assigned variables: counter = %v, checked = %v", counter.current.floatVal, checked.current.boolVal)
+	}
+
+	fa.Unit.ChanneledDot = nil
+	rot.DoNextAction(sim)
+	if counter.current.floatVal != 1 || !checked.current.boolVal {
+		t.Fatalf("Acting didn't assign variables: counter = %v, checked = %v", counter.current.floatVal, checked.current.boolVal)
+	}
+}
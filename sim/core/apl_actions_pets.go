@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type APLActionSummonPet struct {
+	defaultAPLActionImpl
+	petAgent PetAgent
+	pet      *Pet
+}
+
+func (rot *APLRotation) newActionSummonPet(config *proto.APLActionSummonPet) APLActionImpl {
+	petAgent := rot.GetAPLPet(config.Pet)
+	if petAgent == nil {
+		return nil
+	}
+	pet := petAgent.GetPet()
+	if pet.SummonSpell == nil && pet.IsGuardian() {
+		rot.ValidationWarning("%s can only be summoned by its own spell", pet.Label)
+		return nil
+	}
+	return &APLActionSummonPet{
+		petAgent: petAgent,
+		pet:      pet,
+	}
+}
+func (action *APLActionSummonPet) IsReady(sim *Simulation) bool {
+	if action.pet.IsEnabled() {
+		return false
+	}
+	if spell := action.pet.SummonSpell; spell != nil {
+		return spell.CanCast(sim, spell.Unit.CurrentTarget)
+	}
+	return true
+}
+func (action *APLActionSummonPet) Execute(sim *Simulation) {
+	if spell := action.pet.SummonSpell; spell != nil {
+		spell.Cast(sim, spell.Unit.CurrentTarget)
+		return
+	}
+
+	// Permanent pets without a dedicated spell are summoned instantly, e.g. before the pull.
+	if sim.Log != nil {
+		action.pet.Owner.Log(sim, "Summoning pet %s", action.pet.Label)
+	}
+	action.pet.Enable(sim, action.petAgent)
+}
+func (action *APLActionSummonPet) String() string {
+	return fmt.Sprintf("Summon Pet(%s)", action.pet.Label)
+}
+
+type APLActionDismissPet struct {
+	defaultAPLActionImpl
+	pet *Pet
+}
+
+func (rot *APLRotation) newActionDismissPet(config *proto.APLActionDismissPet) APLActionImpl {
+	petAgent := rot.GetAPLPet(config.Pet)
+	if petAgent == nil {
+		return nil
+	}
+	return &APLActionDismissPet{
+		pet: petAgent.GetPet(),
+	}
+}
+func (action *APLActionDismissPet) IsReady(sim *Simulation) bool {
+	return action.pet.IsEnabled()
+}
+func (action *APLActionDismissPet) Execute(sim *Simulation) {
+	if sim.Log != nil {
+		action.pet.Owner.Log(sim, "Dismissing pet %s", action.pet.Label)
+	}
+	action.pet.Disable(sim)
+}
+func (action *APLActionDismissPet) String() string {
+	return fmt.Sprintf("Dismiss Pet(%s)", action.pet.Label)
+}
@@ -160,3 +160,115 @@ func (action *APLActionSchedule) Execute(sim *Simulation) {
 func (action *APLActionSchedule) String() string {
 	return fmt.Sprintf("Schedule(%s, %s)", action.timings, action.innerAction)
 }
+
+// Default for how long a missed press is ignored for if nothing else is performed in the meantime.
+const aplMistakeMaxSkip = time.Millisecond * 1500
+
+type APLActionMistake struct {
+	defaultAPLActionImpl
+	rot         *APLRotation
+	innerAction *APLAction
+	chance      APLValue
+	delay       APLValue
+	maxSkip     APLValue
+
+	ignoredUntil time.Duration
+	// Set when the press was skipped rather than delayed, in which case it is also
+	// noticed once the rotation performs another action.
+	skipped          bool
+	skippedAtActions int
+
+	// A fumbled press isn't rolled again until it's executed.
+	fumbled bool
+	// Set once the roll passed for the press about to be executed.
+	pressed bool
+}
+
+func (rot *APLRotation) newActionMistake(config *proto.APLActionMistake) APLActionImpl {
+	innerAction := rot.newAPLAction(config.Action)
+	if innerAction == nil {
+		return nil
+	}
+	chance := rot.coerceTo(rot.newAPLValue(config.Chance), proto.APLValueType_ValueTypeFloat)
+	if chance == nil {
+		rot.ValidationWarning("Mistake must provide a chance")
+		return nil
+	}
+
+	return &APLActionMistake{
+		rot:         rot,
+		innerAction: innerAction,
+		chance:      chance,
+		delay:       rot.coerceTo(rot.newAPLValue(config.Delay), proto.APLValueType_ValueTypeDuration),
+		maxSkip:     rot.coerceTo(rot.newAPLValue(config.MaxSkip), proto.APLValueType_ValueTypeDuration),
+	}
+}
+func (action *APLActionMistake) GetInnerActions() []*APLAction {
+	return action.innerAction.GetAllActions()
+}
+func (action *APLActionMistake) GetAPLValues() []APLValue {
+	values := []APLValue{action.chance}
+	if action.delay != nil {
+		values = append(values, action.delay)
+	}
+	if action.maxSkip != nil {
+		values = append(values, action.maxSkip)
+	}
+	return values
+}
+func (action *APLActionMistake) Finalize(rot *APLRotation) {
+	action.innerAction.impl.Finalize(rot)
+}
+func (action *APLActionMistake) Reset(*Simulation) {
+	action.ignoredUntil = 0
+	action.skipped = false
+	action.fumbled = false
+	action.pressed = false
+}
+func (action *APLActionMistake) IsReady(sim *Simulation) bool {
+	if !action.innerAction.IsReady(sim) {
+		return false
+	}
+	return sim.CurrentTime >= action.ignoredUntil || (action.skipped && action.rot.numExecutions > action.skippedAtActions)
+}
+
+// Rolls for a mistake once the rotation acts on this entry, so checks which only select the
+// next action don't use up the roll. Returns false if the press was fumbled, in which case
+// the entry is ignored for a while and the rotation picks something else.
+func (action *APLActionMistake) press(sim *Simulation) bool {
+	if action.pressed {
+		return true
+	}
+	if !action.fumbled && sim.Proc(action.chance.GetFloat(sim), "APL Mistake") {
+		delay := time.Duration(0)
+		if action.delay != nil {
+			delay = action.delay.GetDuration(sim)
+		}
+		action.skipped = delay <= 0
+		if action.skipped {
+			delay = aplMistakeMaxSkip
+			if action.maxSkip != nil {
+				delay = action.maxSkip.GetDuration(sim)
+			}
+			action.skippedAtActions = action.rot.numExecutions
+		}
+		action.fumbled = true
+		action.ignoredUntil = sim.CurrentTime + delay
+		if sim.Log != nil {
+			action.rot.unit.Log(sim, "Mistake: ignoring %s for up to %s", action.innerAction.impl, delay)
+		}
+		return false
+	}
+	action.pressed = true
+	return true
+}
+func (action *APLActionMistake) Execute(sim *Simulation) {
+	if !action.press(sim) {
+		return
+	}
+	action.Reset(sim)
+	action.innerAction.Execute(sim)
+}
+func (action *APLActionMistake) String() string {
+	return fmt.Sprintf("Mistake(%s, %s)", action.chance, action.innerAction)
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAPLMistake(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	rot := fa.Unit.newAPLRotation(APLRotationFromJsonString(`{
+		"type": "TypeAPL",
+		"priorityList": [
+			{"action":{"mistake":{"chance":{"const":{"val":"100%"}},"delay":{"const":{"val":"2s"}},"action":{"wait":{"duration":{"const":{"val":"1s"}}}}}}},
+			{"action":{"mistake":{"chance":{"const":{"val":"1"}},"action":{"wait":{"duration":{"const":{"val":"3s"}}}}}}},
+			{"action":{"mistake":{"chance":{"const":{"val":"0"}},"action":{"wait":{"duration":{"const":{"val":"4s"}}}}}}},
+			{"action":{"mistake":{"chance":{"const":{"val":"1"}},"maxSkip":{"const":{"val":"500ms"}},"action":{"wait":{"duration":{"const":{"val":"5s"}}}}}}}
+		]
+	}`))
+	delayed, skipped, neverMissed, shortSkip := rot.priorityList[0], rot.priorityList[1], rot.priorityList[2], rot.priorityList[3]
+
+	rot.reset(sim)
+	// Checking readiness doesn't roll, so every entry is ready until the rotation acts on it.
+	for i := 0; i < 2; i++ {
+		if !delayed.IsReady(sim) || !skipped.IsReady(sim) || !neverMissed.IsReady(sim) || !shortSkip.IsReady(sim) {
+			t.Fatalf("Expected every entry to be ready before it's pressed")
+		}
+	}
+
+	press := func(action *APLAction) bool {
+		return action.impl.(*APLActionMistake).press(sim)
+	}
+	if press(delayed) || press(skipped) || !press(neverMissed) || press(shortSkip) {
+		t.Fatalf("Expected only the entry with 0 chance to be pressed")
+	}
+	if delayed.IsReady(sim) || skipped.IsReady(sim) || shortSkip.IsReady(sim) {
+		t.Fatalf("Expected fumbled entries to be ignored")
+	}
+
+	sim.CurrentTime = time.Millisecond * 500
+	if !shortSkip.IsReady(sim) {
+		t.Fatalf("Expected the skipped entry to be ready after its max skip")
+	}
+	sim.CurrentTime = 0
+
+	// Skipped presses are noticed once another action is performed.
+	rot.numExecutions++
+	if !skipped.IsReady(sim) {
+		t.Fatalf("Expected the skipped entry to be ready after another action")
+	}
+
+	sim.CurrentTime = time.Second
+	if delayed.IsReady(sim) {
+		t.Fatalf("Expected the delayed entry to be ignored for 2s")
+	}
+	sim.CurrentTime = time.Second * 2
+	if !delayed.IsReady(sim) {
+		t.Fatalf("Expected the delayed entry to be ready after 2s")
+	}
+	// A fumbled press isn't rolled again.
+	if !press(delayed) {
+		t.Fatalf("Expected the delayed entry to be pressed once noticed")
+	}
+}
+
+func TestAPLMistakeRolledWhenActing(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	rot := fa.Unit.newAPLRotation(APLRotationFromJsonString(`{
+		"type": "TypeAPL",
+		"priorityList": [
+			{"action":{"mistake":{"chance":{"const":{"val":"1"}},"delay":{"const":{"val":"2s"}},"action":{"wait":{"duration":{"const":{"val":"1s"}}}}}}},
+			{"action":{"wait":{"duration":{"const":{"val":"3s"}}}}}
+		]
+	}`))
+	fa.Unit.Rotation = rot
+	rot.reset(sim)
+	mistake := rot.priorityList[0]
+
+	// Only selecting the next action, as when checking whether to interrupt a channel, doesn't roll.
+	if rot.getNextAction(sim) != mistake || rot.getNextAction(sim) != mistake {
+		t.Fatalf("Expected the mistake entry to be selected")
+	}
+
+	// Acting on it fumbles the press, so the rotation picks the next entry instead.
+	rot.DoNextAction(sim)
+	if len(rot.controllingActions) != 1 || rot.controllingActions[0] != rot.priorityList[1].impl {
+		t.Fatalf("Expected the 3s wait after the fumbled press, got %v", rot.controllingActions)
+	}
+	if rot.numExecutions != 1 {
+		t.Fatalf("Expected the fumbled press not to count as an execution, got %d", rot.numExecutions)
+	}
+}
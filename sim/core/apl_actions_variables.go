@@ -0,0 +1,73 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type APLActionSetVariable struct {
+	defaultAPLActionImpl
+	variable *aplVariable
+	value    APLValue
+}
+
+func (rot *APLRotation) newActionSetVariable(config *proto.APLActionSetVariable) APLActionImpl {
+	if config.Name == "" {
+		rot.ValidationWarning("Set Variable must provide a variable name")
+		return nil
+	}
+
+	// Values were already built while declaring variables, so only replay their warnings here.
+	variableValue := rot.variableValues[config]
+	for _, warning := range variableValue.warnings {
+		rot.ValidationWarning("%s", warning)
+	}
+	if variableValue.value == nil {
+		return nil
+	}
+
+	variable := rot.variables[config.Name]
+	return &APLActionSetVariable{
+		variable: variable,
+		value:    rot.coerceTo(variableValue.value, variable.valType),
+	}
+}
+func (action *APLActionSetVariable) GetAPLValues() []APLValue {
+	return []APLValue{action.value}
+}
+func (action *APLActionSetVariable) IsReady(sim *Simulation) bool {
+	return true
+}
+func (action *APLActionSetVariable) Execute(sim *Simulation) {
+	action.variable.set(sim, action.value)
+}
+
+// Assigns the variable while selecting the next action. The value is only committed once
+// the rotation acts on the selection, see APLRotation.commitVariables.
+func (action *APLActionSetVariable) stage(sim *Simulation, rot *APLRotation, entry *APLAction) {
+	action.variable.stage(sim, action.value)
+	rot.stagedVariables = append(rot.stagedVariables, entry)
+}
+func (action *APLActionSetVariable) String() string {
+	return fmt.Sprintf("Set Variable(%s = %s)", action.variable.name, action.value)
+}
+
+// Commits the variables staged while selecting the current action.
+func (rot *APLRotation) commitVariables(sim *Simulation) {
+	for _, entry := range rot.stagedVariables {
+		entry.impl.(*APLActionSetVariable).variable.commit()
+		if profile := rot.profileFor(entry); profile != nil {
+			profile.executions.add(sim)
+		}
+	}
+	rot.stagedVariables = rot.stagedVariables[:0]
+}
+
+// Drops the variables staged while selecting, for selections which aren't acted on.
+func (rot *APLRotation) discardVariables() {
+	for _, entry := range rot.stagedVariables {
+		entry.impl.(*APLActionSetVariable).variable.isStaged = false
+	}
+	rot.stagedVariables = rot.stagedVariables[:0]
+}
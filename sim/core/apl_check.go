@@ -0,0 +1,227 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Static checks on an APL rotation, which catch mistakes without running the sim.
+func CheckAPL(request *proto.APLCheckRequest) (result *proto.APLCheckResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.APLCheckResult{ErrorResult: fmt.Sprintf("%v", err)}
+		}
+	}()
+
+	if request.Player == nil {
+		return &proto.APLCheckResult{ErrorResult: "aplcheck: no player"}
+	}
+	player := googleProto.Clone(request.Player).(*proto.Player)
+	if player.Rotation == nil || player.Rotation.Type != proto.APLRotation_TypeAPL {
+		return &proto.APLCheckResult{ErrorResult: "aplcheck: player has no APL rotation"}
+	}
+	if player.Equipment == nil {
+		player.Equipment = &proto.EquipmentSpec{}
+	}
+
+	encounter := request.Encounter
+	if encounter == nil {
+		encounter = &proto.Encounter{}
+	}
+
+	// Build the unit the same way as ComputeStats, including the fake prepull which
+	// reports prepull actions that fail to cast.
+	raid := &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{player}}}}
+	env, _, _ := NewEnvironment(raid, encounter, true)
+	rot := env.Raid.Parties[0].Players[0].GetCharacter().Rotation
+
+	return &proto.APLCheckResult{Issues: rot.check()}
+}
+
+type aplCheckEntry struct {
+	listName string
+	action   *APLAction
+}
+
+func (rot *APLRotation) check() []*proto.APLCheckIssue {
+	var issues []*proto.APLCheckIssue
+	addIssue := func(severity proto.APLCheckSeverity, check string, prepull bool, listName string, entryIdx int, message string, vals ...interface{}) {
+		issues = append(issues, &proto.APLCheckIssue{
+			Severity:   severity,
+			Check:      check,
+			Prepull:    prepull,
+			ListName:   listName,
+			EntryIndex: int32(entryIdx),
+			Message:    fmt.Sprintf(message, vals...),
+		})
+	}
+
+	// Anything reported during parsing means part of the rotation is ignored.
+	stats := rot.getStats()
+	for i, entry := range stats.PrepullActions {
+		for _, warning := range entry.Warnings {
+			addIssue(proto.APLCheckSeverity_APLCheckSeverityError, "validation", true, "", i, "%s", warning)
+		}
+	}
+	for i, entry := range stats.PriorityList {
+		for _, warning := range entry.Warnings {
+			addIssue(proto.APLCheckSeverity_APLCheckSeverityError, "validation", false, "", i, "%s", warning)
+		}
+	}
+	for listIdx, list := range stats.ActionLists {
+		listName := rot.actionLists[listIdx].name
+		for _, warning := range list.Warnings {
+			addIssue(proto.APLCheckSeverity_APLCheckSeverityError, "validation", false, listName, -1, "%s", warning)
+		}
+		for i, entry := range list.Items {
+			for _, warning := range entry.Warnings {
+				addIssue(proto.APLCheckSeverity_APLCheckSeverityError, "validation", false, listName, i, "%s", warning)
+			}
+		}
+	}
+
+	entries := MapSlice(rot.priorityList, func(action *APLAction) aplCheckEntry { return aplCheckEntry{action: action} })
+	for _, list := range rot.actionLists {
+		entries = append(entries, MapSlice(list.actions, func(action *APLAction) aplCheckEntry {
+			return aplCheckEntry{listName: list.name, action: action}
+		})...)
+	}
+
+	// Conditions which never change, and entries which can never be reached because an
+	// earlier entry is always taken instead.
+	var shadowingEntry *aplCheckEntry
+	for i := range entries {
+		entry := &entries[i]
+		entryIdx := entry.action.profile.configIdx
+		if shadowingEntry != nil && shadowingEntry.listName != entry.listName {
+			shadowingEntry = nil
+		}
+
+		if shadowingEntry != nil && aplActionShadows(shadowingEntry.action, entry.action) {
+			addIssue(proto.APLCheckSeverity_APLCheckSeverityWarning, "unreachable", false, entry.listName, entryIdx,
+				"Never reached, because entry %d (%s) is always taken first", shadowingEntry.action.profile.configIdx, shadowingEntry.action.impl)
+			continue
+		}
+
+		condition, isConst := foldAPLCondition(entry.action.condition)
+		if isConst && !condition {
+			addIssue(proto.APLCheckSeverity_APLCheckSeverityWarning, "always-false", false, entry.listName, entryIdx,
+				"Condition is always false, so this entry never runs")
+			continue
+		}
+		if isConst && entry.action.condition != nil {
+			addIssue(proto.APLCheckSeverity_APLCheckSeverityWarning, "always-true", false, entry.listName, entryIdx,
+				"Condition is always true and can be removed")
+		}
+		if isConst && shadowingEntry == nil && aplActionAlwaysReady(entry.action, entry.listName) {
+			shadowingEntry = entry
+		}
+	}
+
+	// Sequences only run once, unless something resets them.
+	resetSequences := make(map[string]bool)
+	for _, action := range rot.allAPLActions() {
+		if reset, ok := action.impl.(*APLActionResetSequence); ok {
+			resetSequences[reset.name] = true
+		}
+	}
+	for _, entry := range entries {
+		for _, action := range entry.action.GetAllActions() {
+			if sequence, ok := action.impl.(*APLActionSequence); ok && !resetSequences[sequence.name] {
+				name := ""
+				if sequence.name != "" {
+					name = fmt.Sprintf(" '%s'", sequence.name)
+				}
+				addIssue(proto.APLCheckSeverity_APLCheckSeverityWarning, "never-reset", false, entry.listName, entry.action.profile.configIdx,
+					"Sequence%s is never reset, so it only runs once per iteration", name)
+			}
+		}
+	}
+
+	return issues
+}
+
+// Whether value only depends on constants, so it has the same result every time.
+func isConstAPLValue(value APLValue) bool {
+	switch value.(type) {
+	case *APLValueConst:
+		return true
+	case *APLValueCoerced, *APLValueCompare, *APLValueMath, *APLValueMax, *APLValueMin, *APLValueAnd, *APLValueOr, *APLValueNot:
+		inner := value.GetInnerValues()
+		for _, innerValue := range inner {
+			if innerValue == nil || !isConstAPLValue(innerValue) {
+				return false
+			}
+		}
+		return len(inner) > 0
+	default:
+		return false
+	}
+}
+
+// Returns the value of condition and true if it can be determined without running the sim.
+// A missing condition is always true.
+func foldAPLCondition(condition APLValue) (bool, bool) {
+	switch value := condition.(type) {
+	case nil:
+		return true, true
+	case *APLValueAnd:
+		allTrue := true
+		for _, val := range value.vals {
+			result, isConst := foldAPLCondition(val)
+			if isConst && !result {
+				return false, true
+			}
+			allTrue = allTrue && isConst
+		}
+		return true, allTrue
+	case *APLValueOr:
+		allFalse := true
+		for _, val := range value.vals {
+			result, isConst := foldAPLCondition(val)
+			if isConst && result {
+				return true, true
+			}
+			allFalse = allFalse && isConst
+		}
+		return false, allFalse
+	case *APLValueNot:
+		result, isConst := foldAPLCondition(value.val)
+		return !result, isConst
+	}
+
+	if isConstAPLValue(condition) {
+		return condition.GetBool(nil), true
+	}
+	return false, false
+}
+
+// Whether action is ready every time it is evaluated, ignoring its condition.
+func aplActionAlwaysReady(action *APLAction, listName string) bool {
+	switch impl := action.impl.(type) {
+	case *APLActionWait:
+		return isConstAPLValue(impl.duration) && impl.duration.GetDuration(nil) > 0
+	case *APLActionCallList:
+		// Run List stops evaluation of the main priority list whether or not its list has
+		// anything ready.
+		return impl.isRunList && listName == "" && impl.list != nil
+	case *APLActionCastSpell:
+		spell := impl.spell
+		return spell.DefaultCast.GCD > 0 && spell.Cost == nil && spell.CD.Timer == nil && spell.SharedCD.Timer == nil &&
+			spell.ExtraCastCondition == nil && !spell.Flags.Matches(SpellFlagMCD)
+	default:
+		return false
+	}
+}
+
+// Whether an always ready earlier entry prevents later from ever running.
+func aplActionShadows(earlier *APLAction, later *APLAction) bool {
+	if _, ok := earlier.impl.(*APLActionCastSpell); !ok {
+		return true
+	}
+	// A spell on the GCD only blocks other spells on the GCD.
+	castSpell, ok := later.impl.(*APLActionCastSpell)
+	return ok && castSpell.spell.DefaultCast.GCD > 0
+}
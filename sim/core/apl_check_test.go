@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestAPLCheck(t *testing.T) {
+	result := CheckAPL(&proto.APLCheckRequest{
+		Player: &proto.Player{
+			Name:  "Caster",
+			Class: proto.Class_ClassShaman,
+			Spec:  &proto.Player_ElementalShaman{},
+			Rotation: APLRotationFromJsonString(`{
+				"type": "TypeAPL",
+				"priorityList": [
+					{"action":{"castSpell":{"spellId":{"spellId":12345}}}},
+					{"action":{"condition":{"cmp":{"op":"OpGt","lhs":{"const":{"val":"1"}},"rhs":{"const":{"val":"2"}}}},"castSpell":{"spellId":{"spellId":42}}}},
+					{"action":{"condition":{"and":{"vals":[{"cmp":{"op":"OpLt","lhs":{"const":{"val":"1"}},"rhs":{"const":{"val":"2"}}}}]}},"sequence":{"name":"opener","actions":[{"castSpell":{"spellId":{"spellId":42}}}]}}},
+					{"action":{"condition":{"or":{"vals":[{"currentTime":{}},{"cmp":{"op":"OpLt","lhs":{"currentTime":{}},"rhs":{"const":{"val":"1s"}}}}]}},"castSpell":{"spellId":{"spellId":42}}}},
+					{"action":{"wait":{"duration":{"const":{"val":"1s"}}}}},
+					{"action":{"castSpell":{"spellId":{"spellId":42}}}}
+				]
+			}`),
+		},
+	})
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+
+	expected := []struct {
+		check    string
+		entryIdx int32
+		severity proto.APLCheckSeverity
+	}{
+		{"validation", 0, proto.APLCheckSeverity_APLCheckSeverityError},
+		{"always-false", 1, proto.APLCheckSeverity_APLCheckSeverityWarning},
+		{"always-true", 2, proto.APLCheckSeverity_APLCheckSeverityWarning},
+		{"unreachable", 5, proto.APLCheckSeverity_APLCheckSeverityWarning},
+		{"never-reset", 2, proto.APLCheckSeverity_APLCheckSeverityWarning},
+	}
+	if len(result.Issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %v", len(expected), result.Issues)
+	}
+	for i, issue := range result.Issues {
+		if issue.Check != expected[i].check || issue.EntryIndex != expected[i].entryIdx || issue.Severity != expected[i].severity {
+			t.Errorf("Expected %s at entry %d, got %v", expected[i].check, expected[i].entryIdx, issue)
+		}
+	}
+}
@@ -57,6 +57,25 @@ func (rot *APLRotation) GetTargetUnit(ref *proto.UnitReference) UnitReference {
 	return rot.getUnit(ref, &proto.UnitReference{Type: proto.UnitReference_CurrentTarget})
 }
 
+// Returns the pet agent referenced by ref, which must be one of this unit's pets.
+func (rot *APLRotation) GetAPLPet(ref *proto.UnitReference) PetAgent {
+	if ref == nil || ref.Type != proto.UnitReference_Pet {
+		rot.ValidationWarning("Unit reference is not a pet: %s", ref)
+		return nil
+	}
+
+	unit := rot.GetSourceUnit(ref).Get()
+	if unit == nil {
+		return nil
+	}
+	petAgent, ok := rot.unit.Env.GetAgentFromUnit(unit).(PetAgent)
+	if !ok {
+		rot.ValidationWarning("Unit reference is not a pet: %s", ref)
+		return nil
+	}
+	return petAgent
+}
+
 type AuraReference struct {
 	fixedAura *Aura
 
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Execution statistics for a single priority list or action list entry, collected
+// when SimOptions.ProfileApl is set. Counts are totals across all iterations.
+type aplEntryProfile struct {
+	listName  string
+	configIdx int
+
+	evaluations   aplEntryCount
+	conditionTrue aplEntryCount
+	executions    aplEntryCount
+	blocked       aplEntryCount
+	waitTime      time.Duration
+}
+
+// A count which only increases once per point in time. The list is checked again after
+// each off-GCD action, and a list may be called from several entries, so every count
+// follows the same rule to keep them comparable, e.g. executions never exceed conditionTrue.
+type aplEntryCount struct {
+	total     int64
+	countedAt time.Duration
+}
+
+func (count *aplEntryCount) add(sim *Simulation) {
+	if count.countedAt == sim.CurrentTime {
+		return
+	}
+	count.countedAt = sim.CurrentTime
+	count.total++
+}
+
+func (profile *aplEntryProfile) resetCountedAt() {
+	profile.evaluations.countedAt = -NeverExpires
+	profile.conditionTrue.countedAt = -NeverExpires
+	profile.executions.countedAt = -NeverExpires
+	profile.blocked.countedAt = -NeverExpires
+}
+
+func (rot *APLRotation) newEntryProfile(action *APLAction, listName string, configIdx int) {
+	action.profile = &aplEntryProfile{
+		listName:  listName,
+		configIdx: configIdx,
+	}
+	action.profile.resetCountedAt()
+	rot.entryProfiles = append(rot.entryProfiles, action.profile)
+}
+
+// Returns the profile for action if profiling is enabled and action is a list entry.
+func (rot *APLRotation) profileFor(action *APLAction) *aplEntryProfile {
+	if !rot.profiling {
+		return nil
+	}
+	return action.profile
+}
+
+func (rot *APLRotation) recordExecution(sim *Simulation, action *APLAction) {
+	profile := rot.profileFor(action)
+	if profile == nil {
+		return
+	}
+	profile.executions.add(sim)
+
+	switch action.impl.(type) {
+	case *APLActionWait, *APLActionWaitUntil:
+		rot.waitingProfile = profile
+		rot.waitStart = sim.CurrentTime
+	}
+}
+
+// Invoked once no action is controlling the rotation anymore.
+func (rot *APLRotation) finishWait(sim *Simulation) {
+	if rot.waitingProfile != nil {
+		rot.waitingProfile.waitTime += sim.CurrentTime - rot.waitStart
+		rot.waitingProfile = nil
+	}
+}
+
+func (rot *APLRotation) doneIteration(sim *Simulation) {
+	if !rot.profiling {
+		return
+	}
+	rot.finishWait(sim)
+	rot.profiledIterations++
+	for _, profile := range rot.entryProfiles {
+		profile.resetCountedAt()
+	}
+}
+
+func (rot *APLRotation) getEntryMetricsProto() []*proto.APLEntryMetrics {
+	if rot.profiledIterations == 0 {
+		return nil
+	}
+
+	n := float64(rot.profiledIterations)
+	return MapSlice(rot.entryProfiles, func(profile *aplEntryProfile) *proto.APLEntryMetrics {
+		metrics := &proto.APLEntryMetrics{
+			ListName:      profile.listName,
+			Index:         int32(profile.configIdx),
+			Evaluations:   float64(profile.evaluations.total) / n,
+			ConditionTrue: float64(profile.conditionTrue.total) / n,
+			Executions:    float64(profile.executions.total) / n,
+			Blocked:       float64(profile.blocked.total) / n,
+		}
+		if profile.executions.total > 0 {
+			metrics.AvgWaitSeconds = profile.waitTime.Seconds() / float64(profile.executions.total)
+		}
+		return metrics
+	})
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func findEntryMetrics(t *testing.T, entries []*proto.APLEntryMetrics, listName string, index int32) *proto.APLEntryMetrics {
+	t.Helper()
+	for _, entry := range entries {
+		if entry.ListName == listName && entry.Index == index {
+			return entry
+		}
+	}
+	t.Fatalf("No metrics for entry %d of list %q in %v", index, listName, entries)
+	return nil
+}
+
+func TestAPLProfile(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.Options.ProfileApl = true
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	rot := fa.Unit.newAPLRotation(APLRotationFromJsonString(`{
+		"type": "TypeAPL",
+		"priorityList": [
+			{"hide": true, "action":{"wait":{"duration":{"const":{"val":"1s"}}}}},
+			{"action":{"setVariable":{"name":"passes","value":{"math":{"op":"OpAdd","lhs":{"variable":{"name":"passes"}},"rhs":{"const":{"val":"1"}}}}}}},
+			{"action":{"callList":{"listName":"check"}}},
+			{"action":{"callList":{"listName":"check"}}},
+			{"action":{"condition":{"cmp":{"op":"OpGt","lhs":{"currentTime":{}},"rhs":{"const":{"val":"5s"}}}},"wait":{"duration":{"const":{"val":"0s"}}}}},
+			{"action":{"condition":{"variable":{"name":"ready"}},"wait":{"duration":{"const":{"val":"2s"}}}}},
+			{"action":{"sequence":{"name":"init","actions":[{"setVariable":{"name":"ready","value":{"const":{"val":"true"}}}}]}}}
+		],
+		"actionLists": [
+			{"name":"check","items":[
+				{"action":{"condition":{"cmp":{"op":"OpGt","lhs":{"currentTime":{}},"rhs":{"const":{"val":"100s"}}}},"wait":{"duration":{"const":{"val":"3s"}}}}}
+			]}
+		]
+	}`))
+	fa.Unit.Rotation = rot
+	rot.reset(sim)
+
+	// At 0s the sequence runs first, making the 2s wait ready on the next pass. The wait then
+	// repeats at 2s, 4s and 6s, where the conditional wait passes its condition but isn't ready.
+	rot.DoNextAction(sim)
+	for sim.CurrentTime < time.Second*7 {
+		sim.Step()
+	}
+	rot.doneIteration(sim)
+
+	entries := rot.getEntryMetricsProto()
+	if len(entries) != 7 {
+		t.Fatalf("Expected metrics for every visible entry, got %v", entries)
+	}
+
+	// Both passes at 0s assign the variable, but each entry counts once per point in time.
+	if entry := findEntryMetrics(t, entries, "", 1); entry.Evaluations != 4 || entry.ConditionTrue != 4 || entry.Executions != 4 {
+		t.Fatalf("Unexpected set variable stats: %v", entry)
+	}
+	// The called list is entered twice per pass, but its entry only counts once.
+	if entry := findEntryMetrics(t, entries, "check", 0); entry.Evaluations != 4 || entry.ConditionTrue != 0 {
+		t.Fatalf("Unexpected called list entry stats: %v", entry)
+	}
+	if entry := findEntryMetrics(t, entries, "", 3); entry.Evaluations != 4 || entry.ConditionTrue != 4 || entry.Blocked != 4 {
+		t.Fatalf("Unexpected call list stats: %v", entry)
+	}
+	if entry := findEntryMetrics(t, entries, "", 4); entry.Evaluations != 4 || entry.ConditionTrue != 1 || entry.Blocked != 1 || entry.Executions != 0 {
+		t.Fatalf("Unexpected conditional wait stats: %v", entry)
+	}
+	// The wait's condition fails on the first pass at 0s and passes on the second, which still
+	// counts, so it never executes more often than its condition passes.
+	if entry := findEntryMetrics(t, entries, "", 5); entry.Evaluations != 4 || entry.ConditionTrue != 4 || entry.Executions != 4 || entry.AvgWaitSeconds != 1.75 {
+		t.Fatalf("Unexpected wait stats: %v", entry)
+	}
+	if entry := findEntryMetrics(t, entries, "", 6); entry.Evaluations != 1 || entry.ConditionTrue != 1 || entry.Executions != 1 {
+		t.Fatalf("Unexpected sequence stats: %v", entry)
+	}
+}
@@ -0,0 +1,432 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	goproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+const (
+	defaultAPLTuneBudget     = 100000
+	defaultAPLTuneCandidates = 32
+	minAPLTuneIterations     = 20
+	aplTuneSensitivityPoints = 9
+)
+
+// Splits a const like '1.5s' or '20%' into its number and unit suffix.
+var aplTunableConstRegex = regexp.MustCompile(`^(-?[0-9]*\.?[0-9]+)([a-z%]*)$`)
+
+// aplTuner searches for the best values of a set of rotation constants, and optionally
+// the best order of some priority list entries, using successive halving over random
+// candidates followed by a local search around the winner.
+//
+// Every sim run uses the same random seed, so differences between candidates come from
+// the rotation rather than from noise.
+type aplTuner struct {
+	// SingleRaidSimRunner used to evaluate each candidate.
+	SingleRaidSimRunner raidSimRunner
+	// Request used for this tuning run.
+	Request *proto.APLTuneRequest
+
+	baseRotation *proto.APLRotation
+	baseValues   []float64
+	suffixes     []string
+	permutable   []int32
+
+	seed int64
+	rng  *rand.Rand
+}
+
+type aplTuneCandidate struct {
+	values []float64
+	order  []int32
+	dps    float64
+}
+
+func TuneAPL(request *proto.APLTuneRequest) *proto.APLTuneResult {
+	tuner := &aplTuner{
+		SingleRaidSimRunner: runSim,
+		Request:             request,
+	}
+
+	result, err := tuner.Run()
+	if err != nil {
+		return &proto.APLTuneResult{
+			ErrorResult: err.Error(),
+		}
+	}
+	return result
+}
+
+func (tuner *aplTuner) Run() (*proto.APLTuneResult, error) {
+	if err := tuner.setup(); err != nil {
+		return nil, err
+	}
+
+	budget := int(tuner.Request.Budget)
+	if budget <= 0 {
+		budget = defaultAPLTuneBudget
+	}
+	numCandidates := int(tuner.Request.NumCandidates)
+	if numCandidates <= 0 {
+		numCandidates = defaultAPLTuneCandidates
+	}
+	if len(tuner.Request.Params) == 0 && len(tuner.permutable) < 2 {
+		numCandidates = 1
+	}
+
+	baseCandidate := &aplTuneCandidate{
+		values: tuner.baseValues,
+		order:  tuner.permutable,
+	}
+	candidates := []*aplTuneCandidate{baseCandidate}
+	for len(candidates) < numCandidates {
+		candidates = append(candidates, tuner.randomCandidate())
+	}
+
+	// Successive halving: each round gets an equal share of the budget, and the better
+	// half of the candidates advance to the next round with twice the iterations.
+	numRounds := 1
+	for n := len(candidates); n > 2; n = (n + 1) / 2 {
+		numRounds++
+	}
+	var iterations int
+	for {
+		iterations = max(budget/2/numRounds/len(candidates), minAPLTuneIterations)
+		if err := tuner.evaluate(candidates, iterations); err != nil {
+			return nil, err
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].dps > candidates[j].dps
+		})
+		if len(candidates) <= 2 {
+			break
+		}
+		candidates = candidates[:(len(candidates)+1)/2]
+	}
+	best := candidates[0]
+
+	// Spend the other half of the budget climbing from the best candidate, using the
+	// iterations of the final round so results stay comparable.
+	for remaining := budget / 2 / iterations; remaining > 0; {
+		neighbours := tuner.neighbours(best)
+		if len(neighbours) == 0 {
+			break
+		}
+		if len(neighbours) > remaining {
+			neighbours = neighbours[:remaining]
+		}
+		remaining -= len(neighbours)
+		if err := tuner.evaluate(neighbours, iterations); err != nil {
+			return nil, err
+		}
+
+		improved := false
+		for _, neighbour := range neighbours {
+			if neighbour.dps > best.dps {
+				best = neighbour
+				improved = true
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+
+	// Compare against the unmodified rotation and measure each parameter's sensitivity
+	// using the iterations of the final round.
+	evals := []*aplTuneCandidate{}
+	if best != baseCandidate {
+		evals = append(evals, baseCandidate)
+	}
+	sweeps := make([][]*aplTuneCandidate, len(tuner.Request.Params))
+	for i, param := range tuner.Request.Params {
+		for _, value := range aplTuneSweepValues(param) {
+			sweep := &aplTuneCandidate{
+				values: append([]float64{}, best.values...),
+				order:  best.order,
+			}
+			sweep.values[i] = value
+			sweeps[i] = append(sweeps[i], sweep)
+			evals = append(evals, sweep)
+		}
+	}
+	if err := tuner.evaluate(evals, iterations); err != nil {
+		return nil, err
+	}
+
+	result := &proto.APLTuneResult{
+		BestRotation: tuner.buildRotation(best),
+		BestDps:      best.dps,
+		BaseDps:      baseCandidate.dps,
+		BestOrder:    best.order,
+	}
+	for i, param := range tuner.Request.Params {
+		sensitivity := &proto.APLParamSensitivity{
+			Name:      param.Name,
+			BestValue: best.values[i],
+		}
+		for _, sweep := range sweeps[i] {
+			sensitivity.Values = append(sensitivity.Values, sweep.values[i])
+			sensitivity.Dps = append(sensitivity.Dps, sweep.dps)
+		}
+		result.Sensitivity = append(result.Sensitivity, sensitivity)
+	}
+	return result, nil
+}
+
+// Validates the request and reads the starting value of each parameter.
+func (tuner *aplTuner) setup() error {
+	request := tuner.Request
+	baseSettings := request.GetBaseSettings()
+	if baseSettings.GetRaid() == nil {
+		return errors.New("apltune: missing base settings")
+	}
+	player := raidPlayerByIndex(baseSettings.Raid, request.RaidIndex)
+	if player == nil {
+		return fmt.Errorf("apltune: no player at raid index %d", request.RaidIndex)
+	}
+	if player.Rotation == nil || player.Rotation.Type != proto.APLRotation_TypeAPL {
+		return fmt.Errorf("apltune: player at raid index %d does not use an APL rotation", request.RaidIndex)
+	}
+	tuner.baseRotation = player.Rotation
+
+	for _, param := range request.Params {
+		if param.Min > param.Max || param.Step < 0 {
+			return fmt.Errorf("apltune: invalid range for parameter '%s'", param.Name)
+		}
+		constConfig, err := findAPLTunableConst(tuner.baseRotation, param)
+		if err != nil {
+			return err
+		}
+		match := aplTunableConstRegex.FindStringSubmatch(constConfig.Val)
+		if match == nil {
+			return fmt.Errorf("apltune: parameter '%s' refers to non-numeric value '%s'", param.Name, constConfig.Val)
+		}
+		value, _ := strconv.ParseFloat(match[1], 64)
+		tuner.baseValues = append(tuner.baseValues, value)
+		tuner.suffixes = append(tuner.suffixes, match[2])
+	}
+
+	seen := make(map[int32]bool)
+	for _, idx := range request.PermutableEntries {
+		if idx < 0 || int(idx) >= len(tuner.baseRotation.PriorityList) || seen[idx] {
+			return fmt.Errorf("apltune: invalid permutable entry %d", idx)
+		}
+		seen[idx] = true
+	}
+	// Sorted, so the base candidate's order is the rotation's own.
+	tuner.permutable = append([]int32{}, request.PermutableEntries...)
+	slices.Sort(tuner.permutable)
+
+	tuner.seed = baseSettings.GetSimOptions().GetRandomSeed()
+	if tuner.seed == 0 {
+		tuner.seed = time.Now().UnixNano()
+	}
+	tuner.rng = rand.New(rand.NewSource(tuner.seed))
+	return nil
+}
+
+func (tuner *aplTuner) randomCandidate() *aplTuneCandidate {
+	candidate := &aplTuneCandidate{}
+	for _, param := range tuner.Request.Params {
+		var value float64
+		if param.Step > 0 {
+			value = param.Min + float64(tuner.rng.Intn(aplTuneNumSteps(param)))*param.Step
+		} else {
+			value = param.Min + tuner.rng.Float64()*(param.Max-param.Min)
+		}
+		candidate.values = append(candidate.values, roundAPLTuneValue(value))
+	}
+	for _, i := range tuner.rng.Perm(len(tuner.permutable)) {
+		candidate.order = append(candidate.order, tuner.permutable[i])
+	}
+	return candidate
+}
+
+// Candidates which differ from c by one step in a single parameter, or by swapping two
+// adjacent permutable entries.
+func (tuner *aplTuner) neighbours(c *aplTuneCandidate) []*aplTuneCandidate {
+	var neighbours []*aplTuneCandidate
+	for i, param := range tuner.Request.Params {
+		step := param.Step
+		if step == 0 {
+			step = (param.Max - param.Min) / 16
+		}
+		for _, value := range []float64{c.values[i] - step, c.values[i] + step} {
+			value = roundAPLTuneValue(math.Max(param.Min, math.Min(param.Max, value)))
+			if value == c.values[i] {
+				continue
+			}
+			neighbour := &aplTuneCandidate{
+				values: append([]float64{}, c.values...),
+				order:  c.order,
+			}
+			neighbour.values[i] = value
+			neighbours = append(neighbours, neighbour)
+		}
+	}
+	for i := 0; i+1 < len(c.order); i++ {
+		neighbour := &aplTuneCandidate{
+			values: c.values,
+			order:  append([]int32{}, c.order...),
+		}
+		neighbour.order[i], neighbour.order[i+1] = neighbour.order[i+1], neighbour.order[i]
+		neighbours = append(neighbours, neighbour)
+	}
+	return neighbours
+}
+
+// Runs a sim for each candidate in parallel, storing the resulting dps.
+func (tuner *aplTuner) evaluate(candidates []*aplTuneCandidate, iterations int) error {
+	tickets := make(chan struct{}, runtime.NumCPU())
+	errs := make([]error, len(candidates))
+	var wg sync.WaitGroup
+	for i, candidate := range candidates {
+		wg.Add(1)
+		tickets <- struct{}{}
+		go func(i int, candidate *aplTuneCandidate) {
+			defer func() {
+				<-tickets
+				wg.Done()
+			}()
+			candidate.dps, errs[i] = tuner.simCandidate(candidate, iterations)
+		}(i, candidate)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (tuner *aplTuner) simCandidate(candidate *aplTuneCandidate, iterations int) (float64, error) {
+	request := goproto.Clone(tuner.Request.BaseSettings).(*proto.RaidSimRequest)
+	raidPlayerByIndex(request.Raid, tuner.Request.RaidIndex).Rotation = tuner.buildRotation(candidate)
+	if request.SimOptions == nil {
+		request.SimOptions = &proto.SimOptions{}
+	}
+	request.SimOptions.Iterations = int32(iterations)
+	request.SimOptions.RandomSeed = tuner.seed
+	request.SimOptions.Debug = false
+	request.SimOptions.DebugFirstIteration = false
+
+	result := tuner.SingleRaidSimRunner(request, nil, false)
+	if result.ErrorResult != "" {
+		return 0, errors.New("simulation failed: " + result.ErrorResult)
+	}
+	partyIndex, playerIndex := tuner.Request.RaidIndex/5, tuner.Request.RaidIndex%5
+	return result.RaidMetrics.Parties[partyIndex].Players[playerIndex].Dps.Avg, nil
+}
+
+// Returns a copy of the base rotation with the candidate's values and order applied.
+func (tuner *aplTuner) buildRotation(candidate *aplTuneCandidate) *proto.APLRotation {
+	rotation := goproto.Clone(tuner.baseRotation).(*proto.APLRotation)
+	for i, param := range tuner.Request.Params {
+		constConfig, _ := findAPLTunableConst(rotation, param)
+		constConfig.Val = strconv.FormatFloat(candidate.values[i], 'f', -1, 64) + tuner.suffixes[i]
+	}
+
+	items := append([]*proto.APLListItem{}, rotation.PriorityList...)
+	for i, pos := range tuner.permutable {
+		rotation.PriorityList[pos] = items[candidate.order[i]]
+	}
+	return rotation
+}
+
+func raidPlayerByIndex(raid *proto.Raid, raidIndex int32) *proto.Player {
+	partyIndex, playerIndex := int(raidIndex/5), int(raidIndex%5)
+	if raidIndex < 0 || partyIndex >= len(raid.Parties) || playerIndex >= len(raid.Parties[partyIndex].Players) {
+		return nil
+	}
+	return raid.Parties[partyIndex].Players[playerIndex]
+}
+
+func findAPLTunableConst(rotation *proto.APLRotation, param *proto.APLTunableParam) (*proto.APLValueConst, error) {
+	items := rotation.PriorityList
+	if param.ListName != "" {
+		items = nil
+		for _, list := range rotation.ActionLists {
+			if list.Name == param.ListName {
+				items = list.Items
+				break
+			}
+		}
+		if items == nil {
+			return nil, fmt.Errorf("apltune: no action list with name '%s'", param.ListName)
+		}
+	}
+	if param.EntryIndex < 0 || int(param.EntryIndex) >= len(items) {
+		return nil, fmt.Errorf("apltune: parameter '%s' has invalid entry index %d", param.Name, param.EntryIndex)
+	}
+
+	consts := collectAPLValueConsts(items[param.EntryIndex].ProtoReflect(), nil)
+	if param.ConstIndex < 0 || int(param.ConstIndex) >= len(consts) {
+		return nil, fmt.Errorf("apltune: parameter '%s' has invalid const index %d", param.Name, param.ConstIndex)
+	}
+	return consts[param.ConstIndex], nil
+}
+
+// Appends every APLValueConst within msg, depth-first in field declaration order.
+func collectAPLValueConsts(msg protoreflect.Message, consts []*proto.APLValueConst) []*proto.APLValueConst {
+	if constConfig, ok := msg.Interface().(*proto.APLValueConst); ok {
+		return append(consts, constConfig)
+	}
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsMap() || !msg.Has(fd) {
+			continue
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				consts = collectAPLValueConsts(list.Get(j).Message(), consts)
+			}
+		} else {
+			consts = collectAPLValueConsts(msg.Get(fd).Message(), consts)
+		}
+	}
+	return consts
+}
+
+func aplTuneNumSteps(param *proto.APLTunableParam) int {
+	return int(math.Floor((param.Max-param.Min)/param.Step+1e-9)) + 1
+}
+
+// Evenly spaced values covering the parameter's range, aligned to its step if it has one.
+func aplTuneSweepValues(param *proto.APLTunableParam) []float64 {
+	numPoints := aplTuneSensitivityPoints
+	if param.Step > 0 {
+		numPoints = min(numPoints, aplTuneNumSteps(param))
+	}
+	if numPoints < 2 || param.Max == param.Min {
+		return []float64{param.Min}
+	}
+
+	values := make([]float64, numPoints)
+	for i := range values {
+		if param.Step > 0 {
+			step := math.Round(float64(i*(aplTuneNumSteps(param)-1)) / float64(numPoints-1))
+			values[i] = roundAPLTuneValue(param.Min + step*param.Step)
+		} else {
+			values[i] = roundAPLTuneValue(param.Min + float64(i)*(param.Max-param.Min)/float64(numPoints-1))
+		}
+	}
+	return values
+}
+
+// Removes floating point noise so values print cleanly in the rotation.
+func roundAPLTuneValue(value float64) float64 {
+	return math.Round(value*1e6) / 1e6
+}
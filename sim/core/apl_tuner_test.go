@@ -0,0 +1,167 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+const tunerTestRotation = `{
+	"type": "TypeAPL",
+	"priorityList": [
+		{"action": {"condition": {"cmp": {"op": "OpGt", "lhs": {"currentTime": {}}, "rhs": {"const": {"val": "3s"}}}}, "castSpell": {"spellId": {"spellId": 1}}}},
+		{"notes": "a", "action": {"castSpell": {"spellId": {"spellId": 2}}}},
+		{"notes": "b", "action": {"castSpell": {"spellId": {"spellId": 3}}}}
+	]
+}`
+
+func TestAPLTuner(t *testing.T) {
+	rotation := APLRotationFromJsonString(tunerTestRotation)
+
+	var mu sync.Mutex
+	seeds := make(map[int64]bool)
+
+	// Dps peaks when the threshold is 7s and entry "b" comes before "a".
+	fakeRunner := func(request *proto.RaidSimRequest, _ chan *proto.ProgressMetrics, _ bool) *proto.RaidSimResult {
+		mu.Lock()
+		seeds[request.SimOptions.RandomSeed] = true
+		mu.Unlock()
+
+		rot := request.Raid.Parties[0].Players[0].Rotation
+		val := rot.PriorityList[0].Action.Condition.GetCmp().Rhs.GetConst().Val
+		if !strings.HasSuffix(val, "s") {
+			t.Errorf("Tuned value lost its unit: %s", val)
+		}
+		threshold, _ := strconv.ParseFloat(strings.TrimSuffix(val, "s"), 64)
+
+		dps := 1000 - (threshold-7)*(threshold-7)
+		if rot.PriorityList[1].Notes == "b" {
+			dps += 50
+		}
+		return &proto.RaidSimResult{
+			RaidMetrics: &proto.RaidMetrics{
+				Parties: []*proto.PartyMetrics{{
+					Players: []*proto.UnitMetrics{{Dps: &proto.DistributionMetrics{Avg: dps}}},
+				}},
+			},
+		}
+	}
+
+	tuner := &aplTuner{
+		SingleRaidSimRunner: fakeRunner,
+		Request: &proto.APLTuneRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{Parties: []*proto.Party{{
+					Players: []*proto.Player{{Name: "Player", Rotation: rotation}},
+				}}},
+				SimOptions: &proto.SimOptions{RandomSeed: 101},
+			},
+			Params: []*proto.APLTunableParam{
+				{Name: "threshold", EntryIndex: 0, ConstIndex: 0, Min: 0, Max: 10, Step: 1},
+			},
+			PermutableEntries: []int32{1, 2},
+			Budget:            2000,
+			NumCandidates:     48,
+		},
+	}
+
+	result, err := tuner.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.BaseDps != 984 {
+		t.Errorf("Expected base dps 984, got %f", result.BaseDps)
+	}
+	if result.BestDps != 1050 {
+		t.Errorf("Expected best dps 1050, got %f", result.BestDps)
+	}
+	if val := result.BestRotation.PriorityList[0].Action.Condition.GetCmp().Rhs.GetConst().Val; val != "7s" {
+		t.Errorf("Expected tuned value 7s, got %s", val)
+	}
+	if result.BestRotation.PriorityList[1].Notes != "b" || len(result.BestOrder) != 2 || result.BestOrder[0] != 2 {
+		t.Errorf("Expected entries to be reordered, got order %v", result.BestOrder)
+	}
+	if len(seeds) != 1 || !seeds[101] {
+		t.Errorf("Expected every sim to use the request seed, got %v", seeds)
+	}
+	// The input rotation must not be modified.
+	if rotation.PriorityList[1].Notes != "a" {
+		t.Errorf("Base rotation was modified")
+	}
+
+	if len(result.Sensitivity) != 1 {
+		t.Fatalf("Expected 1 sensitivity entry, got %d", len(result.Sensitivity))
+	}
+	sensitivity := result.Sensitivity[0]
+	if len(sensitivity.Values) != aplTuneSensitivityPoints || sensitivity.Values[0] != 0 || sensitivity.Values[len(sensitivity.Values)-1] != 10 {
+		t.Errorf("Unexpected sensitivity values: %v", sensitivity.Values)
+	}
+	for i, value := range sensitivity.Values {
+		if expected := 1050 - (value-7)*(value-7); sensitivity.Dps[i] != expected {
+			t.Errorf("Sensitivity at %f: expected %f, got %f", value, expected, sensitivity.Dps[i])
+		}
+	}
+}
+
+func TestAPLTunerInvalidParam(t *testing.T) {
+	tuner := &aplTuner{
+		Request: &proto.APLTuneRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{Parties: []*proto.Party{{
+					Players: []*proto.Player{{Name: "Player", Rotation: APLRotationFromJsonString(tunerTestRotation)}},
+				}}},
+			},
+			Params: []*proto.APLTunableParam{
+				{Name: "missing", EntryIndex: 1, ConstIndex: 0, Min: 0, Max: 10},
+			},
+		},
+	}
+	if _, err := tuner.Run(); err == nil || !strings.Contains(err.Error(), "invalid const index") {
+		t.Errorf("Expected invalid const index error, got %v", err)
+	}
+}
+
+func TestAPLTunerUnsortedPermutableEntries(t *testing.T) {
+	// Dps only depends on which entry comes first.
+	fakeRunner := func(request *proto.RaidSimRequest, _ chan *proto.ProgressMetrics, _ bool) *proto.RaidSimResult {
+		dps := 1000.0
+		if request.Raid.Parties[0].Players[0].Rotation.PriorityList[1].Notes == "b" {
+			dps += 50
+		}
+		return &proto.RaidSimResult{
+			RaidMetrics: &proto.RaidMetrics{
+				Parties: []*proto.PartyMetrics{{
+					Players: []*proto.UnitMetrics{{Dps: &proto.DistributionMetrics{Avg: dps}}},
+				}},
+			},
+		}
+	}
+
+	tuner := &aplTuner{
+		SingleRaidSimRunner: fakeRunner,
+		Request: &proto.APLTuneRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{Parties: []*proto.Party{{
+					Players: []*proto.Player{{Name: "Player", Rotation: APLRotationFromJsonString(tunerTestRotation)}},
+				}}},
+				SimOptions: &proto.SimOptions{RandomSeed: 101},
+			},
+			PermutableEntries: []int32{2, 1},
+			Budget:            100,
+			NumCandidates:     1,
+		},
+	}
+
+	result, err := tuner.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The base candidate is the rotation as given, with "a" first.
+	if result.BaseDps != 1000 {
+		t.Errorf("Expected base dps 1000, got %f", result.BaseDps)
+	}
+}
@@ -80,6 +80,16 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 	case *proto.APLValue_Min:
 		return rot.newValueMin(config.GetMin())
 
+	// Variables
+	case *proto.APLValue_Variable:
+		return rot.newValueVariable(config.GetVariable())
+
+	// Randomness
+	case *proto.APLValue_Random:
+		return rot.newValueRandom(config.GetRandom())
+	case *proto.APLValue_Chance:
+		return rot.newValueChance(config.GetChance())
+
 	// Encounter
 	case *proto.APLValue_CurrentTime:
 		return rot.newValueCurrentTime(config.GetCurrentTime())
@@ -99,6 +109,10 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		return rot.newValueBossSpellIsCasting(config.GetBossSpellIsCasting())
 	case *proto.APLValue_BossSpellTimeToReady:
 		return rot.newValueBossSpellTimeToReady(config.GetBossSpellTimeToReady())
+	case *proto.APLValue_BossNextDangerousEventTime:
+		return rot.newValueBossNextDangerousEventTime(config.GetBossNextDangerousEventTime())
+	case *proto.APLValue_BossNextDangerousEventDamage:
+		return rot.newValueBossNextDangerousEventDamage(config.GetBossNextDangerousEventDamage())
 
 	// Resources
 	case *proto.APLValue_CurrentHealth:
@@ -181,6 +195,20 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		return rot.newValueAuraICDIsReadyWithReactionTime(config.GetAuraIcdIsReadyWithReactionTime())
 	case *proto.APLValue_AuraShouldRefresh:
 		return rot.newValueAuraShouldRefresh(config.GetAuraShouldRefresh())
+	case *proto.APLValue_AuraAppliedBy:
+		return rot.newValueAuraAppliedBy(config.GetAuraAppliedBy())
+
+	// Raid
+	case *proto.APLValue_RaidAuraCount:
+		return rot.newValueRaidAuraCount(config.GetRaidAuraCount())
+	case *proto.APLValue_RaidAuraAnyActive:
+		return rot.newValueRaidAuraAnyActive(config.GetRaidAuraAnyActive())
+	case *proto.APLValue_RaidAuraAllActive:
+		return rot.newValueRaidAuraAllActive(config.GetRaidAuraAllActive())
+	case *proto.APLValue_RaidAuraMinRemainingTime:
+		return rot.newValueRaidAuraMinRemainingTime(config.GetRaidAuraMinRemainingTime())
+	case *proto.APLValue_RaidSpellTimeToReady:
+		return rot.newValueRaidSpellTimeToReady(config.GetRaidSpellTimeToReady())
 
 	// Dots
 	case *proto.APLValue_DotIsActive:
@@ -188,6 +216,12 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 	case *proto.APLValue_DotRemainingTime:
 		return rot.newValueDotRemainingTime(config.GetDotRemainingTime())
 
+	// Pets
+	case *proto.APLValue_PetIsActive:
+		return rot.newValuePetIsActive(config.GetPetIsActive())
+	case *proto.APLValue_PetRemainingTime:
+		return rot.newValuePetRemainingTime(config.GetPetRemainingTime())
+
 	// Sequences
 	case *proto.APLValue_SequenceIsComplete:
 		return rot.newValueSequenceIsComplete(config.GetSequenceIsComplete())
@@ -54,3 +54,59 @@ func (value *APLValueBossSpellTimeToReady) GetDuration(sim *Simulation) time.Dur
 func (value *APLValueBossSpellTimeToReady) String() string {
 	return fmt.Sprintf("Boss Spell Time to Ready(%s)", value.spell.ActionID)
 }
+
+type APLValueBossNextDangerousEventTime struct {
+	DefaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueBossNextDangerousEventTime(config *proto.APLValueBossNextDangerousEventTime) APLValue {
+	if !rot.unit.Env.HasDangerousEvents() {
+		rot.ValidationWarning("Encounter has no dangerous boss abilities")
+		return nil
+	}
+	return &APLValueBossNextDangerousEventTime{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueBossNextDangerousEventTime) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueBossNextDangerousEventTime) GetDuration(sim *Simulation) time.Duration {
+	event, ok := value.unit.Env.NextDangerousEvent(sim, value.unit)
+	if !ok {
+		return NeverExpires
+	}
+	return event.TimeUntil(sim)
+}
+func (value *APLValueBossNextDangerousEventTime) String() string {
+	return "Boss Next Dangerous Event Time"
+}
+
+type APLValueBossNextDangerousEventDamage struct {
+	DefaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueBossNextDangerousEventDamage(config *proto.APLValueBossNextDangerousEventDamage) APLValue {
+	if !rot.unit.Env.HasDangerousEvents() {
+		rot.ValidationWarning("Encounter has no dangerous boss abilities")
+		return nil
+	}
+	return &APLValueBossNextDangerousEventDamage{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueBossNextDangerousEventDamage) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueBossNextDangerousEventDamage) GetFloat(sim *Simulation) float64 {
+	event, ok := value.unit.Env.NextDangerousEvent(sim, value.unit)
+	if !ok {
+		return 0
+	}
+	return event.Damage
+}
+func (value *APLValueBossNextDangerousEventDamage) String() string {
+	return "Boss Next Dangerous Event Damage"
+}
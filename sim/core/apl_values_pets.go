@@ -0,0 +1,56 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type APLValuePetIsActive struct {
+	DefaultAPLValueImpl
+	pet *Pet
+}
+
+func (rot *APLRotation) newValuePetIsActive(config *proto.APLValuePetIsActive) APLValue {
+	petAgent := rot.GetAPLPet(config.Pet)
+	if petAgent == nil {
+		return nil
+	}
+	return &APLValuePetIsActive{
+		pet: petAgent.GetPet(),
+	}
+}
+func (value *APLValuePetIsActive) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValuePetIsActive) GetBool(sim *Simulation) bool {
+	return value.pet.IsEnabled()
+}
+func (value *APLValuePetIsActive) String() string {
+	return fmt.Sprintf("Pet Is Active(%s)", value.pet.Label)
+}
+
+type APLValuePetRemainingTime struct {
+	DefaultAPLValueImpl
+	pet *Pet
+}
+
+func (rot *APLRotation) newValuePetRemainingTime(config *proto.APLValuePetRemainingTime) APLValue {
+	petAgent := rot.GetAPLPet(config.Pet)
+	if petAgent == nil {
+		return nil
+	}
+	return &APLValuePetRemainingTime{
+		pet: petAgent.GetPet(),
+	}
+}
+func (value *APLValuePetRemainingTime) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValuePetRemainingTime) GetDuration(sim *Simulation) time.Duration {
+	return value.pet.RemainingDuration(sim)
+}
+func (value *APLValuePetRemainingTime) String() string {
+	return fmt.Sprintf("Pet Remaining Time(%s)", value.pet.Label)
+}
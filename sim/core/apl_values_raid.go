@@ -0,0 +1,245 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Returns the players looked at by raid values.
+func (rot *APLRotation) getRaidMembers(partyOnly bool) []*Unit {
+	if !partyOnly {
+		return rot.unit.Env.Raid.AllPlayerUnits
+	}
+
+	agent := rot.unit.Env.GetAgentFromUnit(rot.unit)
+	if agent == nil || agent.GetCharacter().Party == nil {
+		rot.ValidationWarning("%s is not in a party", rot.unit.Label)
+		return nil
+	}
+	return MapSlice(agent.GetCharacter().Party.Players, func(player Agent) *Unit {
+		return &player.GetCharacter().Unit
+	})
+}
+
+func (rot *APLRotation) getRaidAuras(auraId *proto.ActionID, partyOnly bool) []*Aura {
+	actionID := ProtoToActionID(auraId)
+	var auras []*Aura
+	for _, unit := range rot.getRaidMembers(partyOnly) {
+		if aura := unit.GetAuraByID(actionID); aura != nil {
+			auras = append(auras, aura)
+		}
+	}
+	if len(auras) == 0 {
+		rot.ValidationWarning("No raid member has aura: %s", actionID)
+	}
+	return auras
+}
+
+func raidAurasString(auras []*Aura, partyOnly bool) string {
+	if partyOnly {
+		return fmt.Sprintf("%s, party", auras[0].ActionID)
+	}
+	return auras[0].ActionID.String()
+}
+
+type APLValueAuraAppliedBy struct {
+	DefaultAPLValueImpl
+	aura    AuraReference
+	applier UnitReference
+}
+
+func (rot *APLRotation) newValueAuraAppliedBy(config *proto.APLValueAuraAppliedBy) APLValue {
+	aura := rot.GetAPLAura(rot.GetSourceUnit(config.SourceUnit), config.AuraId)
+	if aura.Get() == nil {
+		return nil
+	}
+	applier := rot.GetSourceUnit(config.Applier)
+	if applier.Get() == nil {
+		return nil
+	}
+	return &APLValueAuraAppliedBy{
+		aura:    aura,
+		applier: applier,
+	}
+}
+func (value *APLValueAuraAppliedBy) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueAuraAppliedBy) GetBool(sim *Simulation) bool {
+	aura := value.aura.Get()
+	return aura.IsActive() && aura.applier == value.applier.Get()
+}
+func (value *APLValueAuraAppliedBy) String() string {
+	return fmt.Sprintf("Aura Applied By(%s, %s)", value.aura.String(), value.applier.Get().Label)
+}
+
+type APLValueRaidAuraCount struct {
+	DefaultAPLValueImpl
+	auras     []*Aura
+	partyOnly bool
+}
+
+func (rot *APLRotation) newValueRaidAuraCount(config *proto.APLValueRaidAuraCount) APLValue {
+	auras := rot.getRaidAuras(config.AuraId, config.PartyOnly)
+	if len(auras) == 0 {
+		return nil
+	}
+	return &APLValueRaidAuraCount{
+		auras:     auras,
+		partyOnly: config.PartyOnly,
+	}
+}
+func (value *APLValueRaidAuraCount) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueRaidAuraCount) GetInt(sim *Simulation) int32 {
+	count := int32(0)
+	for _, aura := range value.auras {
+		if aura.IsActive() {
+			count++
+		}
+	}
+	return count
+}
+func (value *APLValueRaidAuraCount) String() string {
+	return fmt.Sprintf("Raid Aura Count(%s)", raidAurasString(value.auras, value.partyOnly))
+}
+
+type APLValueRaidAuraAnyActive struct {
+	DefaultAPLValueImpl
+	auras     []*Aura
+	partyOnly bool
+}
+
+func (rot *APLRotation) newValueRaidAuraAnyActive(config *proto.APLValueRaidAuraAnyActive) APLValue {
+	auras := rot.getRaidAuras(config.AuraId, config.PartyOnly)
+	if len(auras) == 0 {
+		return nil
+	}
+	return &APLValueRaidAuraAnyActive{
+		auras:     auras,
+		partyOnly: config.PartyOnly,
+	}
+}
+func (value *APLValueRaidAuraAnyActive) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueRaidAuraAnyActive) GetBool(sim *Simulation) bool {
+	for _, aura := range value.auras {
+		if aura.IsActive() {
+			return true
+		}
+	}
+	return false
+}
+func (value *APLValueRaidAuraAnyActive) String() string {
+	return fmt.Sprintf("Raid Aura Any Active(%s)", raidAurasString(value.auras, value.partyOnly))
+}
+
+type APLValueRaidAuraAllActive struct {
+	DefaultAPLValueImpl
+	auras     []*Aura
+	partyOnly bool
+}
+
+func (rot *APLRotation) newValueRaidAuraAllActive(config *proto.APLValueRaidAuraAllActive) APLValue {
+	auras := rot.getRaidAuras(config.AuraId, config.PartyOnly)
+	if len(auras) == 0 {
+		return nil
+	}
+	return &APLValueRaidAuraAllActive{
+		auras:     auras,
+		partyOnly: config.PartyOnly,
+	}
+}
+func (value *APLValueRaidAuraAllActive) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueRaidAuraAllActive) GetBool(sim *Simulation) bool {
+	for _, aura := range value.auras {
+		if !aura.IsActive() {
+			return false
+		}
+	}
+	return true
+}
+func (value *APLValueRaidAuraAllActive) String() string {
+	return fmt.Sprintf("Raid Aura All Active(%s)", raidAurasString(value.auras, value.partyOnly))
+}
+
+type APLValueRaidAuraMinRemainingTime struct {
+	DefaultAPLValueImpl
+	auras     []*Aura
+	partyOnly bool
+}
+
+func (rot *APLRotation) newValueRaidAuraMinRemainingTime(config *proto.APLValueRaidAuraMinRemainingTime) APLValue {
+	auras := rot.getRaidAuras(config.AuraId, config.PartyOnly)
+	if len(auras) == 0 {
+		return nil
+	}
+	return &APLValueRaidAuraMinRemainingTime{
+		auras:     auras,
+		partyOnly: config.PartyOnly,
+	}
+}
+func (value *APLValueRaidAuraMinRemainingTime) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueRaidAuraMinRemainingTime) GetDuration(sim *Simulation) time.Duration {
+	minRemaining := time.Duration(0)
+	for _, aura := range value.auras {
+		if !aura.IsActive() {
+			continue
+		}
+		if remaining := aura.RemainingDuration(sim); minRemaining == 0 || remaining < minRemaining {
+			minRemaining = remaining
+		}
+	}
+	return minRemaining
+}
+func (value *APLValueRaidAuraMinRemainingTime) String() string {
+	return fmt.Sprintf("Raid Aura Min Remaining Time(%s)", raidAurasString(value.auras, value.partyOnly))
+}
+
+type APLValueRaidSpellTimeToReady struct {
+	DefaultAPLValueImpl
+	spells    []*Spell
+	partyOnly bool
+}
+
+func (rot *APLRotation) newValueRaidSpellTimeToReady(config *proto.APLValueRaidSpellTimeToReady) APLValue {
+	actionID := ProtoToActionID(config.SpellId)
+	var spells []*Spell
+	for _, unit := range rot.getRaidMembers(config.PartyOnly) {
+		if spell := unit.GetSpell(actionID); spell != nil {
+			spells = append(spells, spell)
+		}
+	}
+	if len(spells) == 0 {
+		rot.ValidationWarning("No raid member knows spell: %s", actionID)
+		return nil
+	}
+	return &APLValueRaidSpellTimeToReady{
+		spells:    spells,
+		partyOnly: config.PartyOnly,
+	}
+}
+func (value *APLValueRaidSpellTimeToReady) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueRaidSpellTimeToReady) GetDuration(sim *Simulation) time.Duration {
+	minTimeToReady := NeverExpires
+	for _, spell := range value.spells {
+		minTimeToReady = min(minTimeToReady, spell.TimeToReady(sim))
+	}
+	return minTimeToReady
+}
+func (value *APLValueRaidSpellTimeToReady) String() string {
+	if value.partyOnly {
+		return fmt.Sprintf("Raid Spell Time To Ready(%s, party)", value.spells[0].ActionID)
+	}
+	return fmt.Sprintf("Raid Spell Time To Ready(%s)", value.spells[0].ActionID)
+}
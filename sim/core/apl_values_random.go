@@ -0,0 +1,51 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type APLValueRandom struct {
+	DefaultAPLValueImpl
+}
+
+func (rot *APLRotation) newValueRandom(_ *proto.APLValueRandom) APLValue {
+	return &APLValueRandom{}
+}
+func (value *APLValueRandom) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueRandom) GetFloat(sim *Simulation) float64 {
+	return sim.RandomFloat("APL Random")
+}
+func (value *APLValueRandom) String() string {
+	return "Random()"
+}
+
+type APLValueChance struct {
+	DefaultAPLValueImpl
+	probability APLValue
+}
+
+func (rot *APLRotation) newValueChance(config *proto.APLValueChance) APLValue {
+	probability := rot.coerceTo(rot.newAPLValue(config.Probability), proto.APLValueType_ValueTypeFloat)
+	if probability == nil {
+		return nil
+	}
+	return &APLValueChance{
+		probability: probability,
+	}
+}
+func (value *APLValueChance) GetInnerValues() []APLValue {
+	return []APLValue{value.probability}
+}
+func (value *APLValueChance) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueChance) GetBool(sim *Simulation) bool {
+	return sim.Proc(value.probability.GetFloat(sim), "APL Chance")
+}
+func (value *APLValueChance) String() string {
+	return fmt.Sprintf("Chance(%s)", value.probability)
+}
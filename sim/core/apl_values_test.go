@@ -42,3 +42,113 @@ func TestValueConst(t *testing.T) {
 		t.Fatalf("Unexpected coerced duration value %s", coercedDurVal.GetDuration(sim))
 	}
 }
+
+func TestRaidAuraValues(t *testing.T) {
+	sim, fsu := setupFakeShieldUser()
+	rot := &APLRotation{unit: &fsu.Unit}
+
+	shieldID := ActionID{SpellID: 43}
+	count := rot.newValueRaidAuraCount(&proto.APLValueRaidAuraCount{AuraId: shieldID.ToProto()})
+	all := rot.newValueRaidAuraAllActive(&proto.APLValueRaidAuraAllActive{AuraId: shieldID.ToProto(), PartyOnly: true})
+	minRemaining := rot.newValueRaidAuraMinRemainingTime(&proto.APLValueRaidAuraMinRemainingTime{AuraId: shieldID.ToProto()})
+	appliedBy := rot.newValueAuraAppliedBy(&proto.APLValueAuraAppliedBy{AuraId: shieldID.ToProto()})
+	timeToReady := rot.newValueRaidSpellTimeToReady(&proto.APLValueRaidSpellTimeToReady{SpellId: shieldID.ToProto()})
+	if len(rot.curWarnings) != 0 {
+		t.Fatalf("Unexpected warnings: %v", rot.curWarnings)
+	}
+
+	if count.GetInt(sim) != 0 || all.GetBool(sim) || minRemaining.GetDuration(sim) != 0 || appliedBy.GetBool(sim) {
+		t.Fatalf("Expected no active auras")
+	}
+	if timeToReady.GetDuration(sim) != 0 {
+		t.Fatalf("Expected spell to be ready, got %s", timeToReady.GetDuration(sim))
+	}
+
+	shield := fsu.shields[0]
+	shield.Spell.ApplyEffects = func(sim *Simulation, _ *Unit, _ *Spell) {
+		shield.Apply(sim, 100)
+	}
+	shield.Spell.Cast(sim, &fsu.Unit)
+	if count.GetInt(sim) != 1 || !all.GetBool(sim) || minRemaining.GetDuration(sim) != time.Second*30 {
+		t.Fatalf("Expected the aura to be active on 1 player for 30s, got %d for %s", count.GetInt(sim), minRemaining.GetDuration(sim))
+	}
+	if !appliedBy.GetBool(sim) {
+		t.Fatalf("Expected the aura to be applied by the caster")
+	}
+
+	// Auras which weren't applied by a spell have no applier.
+	aura := fsu.GetAuraByID(shieldID)
+	aura.Deactivate(sim)
+	aura.Activate(sim)
+	if appliedBy.GetBool(sim) {
+		t.Fatalf("Expected the aura to have no applier")
+	}
+
+	if rot.newValueRaidAuraCount(&proto.APLValueRaidAuraCount{AuraId: ActionID{SpellID: 1}.ToProto()}) != nil || len(rot.curWarnings) != 1 {
+		t.Fatalf("Expected a warning for an aura nobody has, got %v", rot.curWarnings)
+	}
+}
+
+func TestAuraAppliedByAfterTravelTime(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	rot := &APLRotation{unit: &fa.Unit}
+
+	debuff := fa.Dot.Aura
+	appliedBy := rot.newValueAuraAppliedBy(&proto.APLValueAuraAppliedBy{
+		SourceUnit: &proto.UnitReference{Type: proto.UnitReference_CurrentTarget},
+		AuraId:     debuff.ActionID.ToProto(),
+	})
+	if appliedBy == nil {
+		t.Fatalf("Failed to create value: %v", rot.curWarnings)
+	}
+
+	// Like a projectile spell, which applies its debuff once it lands.
+	sim.applyingUnit = &fa.Unit
+	fa.Spell.WaitTravelTime(sim, func(sim *Simulation) {
+		debuff.Activate(sim)
+	})
+	sim.applyingUnit = nil
+
+	for i := 0; i < 10 && !debuff.IsActive(); i++ {
+		sim.Step()
+	}
+	if !debuff.IsActive() || !appliedBy.GetBool(sim) {
+		t.Fatalf("Expected the debuff to be applied by the caster after travel time")
+	}
+
+	// Pending actions built directly, rather than with NewDelayedAction, also keep the applier.
+	debuff.Deactivate(sim)
+	sim.applyingUnit = &fa.Unit
+	sim.AddPendingAction(&PendingAction{
+		NextActionAt: sim.CurrentTime + time.Second,
+		OnAction: func(sim *Simulation) {
+			debuff.Activate(sim)
+		},
+	})
+	sim.applyingUnit = nil
+
+	for i := 0; i < 10 && !debuff.IsActive(); i++ {
+		sim.Step()
+	}
+	if !debuff.IsActive() || !appliedBy.GetBool(sim) {
+		t.Fatalf("Expected the debuff to be applied by the caster from a directly built pending action")
+	}
+}
+
+func TestAPLRandomValues(t *testing.T) {
+	sim := SetupFakeSim()
+	rot := &APLRotation{unit: &sim.Raid.Parties[0].Players[0].GetCharacter().Unit}
+
+	random := rot.newValueRandom(&proto.APLValueRandom{})
+	never := rot.newValueChance(&proto.APLValueChance{Probability: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "0%"}}}})
+	always := rot.newValueChance(&proto.APLValueChance{Probability: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "1"}}}})
+	for i := 0; i < 100; i++ {
+		if val := random.GetFloat(sim); val < 0 || val >= 1 {
+			t.Fatalf("Random value out of range: %f", val)
+		}
+		if never.GetBool(sim) || !always.GetBool(sim) {
+			t.Fatalf("Unexpected chance result")
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// A named value which is assigned by Set Variable actions and read with the Variable value.
+type aplVariable struct {
+	name    string
+	valType proto.APLValueType
+
+	// All Set Variable actions which assign this variable.
+	configs []*proto.APLActionSetVariable
+
+	resolving      bool
+	resolved       bool
+	selfReferenced bool
+
+	// Selecting the next action may only be a check, e.g. whether to interrupt a channel,
+	// so values assigned while selecting are staged until the rotation acts on the selection.
+	current  aplVariableState
+	staged   aplVariableState
+	isStaged bool
+}
+
+type aplVariableState struct {
+	boolVal     bool
+	intVal      int32
+	floatVal    float64
+	durationVal time.Duration
+	stringVal   string
+}
+
+func (variable *aplVariable) reset() {
+	variable.current = aplVariableState{}
+	variable.staged = aplVariableState{}
+	variable.isStaged = false
+}
+
+// The staged value if there is one, otherwise the current value.
+func (variable *aplVariable) state() *aplVariableState {
+	if variable.isStaged {
+		return &variable.staged
+	}
+	return &variable.current
+}
+
+func (variable *aplVariable) evaluate(sim *Simulation, value APLValue) aplVariableState {
+	var state aplVariableState
+	switch variable.valType {
+	case proto.APLValueType_ValueTypeBool:
+		state.boolVal = value.GetBool(sim)
+	case proto.APLValueType_ValueTypeInt:
+		state.intVal = value.GetInt(sim)
+	case proto.APLValueType_ValueTypeFloat:
+		state.floatVal = value.GetFloat(sim)
+	case proto.APLValueType_ValueTypeDuration:
+		state.durationVal = value.GetDuration(sim)
+	case proto.APLValueType_ValueTypeString:
+		state.stringVal = value.GetString(sim)
+	}
+	return state
+}
+
+func (variable *aplVariable) set(sim *Simulation, value APLValue) {
+	variable.current = variable.evaluate(sim, value)
+	variable.isStaged = false
+}
+
+func (variable *aplVariable) stage(sim *Simulation, value APLValue) {
+	variable.staged = variable.evaluate(sim, value)
+	variable.isStaged = true
+}
+
+func (variable *aplVariable) commit() {
+	if variable.isStaged {
+		variable.current = variable.staged
+		variable.isStaged = false
+	}
+}
+
+// The value of a Set Variable action, built ahead of time so the variable's type is
+// known before anything reads it.
+type aplVariableValue struct {
+	value    APLValue
+	warnings []string
+}
+
+// Finds all Set Variable actions in the rotation and determines the type of each variable.
+func (rot *APLRotation) declareVariables(config *proto.APLRotation) {
+	rot.variables = make(map[string]*aplVariable)
+	rot.variableValues = make(map[*proto.APLActionSetVariable]aplVariableValue)
+	prepullConfigs := make(map[*proto.APLActionSetVariable]bool)
+
+	var variables []*aplVariable
+	collect := func(action *proto.APLAction, isPrepull bool) {
+		if action == nil {
+			return
+		}
+		walkAPLActionConfigs(action.ProtoReflect(), func(action *proto.APLAction) {
+			setVariable := action.GetSetVariable()
+			if setVariable == nil || setVariable.Name == "" {
+				return
+			}
+			variable := rot.variables[setVariable.Name]
+			if variable == nil {
+				variable = &aplVariable{name: setVariable.Name}
+				rot.variables[setVariable.Name] = variable
+				variables = append(variables, variable)
+			}
+			variable.configs = append(variable.configs, setVariable)
+			prepullConfigs[setVariable] = isPrepull
+		})
+	}
+	for _, prepullItem := range config.PrepullActions {
+		if !prepullItem.Hide {
+			collect(prepullItem.Action, true)
+		}
+	}
+	for _, aplItem := range config.PriorityList {
+		if !aplItem.Hide {
+			collect(aplItem.Action, false)
+		}
+	}
+	for _, listConfig := range config.ActionLists {
+		for _, aplItem := range listConfig.Items {
+			if !aplItem.Hide {
+				collect(aplItem.Action, false)
+			}
+		}
+	}
+
+	rot.variablePrepullConfigs = prepullConfigs
+	for _, variable := range variables {
+		rot.resolveVariable(variable)
+	}
+	rot.variablePrepullConfigs = nil
+}
+
+// Calls fn for msg and every APLAction nested within it.
+func walkAPLActionConfigs(msg protoreflect.Message, fn func(*proto.APLAction)) {
+	if action, ok := msg.Interface().(*proto.APLAction); ok {
+		fn(action)
+	}
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsMap() {
+			return true
+		}
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				walkAPLActionConfigs(list.Get(i).Message(), fn)
+			}
+		} else {
+			walkAPLActionConfigs(v.Message(), fn)
+		}
+		return true
+	})
+}
+
+func (rot *APLRotation) resolveVariable(variable *aplVariable) {
+	if variable.resolved {
+		return
+	}
+	if variable.resolving {
+		// A variable which refers to itself, e.g. a counter. These are always numeric.
+		variable.selfReferenced = true
+		return
+	}
+	variable.resolving = true
+	variable.valType = proto.APLValueType_ValueTypeFloat
+
+	var values []APLValue
+	for _, config := range variable.configs {
+		savedWarnings, savedPrepull := rot.curWarnings, rot.parsingPrepull
+		rot.curWarnings = nil
+		rot.parsingPrepull = rot.variablePrepullConfigs[config]
+
+		value := rot.newAPLValue(config.Value)
+		if value == nil && config.Value == nil {
+			rot.ValidationWarning("Set Variable must provide a value")
+		}
+		rot.variableValues[config] = aplVariableValue{value: value, warnings: rot.curWarnings}
+
+		rot.curWarnings, rot.parsingPrepull = savedWarnings, savedPrepull
+		if value != nil {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) > 0 {
+		variable.valType = highestOrderTypeList(values)
+	}
+	if variable.selfReferenced && variable.valType != proto.APLValueType_ValueTypeFloat {
+		if variable.valType == proto.APLValueType_ValueTypeBool || variable.valType == proto.APLValueType_ValueTypeString {
+			first := rot.variableValues[variable.configs[0]]
+			first.warnings = append(first.warnings, fmt.Sprintf("Variable '%s' refers to itself, so it will be treated as a number", variable.name))
+			rot.variableValues[variable.configs[0]] = first
+		}
+		variable.valType = proto.APLValueType_ValueTypeFloat
+	}
+
+	variable.resolving = false
+	variable.resolved = true
+}
+
+type APLValueVariable struct {
+	DefaultAPLValueImpl
+	variable *aplVariable
+}
+
+func (rot *APLRotation) newValueVariable(config *proto.APLValueVariable) APLValue {
+	if config.Name == "" {
+		rot.ValidationWarning("Variable must provide a variable name")
+		return nil
+	}
+	variable := rot.variables[config.Name]
+	if variable == nil {
+		rot.ValidationWarning("No variable with name: '%s'", config.Name)
+		return nil
+	}
+	rot.resolveVariable(variable)
+	return &APLValueVariable{
+		variable: variable,
+	}
+}
+func (value *APLValueVariable) Type() proto.APLValueType {
+	return value.variable.valType
+}
+func (value *APLValueVariable) GetBool(sim *Simulation) bool {
+	return value.variable.state().boolVal
+}
+func (value *APLValueVariable) GetInt(sim *Simulation) int32 {
+	return value.variable.state().intVal
+}
+func (value *APLValueVariable) GetFloat(sim *Simulation) float64 {
+	return value.variable.state().floatVal
+}
+func (value *APLValueVariable) GetDuration(sim *Simulation) time.Duration {
+	return value.variable.state().durationVal
+}
+func (value *APLValueVariable) GetString(sim *Simulation) string {
+	return value.variable.state().stringVal
+}
+func (value *APLValueVariable) String() string {
+	return fmt.Sprintf("Variable(%s)", value.variable.name)
+}
@@ -0,0 +1,178 @@
+package apltext
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+func TestParseExample(t *testing.T) {
+	rot, err := Parse(`
+# Elemental: keep Flame Shock up through Lava Burst casts.
+actions.precombat+=/cast,spell=other:OtherActionPotion,at=-1.5s
+actions+=/cast,spell=60043,if=dot_remaining_time(49233)>spell_cast_time(60043)
+`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PrepullActions: []*proto.APLPrepullAction{{
+			Action: &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+				SpellId: &proto.ActionID{RawId: &proto.ActionID_OtherId{OtherId: proto.OtherAction_OtherActionPotion}},
+			}}},
+			DoAtValue: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "-1.5s"}}},
+		}},
+		PriorityList: []*proto.APLListItem{{
+			Action: &proto.APLAction{
+				Condition: &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+					Op: proto.APLValueCompare_OpGt,
+					Lhs: &proto.APLValue{Value: &proto.APLValue_DotRemainingTime{DotRemainingTime: &proto.APLValueDotRemainingTime{
+						SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 49233}},
+					}}},
+					Rhs: &proto.APLValue{Value: &proto.APLValue_SpellCastTime{SpellCastTime: &proto.APLValueSpellCastTime{
+						SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 60043}},
+					}}},
+				}}},
+				Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{
+					SpellId: &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: 60043}},
+				}},
+			},
+		}},
+	}
+	if !goproto.Equal(rot, expected) {
+		t.Fatalf("Unexpected rotation:\n%s", protojson.Format(rot))
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	cases := []struct {
+		src    string
+		output string
+	}{
+		{src: "a_is_b", output: ""},
+		{src: "current_time > 1s & !gcd_is_ready | current_mana_percent < 20%", output: "current_time > 1s & !gcd_is_ready | current_mana_percent < 20%"},
+		{src: "(current_time > 1s | gcd_is_ready) & number_targets > 2", output: "(current_time > 1s | gcd_is_ready) & number_targets > 2"},
+		{src: "current_time - (remaining_time - 1s)", output: "current_time - (remaining_time - 1s)"},
+		{src: "(current_time - remaining_time) - 1s", output: "current_time - remaining_time - 1s"},
+		{src: "current_time*2+-1s", output: "current_time * 2 + -1s"},
+		{src: "!(current_time >= 5s)", output: "!(current_time >= 5s)"},
+		{src: "max(1s, current_time, \"2 s\")", output: "max(1s, current_time, \"2 s\")"},
+		{src: "and(gcd_is_ready)", output: "and(gcd_is_ready)"},
+		{src: "aura_is_active(48518, source_unit=target:1)", output: "aura_is_active(48518, source_unit=target:1)"},
+		{src: "aura_is_active(source_unit=pet:0@player:2, aura_id=item:40211#-1)", output: "aura_is_active(item:40211#-1, source_unit=pet:0@player:2)"},
+	}
+
+	for _, c := range cases {
+		value, err := ParseValue(c.src)
+		if c.output == "" {
+			if err == nil {
+				t.Errorf("Expected error parsing %q", c.src)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Failed to parse %q: %v", c.src, err)
+			continue
+		}
+		if output := FormatValue(value); output != c.output {
+			t.Errorf("Formatting %q: expected %q, got %q", c.src, c.output, output)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		src  string
+		line int
+		col  int
+	}{
+		{src: "actions+=/cast_spel,1", line: 1, col: 11},
+		{src: "\n  actions+=/cast_spell,1,if=current_time > > 1s", line: 2, col: 44},
+		{src: "actions+=/cast_spell,spell_id=1,spell_id=2", line: 1, col: 33},
+		{src: "actions+=/cast_spell,target=self,1", line: 1, col: 34},
+		{src: "actions+=/wait,1 < 2 < 3", line: 1, col: 22},
+		{src: "actions.foo.bar+=/wait,1", line: 1, col: 1},
+		{src: "actions+=/cast_spell,\"abc", line: 1, col: 22},
+	}
+
+	for _, c := range cases {
+		_, err := Parse(c.src)
+		var parseErr *Error
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected parse error for %q, got %v", c.src, err)
+			continue
+		}
+		if parseErr.Line != c.line || parseErr.Column != c.col {
+			t.Errorf("Parsing %q: expected error at %d:%d, got %v", c.src, c.line, c.col, parseErr)
+		}
+	}
+}
+
+func TestActionLists(t *testing.T) {
+	src := `actions+=/set_variable,aoe,number_targets > 2
+actions+=/run_list,aoe,if=variable(aoe)
+actions+=/call_list,single
+actions.aoe+=/cast_spell,49238
+actions.single+=/cast_spell,49237
+actions.aoe+=/cast_spell,49271
+`
+	rot, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(rot.ActionLists) != 2 || rot.ActionLists[0].Name != "aoe" || len(rot.ActionLists[0].Items) != 2 {
+		t.Fatalf("Unexpected action lists:\n%s", protojson.Format(rot))
+	}
+
+	// Lists are printed after the main list, in order of their first line.
+	expected := `actions+=/set_variable,aoe,number_targets > 2
+actions+=/run_list,aoe,if=variable(aoe)
+actions+=/call_list,single
+actions.aoe+=/cast_spell,49238
+actions.aoe+=/cast_spell,49271
+actions.single+=/cast_spell,49237
+`
+	if output := Format(rot); output != expected {
+		t.Fatalf("Unexpected output:\n%s", output)
+	}
+}
+
+// Every rotation shipped with the UI must survive a round trip through text.
+func TestRoundTripPresetRotations(t *testing.T) {
+	files, err := filepath.Glob("../../../ui/*/apls/*.apl.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Skip("No preset rotations found")
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rot := &proto.APLRotation{}
+		if err := protojson.Unmarshal(data, rot); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		rot.Type = proto.APLRotation_TypeAPL
+
+		text := Format(rot)
+		parsed, err := Parse(text)
+		if err != nil {
+			t.Errorf("%s: %v\n%s", file, err, text)
+			continue
+		}
+		if !goproto.Equal(rot, parsed) {
+			t.Errorf("%s: round trip mismatch\n%s", file, text)
+		}
+	}
+}
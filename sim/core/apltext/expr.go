@@ -0,0 +1,153 @@
+package apltext
+
+import (
+	"strconv"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Operator precedence, from loosest to tightest binding.
+const (
+	precOr = iota + 1
+	precAnd
+	precCmp
+	precAdd
+	precMul
+	precUnary
+	precPrimary
+)
+
+var cmpOps = map[string]proto.APLValueCompare_ComparisonOperator{
+	"==": proto.APLValueCompare_OpEq,
+	"!=": proto.APLValueCompare_OpNe,
+	"<":  proto.APLValueCompare_OpLt,
+	"<=": proto.APLValueCompare_OpLe,
+	">":  proto.APLValueCompare_OpGt,
+	">=": proto.APLValueCompare_OpGe,
+}
+
+var mathOps = map[string]proto.APLValueMath_MathOperator{
+	"+": proto.APLValueMath_OpAdd,
+	"-": proto.APLValueMath_OpSub,
+	"*": proto.APLValueMath_OpMul,
+	"/": proto.APLValueMath_OpDiv,
+}
+
+func (p *parser) parseExpr() *proto.APLValue {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() *proto.APLValue {
+	first := p.parseAnd()
+	if !p.isPunct("|") {
+		return first
+	}
+	vals := []*proto.APLValue{first}
+	for p.accept("|") {
+		vals = append(vals, p.parseAnd())
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: vals}}}
+}
+
+func (p *parser) parseAnd() *proto.APLValue {
+	first := p.parseCmp()
+	if !p.isPunct("&") {
+		return first
+	}
+	vals := []*proto.APLValue{first}
+	for p.accept("&") {
+		vals = append(vals, p.parseCmp())
+	}
+	return &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: vals}}}
+}
+
+func (p *parser) parseCmp() *proto.APLValue {
+	lhs := p.parseAdd()
+	tok := p.peek()
+	op, ok := cmpOps[tok.text]
+	if tok.kind != tokPunct || !ok {
+		return lhs
+	}
+	p.next()
+	rhs := p.parseAdd()
+	if next := p.peek(); next.kind == tokPunct {
+		if _, chained := cmpOps[next.text]; chained {
+			p.errorf(next, "comparisons cannot be chained, use parentheses")
+		}
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{Op: op, Lhs: lhs, Rhs: rhs}}}
+}
+
+func (p *parser) parseAdd() *proto.APLValue {
+	lhs := p.parseMul()
+	for p.isPunct("+") || p.isPunct("-") {
+		op := mathOps[p.next().text]
+		rhs := p.parseMul()
+		lhs = &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: op, Lhs: lhs, Rhs: rhs}}}
+	}
+	return lhs
+}
+
+func (p *parser) parseMul() *proto.APLValue {
+	lhs := p.parseUnary()
+	for p.isPunct("*") || p.isPunct("/") {
+		op := mathOps[p.next().text]
+		rhs := p.parseUnary()
+		lhs = &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: op, Lhs: lhs, Rhs: rhs}}}
+	}
+	return lhs
+}
+
+func (p *parser) parseUnary() *proto.APLValue {
+	if p.accept("!") {
+		return &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: p.parseUnary()}}}
+	}
+	if p.isPunct("-") {
+		// Only numeric literals can be negated; there is no negation value.
+		_, text := p.parseNumberText()
+		return newConst(text)
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() *proto.APLValue {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		return newConst(tok.text)
+	case tokString:
+		str, err := strconv.Unquote(tok.text)
+		if err != nil {
+			p.errorf(tok, "invalid string %s", tok.text)
+		}
+		return newConst(str)
+	case tokPunct:
+		if tok.text == "(" {
+			value := p.parseExpr()
+			p.expect(")")
+			return value
+		}
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			return newConst(tok.text)
+		case "none":
+			return &proto.APLValue{}
+		}
+		fd := valueOneof.Fields().ByName(protoreflect.Name(tok.text))
+		if fd == nil {
+			p.errorf(tok, "unknown value %q", tok.text)
+		}
+		value := &proto.APLValue{}
+		inner := value.ProtoReflect().Mutable(fd).Message()
+		p.parseArgs(inner, true, nil)
+		return value
+	}
+	p.errorf(tok, "expected value, found %s", tok.describe())
+	return nil
+}
+
+func newConst(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
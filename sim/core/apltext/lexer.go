@@ -0,0 +1,121 @@
+package apltext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Error is a parse error with the 1-based line and column where it occurred.
+type Error struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", err.Line, err.Column, err.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	col  int
+}
+
+func (tok token) describe() string {
+	switch tok.kind {
+	case tokEOF:
+		return "end of line"
+	case tokString:
+		return "string " + tok.text
+	default:
+		return strconv.Quote(tok.text)
+	}
+}
+
+// Two-character operators must come before their one-character prefixes.
+var punctuation = []string{
+	"<=", ">=", "==", "!=",
+	"(", ")", "[", "]", "{", "}", ",", "=", ":", "#", "@",
+	"!", "&", "|", "<", ">", "+", "-", "*", "/",
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+// Numbers keep any unit suffix (1.5s, 60%, 300ms) so constants round-trip exactly.
+func isNumberChar(c byte) bool {
+	return isIdentChar(c) || c == '.' || c == '%'
+}
+
+// Splits a single line into tokens. col is the column of src[0] within the line.
+func lex(src string, line int, col int) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case isIdentStart(c):
+			for i < len(src) && isIdentChar(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], col: col + start})
+		case isDigit(c):
+			for i < len(src) && isNumberChar(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], col: col + start})
+		case c == '"':
+			i++
+			for i < len(src) && src[i] != '"' {
+				if src[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(src) {
+				return nil, &Error{Line: line, Column: col + start, Msg: "unterminated string"}
+			}
+			i++
+			tokens = append(tokens, token{kind: tokString, text: src[start:i], col: col + start})
+		default:
+			matched := false
+			for _, punct := range punctuation {
+				if strings.HasPrefix(src[i:], punct) {
+					i += len(punct)
+					tokens = append(tokens, token{kind: tokPunct, text: punct, col: col + start})
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, &Error{Line: line, Column: col + start, Msg: fmt.Sprintf("unexpected character %q", c)}
+			}
+		}
+	}
+	return append(tokens, token{kind: tokEOF, col: col + len(src)}), nil
+}
@@ -0,0 +1,649 @@
+// Package apltext converts APL rotations to and from a compact, line-based text
+// format modeled on SimulationCraft action lists:
+//
+//	actions.precombat+=/cast_spell,48461,at=-1.5s
+//	actions+=/cast_spell,48463,if=!dot_is_active(48463)
+//	actions+=/cast_spell,60043,if=dot_remaining_time(49233) > spell_cast_time(60043)
+//	actions.aoe+=/cast_spell,49238,if=number_targets > 2
+//
+// Action, value and field names are the snake_case field names from proto/apl.proto,
+// so every action and value is available without any extra registration here.
+package apltext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	precombatList = "actions.precombat"
+	defaultList   = "actions"
+)
+
+var (
+	actionDesc = (&proto.APLAction{}).ProtoReflect().Descriptor()
+	valueDesc  = (&proto.APLValue{}).ProtoReflect().Descriptor()
+
+	actionOneof = actionDesc.Oneofs().ByName("action")
+	valueOneof  = valueDesc.Oneofs().ByName("value")
+
+	actionIDName = (&proto.ActionID{}).ProtoReflect().Descriptor().FullName()
+	unitRefName  = (&proto.UnitReference{}).ProtoReflect().Descriptor().FullName()
+)
+
+// Shorthands accepted by the parser. The printer always uses the full names.
+var actionAliases = map[string]string{
+	"cast":    "cast_spell",
+	"channel": "channel_spell",
+}
+var fieldAliases = map[string]string{
+	"spell": "spell_id",
+	"aura":  "aura_id",
+}
+
+// Parse converts rotation text into an APL rotation.
+func Parse(src string) (*proto.APLRotation, error) {
+	rot := &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+	}
+
+	for i, line := range strings.Split(src, "\n") {
+		lineNum := i + 1
+		line = strings.TrimRight(line, "\r")
+		body := strings.TrimLeft(line, " \t")
+		if body == "" || body[0] == '#' {
+			continue
+		}
+		col := len(line) - len(body) + 1
+
+		eq := strings.IndexByte(body, '=')
+		if eq < 0 {
+			return nil, &Error{Line: lineNum, Column: col, Msg: "expected 'actions+=/' or 'actions.precombat+=/'"}
+		}
+		listName := strings.TrimSpace(strings.TrimSuffix(body[:eq], "+"))
+		rest := body[eq+1:]
+		restCol := col + eq + 1
+		if strings.HasPrefix(rest, "/") {
+			rest = rest[1:]
+			restCol++
+		}
+
+		tokens, err := lex(rest, lineNum, restCol)
+		if err != nil {
+			return nil, err
+		}
+		p := &parser{line: lineNum, tokens: tokens}
+
+		switch {
+		case listName == precombatList:
+			err = p.run(func() { rot.PrepullActions = append(rot.PrepullActions, p.parsePrepullAction()) })
+		case listName == defaultList:
+			err = p.run(func() { rot.PriorityList = append(rot.PriorityList, p.parseListItem()) })
+		case strings.HasPrefix(listName, defaultList+".") && identRegex.MatchString(listName[len(defaultList)+1:]):
+			list := findOrAddActionList(rot, listName[len(defaultList)+1:])
+			err = p.run(func() { list.Items = append(list.Items, p.parseListItem()) })
+		default:
+			err = &Error{Line: lineNum, Column: col, Msg: fmt.Sprintf("unknown action list %q", listName)}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rot, nil
+}
+
+// Named lists are defined by their first line, in order of appearance.
+func findOrAddActionList(rot *proto.APLRotation, name string) *proto.APLActionList {
+	for _, list := range rot.ActionLists {
+		if list.Name == name {
+			return list
+		}
+	}
+	list := &proto.APLActionList{Name: name}
+	rot.ActionLists = append(rot.ActionLists, list)
+	return list
+}
+
+// ParseValue parses a single value expression, e.g. 'current_time > 5s'.
+func ParseValue(src string) (*proto.APLValue, error) {
+	tokens, err := lex(src, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{line: 1, tokens: tokens}
+	var value *proto.APLValue
+	err = p.run(func() {
+		value = p.parseExpr()
+		p.expectEOF()
+	})
+	return value, err
+}
+
+// ParseAction parses a single action in nested form, e.g. 'cast_spell(48461, if=current_time > 5s)'.
+func ParseAction(src string) (*proto.APLAction, error) {
+	tokens, err := lex(src, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{line: 1, tokens: tokens}
+	var action *proto.APLAction
+	err = p.run(func() {
+		action = p.parseAction(true, nil)
+		p.expectEOF()
+	})
+	return action, err
+}
+
+type parser struct {
+	line   int
+	tokens []token
+	pos    int
+}
+
+// Parse errors are raised with panic and turned back into an error here, which
+// keeps the recursive descent functions free of error plumbing.
+func (p *parser) run(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			parseErr, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			err = parseErr
+		}
+	}()
+	f()
+	return nil
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) {
+	panic(&Error{Line: p.line, Column: tok.col, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isPunct(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokPunct && tok.text == text
+}
+
+func (p *parser) accept(text string) bool {
+	if p.isPunct(text) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) token {
+	tok := p.next()
+	if tok.kind != tokPunct || tok.text != text {
+		p.errorf(tok, "expected %q, found %s", text, tok.describe())
+	}
+	return tok
+}
+
+func (p *parser) expectIdent() token {
+	tok := p.next()
+	if tok.kind != tokIdent {
+		p.errorf(tok, "expected identifier, found %s", tok.describe())
+	}
+	return tok
+}
+
+func (p *parser) expectEOF() {
+	if tok := p.peek(); tok.kind != tokEOF {
+		p.errorf(tok, "unexpected %s", tok.describe())
+	}
+}
+
+func (p *parser) parsePrepullAction() *proto.APLPrepullAction {
+	prepull := &proto.APLPrepullAction{}
+	prepull.Action = p.parseAction(false, func(key token) bool {
+		switch key.text {
+		case "at":
+			if prepull.DoAtValue != nil {
+				p.errorf(key, "duplicate field %q", key.text)
+			}
+			prepull.DoAtValue = p.parseExpr()
+		case "hide":
+			prepull.Hide = p.parseBool()
+		default:
+			return false
+		}
+		return true
+	})
+	return prepull
+}
+
+func (p *parser) parseListItem() *proto.APLListItem {
+	item := &proto.APLListItem{}
+	item.Action = p.parseAction(false, func(key token) bool {
+		switch key.text {
+		case "hide":
+			item.Hide = p.parseBool()
+		case "notes":
+			item.Notes = p.parseString()
+		default:
+			return false
+		}
+		return true
+	})
+	return item
+}
+
+// Parses an action. Top-level actions take comma-separated arguments up to the end
+// of the line, nested actions take them in parentheses. extraKeys handles keyword
+// arguments which belong to the enclosing list entry rather than the action.
+func (p *parser) parseAction(nested bool, extraKeys func(key token) bool) *proto.APLAction {
+	nameTok := p.expectIdent()
+	action := &proto.APLAction{}
+
+	var inner protoreflect.Message
+	if nameTok.text != "none" {
+		name := nameTok.text
+		if alias, ok := actionAliases[name]; ok {
+			name = alias
+		}
+		fd := actionOneof.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			p.errorf(nameTok, "unknown action %q", nameTok.text)
+		}
+		inner = action.ProtoReflect().Mutable(fd).Message()
+	}
+
+	p.parseArgs(inner, nested, func(key token) bool {
+		if key.text == "if" {
+			if action.Condition != nil {
+				p.errorf(key, "duplicate field %q", key.text)
+			}
+			action.Condition = p.parseExpr()
+			return true
+		}
+		return extraKeys != nil && extraKeys(key)
+	})
+	return action
+}
+
+// Returns the fields which may be given positionally, in declaration order. Unit
+// references are optional targeting overrides, so they are always keyword-only.
+func positionalFields(md protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	var fields []protoreflect.FieldDescriptor
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if !isUnitRef(fd) {
+			fields = append(fields, fd)
+		}
+	}
+	return fields
+}
+
+func isUnitRef(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.MessageKind && fd.Message().FullName() == unitRefName
+}
+
+// Parses the arguments of an action or value into m. m may be nil for 'none', in
+// which case only extraKeys are accepted.
+func (p *parser) parseArgs(m protoreflect.Message, nested bool, extraKeys func(key token) bool) {
+	if nested {
+		if !p.accept("(") {
+			return
+		}
+		if p.accept(")") {
+			return
+		}
+	} else if p.peek().kind == tokEOF {
+		return
+	} else {
+		p.expect(",")
+	}
+
+	var positional []protoreflect.FieldDescriptor
+	if m != nil {
+		positional = positionalFields(m.Descriptor())
+	}
+	posIdx := 0
+	seenKeyword := false
+	seen := make(map[protoreflect.FieldNumber]bool)
+
+	for {
+		if p.peek().kind == tokIdent && p.peekAt(1).kind == tokPunct && p.peekAt(1).text == "=" {
+			key := p.next()
+			p.next()
+			seenKeyword = true
+			if extraKeys != nil && extraKeys(key) {
+				// Handled by the caller.
+			} else {
+				fd := p.lookupField(m, key)
+				if seen[fd.Number()] {
+					p.errorf(key, "duplicate field %q", key.text)
+				}
+				seen[fd.Number()] = true
+				p.parseFieldValue(m, fd)
+			}
+		} else {
+			tok := p.peek()
+			if seenKeyword {
+				p.errorf(tok, "positional argument after keyword argument")
+			}
+			if posIdx >= len(positional) {
+				p.errorf(tok, "too many positional arguments")
+			}
+			fd := positional[posIdx]
+			seen[fd.Number()] = true
+			if fd.IsList() {
+				// A repeated field takes all remaining positional arguments.
+				m.Mutable(fd).List().Append(p.parseSingular(m, fd))
+			} else {
+				m.Set(fd, p.parseSingular(m, fd))
+				posIdx++
+			}
+		}
+
+		if nested {
+			if p.accept(",") {
+				continue
+			}
+			p.expect(")")
+			return
+		}
+		if p.peek().kind == tokEOF {
+			return
+		}
+		p.expect(",")
+	}
+}
+
+func (p *parser) lookupField(m protoreflect.Message, key token) protoreflect.FieldDescriptor {
+	if m == nil {
+		p.errorf(key, "unknown field %q", key.text)
+	}
+	fields := m.Descriptor().Fields()
+	fd := fields.ByName(protoreflect.Name(key.text))
+	if fd == nil {
+		if alias, ok := fieldAliases[key.text]; ok {
+			fd = fields.ByName(protoreflect.Name(alias))
+		}
+	}
+	if fd == nil {
+		p.errorf(key, "unknown field %q for %s", key.text, m.Descriptor().Name())
+	}
+	return fd
+}
+
+func (p *parser) parseFieldValue(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	if fd.IsMap() {
+		p.errorf(p.peek(), "map field %q is not supported", fd.Name())
+	}
+	if !fd.IsList() {
+		m.Set(fd, p.parseSingular(m, fd))
+		return
+	}
+
+	p.expect("[")
+	list := m.Mutable(fd).List()
+	if p.accept("]") {
+		return
+	}
+	for {
+		list.Append(p.parseSingular(m, fd))
+		if p.accept(",") {
+			continue
+		}
+		p.expect("]")
+		return
+	}
+}
+
+// Parses a single (non-list) value for field fd of m.
+func (p *parser) parseSingular(m protoreflect.Message, fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch fd.Message().FullName() {
+		case valueDesc.FullName():
+			return protoreflect.ValueOfMessage(p.parseExpr().ProtoReflect())
+		case actionDesc.FullName():
+			return protoreflect.ValueOfMessage(p.parseAction(true, nil).ProtoReflect())
+		case actionIDName:
+			if !p.isPunct("{") {
+				return protoreflect.ValueOfMessage(p.parseActionID().ProtoReflect())
+			}
+		case unitRefName:
+			if !p.isPunct("{") {
+				return protoreflect.ValueOfMessage(p.parseUnitRef().ProtoReflect())
+			}
+		}
+		var msg protoreflect.Message
+		if fd.IsList() {
+			msg = m.Mutable(fd).List().NewElement().Message()
+		} else {
+			msg = m.NewField(fd).Message()
+		}
+		p.parseMessageLiteral(msg)
+		return protoreflect.ValueOfMessage(msg)
+	case protoreflect.EnumKind:
+		tok := p.peek()
+		if tok.kind == tokIdent {
+			p.next()
+			enumVal := fd.Enum().Values().ByName(protoreflect.Name(tok.text))
+			if enumVal == nil {
+				p.errorf(tok, "unknown %s value %q", fd.Enum().Name(), tok.text)
+			}
+			return protoreflect.ValueOfEnum(enumVal.Number())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(p.parseInt(32)))
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(p.parseBool())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(p.parseInt(32)))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(p.parseInt(64))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(p.parseUint(32)))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(p.parseUint(64))
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(p.parseFloat(32)))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(p.parseFloat(64))
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(p.parseString())
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(p.parseString()))
+	}
+	p.errorf(p.peek(), "unsupported field type %s", fd.Kind())
+	return protoreflect.Value{}
+}
+
+// Parses the generic message form, '{field=value, ...}'.
+func (p *parser) parseMessageLiteral(m protoreflect.Message) {
+	p.expect("{")
+	if p.accept("}") {
+		return
+	}
+	seen := make(map[protoreflect.FieldNumber]bool)
+	for {
+		key := p.expectIdent()
+		p.expect("=")
+		fd := p.lookupField(m, key)
+		if seen[fd.Number()] {
+			p.errorf(key, "duplicate field %q", key.text)
+		}
+		seen[fd.Number()] = true
+		p.parseFieldValue(m, fd)
+		if p.accept(",") {
+			continue
+		}
+		p.expect("}")
+		return
+	}
+}
+
+// Parses an action ID: '49233' or 'spell:49233', 'item:40211', 'other:OtherActionPotion',
+// optionally followed by '#<tag>'.
+func (p *parser) parseActionID() *proto.ActionID {
+	id := &proto.ActionID{}
+	tok := p.peek()
+	if tok.kind == tokIdent {
+		p.next()
+		p.expect(":")
+		switch tok.text {
+		case "spell":
+			id.RawId = &proto.ActionID_SpellId{SpellId: int32(p.parseInt(32))}
+		case "item":
+			id.RawId = &proto.ActionID_ItemId{ItemId: int32(p.parseInt(32))}
+		case "other":
+			otherTok := p.peek()
+			if otherTok.kind == tokIdent {
+				p.next()
+				other, ok := proto.OtherAction_value[otherTok.text]
+				if !ok {
+					p.errorf(otherTok, "unknown OtherAction %q", otherTok.text)
+				}
+				id.RawId = &proto.ActionID_OtherId{OtherId: proto.OtherAction(other)}
+			} else {
+				id.RawId = &proto.ActionID_OtherId{OtherId: proto.OtherAction(p.parseInt(32))}
+			}
+		default:
+			p.errorf(tok, "expected 'spell:', 'item:' or 'other:', found %s", tok.describe())
+		}
+	} else {
+		id.RawId = &proto.ActionID_SpellId{SpellId: int32(p.parseInt(32))}
+	}
+
+	if p.accept("#") {
+		id.Tag = int32(p.parseInt(32))
+	}
+	return id
+}
+
+// Parses a unit reference: 'self', 'current_target', 'all_players', 'all_targets',
+// 'player:N', 'target:N', 'pet:N' or 'pet:N@<owner>'.
+func (p *parser) parseUnitRef() *proto.UnitReference {
+	if p.isPunct("{") {
+		ref := &proto.UnitReference{}
+		p.parseMessageLiteral(ref.ProtoReflect())
+		return ref
+	}
+
+	tok := p.expectIdent()
+	switch tok.text {
+	case "self":
+		return &proto.UnitReference{Type: proto.UnitReference_Self}
+	case "current_target":
+		return &proto.UnitReference{Type: proto.UnitReference_CurrentTarget}
+	case "all_players":
+		return &proto.UnitReference{Type: proto.UnitReference_AllPlayers}
+	case "all_targets":
+		return &proto.UnitReference{Type: proto.UnitReference_AllTargets}
+	case "player", "target", "pet":
+		ref := &proto.UnitReference{}
+		switch tok.text {
+		case "player":
+			ref.Type = proto.UnitReference_Player
+		case "target":
+			ref.Type = proto.UnitReference_Target
+		case "pet":
+			ref.Type = proto.UnitReference_Pet
+		}
+		p.expect(":")
+		ref.Index = int32(p.parseInt(32))
+		if ref.Type == proto.UnitReference_Pet && p.accept("@") {
+			ref.Owner = p.parseUnitRef()
+		}
+		return ref
+	}
+	p.errorf(tok, "unknown unit %q", tok.text)
+	return nil
+}
+
+func (p *parser) parseNumberText() (token, string) {
+	tok := p.peek()
+	negative := p.accept("-")
+	numTok := p.next()
+	if numTok.kind != tokNumber {
+		p.errorf(numTok, "expected number, found %s", numTok.describe())
+	}
+	if negative {
+		return tok, "-" + numTok.text
+	}
+	return tok, numTok.text
+}
+
+func (p *parser) parseInt(bitSize int) int64 {
+	tok, text := p.parseNumberText()
+	val, err := strconv.ParseInt(text, 10, bitSize)
+	if err != nil {
+		p.errorf(tok, "invalid integer %q", text)
+	}
+	return val
+}
+
+func (p *parser) parseUint(bitSize int) uint64 {
+	tok, text := p.parseNumberText()
+	val, err := strconv.ParseUint(text, 10, bitSize)
+	if err != nil {
+		p.errorf(tok, "invalid unsigned integer %q", text)
+	}
+	return val
+}
+
+func (p *parser) parseFloat(bitSize int) float64 {
+	tok, text := p.parseNumberText()
+	val, err := strconv.ParseFloat(text, bitSize)
+	if err != nil {
+		p.errorf(tok, "invalid number %q", text)
+	}
+	return val
+}
+
+func (p *parser) parseBool() bool {
+	tok := p.next()
+	switch tok.text {
+	case "true", "1":
+		return true
+	case "false", "0":
+		return false
+	}
+	p.errorf(tok, "expected true or false, found %s", tok.describe())
+	return false
+}
+
+// Strings may be written bare if they are a single identifier.
+func (p *parser) parseString() string {
+	tok := p.next()
+	switch tok.kind {
+	case tokIdent:
+		return tok.text
+	case tokString:
+		str, err := strconv.Unquote(tok.text)
+		if err != nil {
+			p.errorf(tok, "invalid string %s", tok.text)
+		}
+		return str
+	}
+	p.errorf(tok, "expected string, found %s", tok.describe())
+	return ""
+}
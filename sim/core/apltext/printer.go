@@ -0,0 +1,328 @@
+package apltext
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	identRegex     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	bareConstRegex = regexp.MustCompile(`^-?[0-9][0-9A-Za-z_.%]*$`)
+)
+
+// Format converts the prepull actions, priority list and action lists of rot to text. Parsing
+// the result yields a rotation equal to the input, apart from Type and Simple.
+func Format(rot *proto.APLRotation) string {
+	var sb strings.Builder
+	for _, prepull := range rot.PrepullActions {
+		var extra []string
+		if prepull.DoAtValue != nil {
+			extra = append(extra, "at="+FormatValue(prepull.DoAtValue))
+		}
+		if prepull.Hide {
+			extra = append(extra, "hide=true")
+		}
+		sb.WriteString(precombatList + "+=/" + formatTopLevelAction(prepull.Action, extra) + "\n")
+	}
+	writeListItems(&sb, defaultList, rot.PriorityList)
+	for _, list := range rot.ActionLists {
+		writeListItems(&sb, defaultList+"."+list.Name, list.Items)
+	}
+	return sb.String()
+}
+
+func writeListItems(sb *strings.Builder, listName string, items []*proto.APLListItem) {
+	for _, item := range items {
+		var extra []string
+		if item.Hide {
+			extra = append(extra, "hide=true")
+		}
+		if item.Notes != "" {
+			extra = append(extra, "notes="+strconv.Quote(item.Notes))
+		}
+		sb.WriteString(listName + "+=/" + formatTopLevelAction(item.Action, extra) + "\n")
+	}
+}
+
+// FormatValue converts a single value to its expression form.
+func FormatValue(value *proto.APLValue) string {
+	return formatValue(value, precOr)
+}
+
+// FormatAction converts a single action to its nested form, as accepted by ParseAction.
+func FormatAction(action *proto.APLAction) string {
+	name, args := formatActionParts(action)
+	if len(args) == 0 {
+		return name
+	}
+	return name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func formatTopLevelAction(action *proto.APLAction, extra []string) string {
+	name, args := formatActionParts(action)
+	return strings.Join(append([]string{name}, append(args, extra...)...), ",")
+}
+
+func formatActionParts(action *proto.APLAction) (string, []string) {
+	if action == nil {
+		return "none", nil
+	}
+
+	name := "none"
+	var args []string
+	m := action.ProtoReflect()
+	if fd := m.WhichOneof(actionOneof); fd != nil {
+		name = string(fd.Name())
+		args = formatArgs(m.Get(fd).Message())
+	}
+	if action.Condition != nil {
+		args = append(args, "if="+FormatValue(action.Condition))
+	}
+	return name, args
+}
+
+// Mirrors parser.parseArgs: leading set fields are positional, the rest are keywords.
+func formatArgs(m protoreflect.Message) []string {
+	var positional, keywords []string
+	usePositional := true
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			if !isUnitRef(fd) {
+				usePositional = false
+			}
+			continue
+		}
+
+		switch {
+		case isUnitRef(fd) || !usePositional:
+			keywords = append(keywords, string(fd.Name())+"="+formatField(fd, m.Get(fd)))
+		case fd.IsList():
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				positional = append(positional, formatSingular(fd, list.Get(j)))
+			}
+			usePositional = false
+		default:
+			positional = append(positional, formatSingular(fd, m.Get(fd)))
+		}
+	}
+	return append(positional, keywords...)
+}
+
+func formatField(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if !fd.IsList() {
+		return formatSingular(fd, v)
+	}
+	list := v.List()
+	elems := make([]string, list.Len())
+	for i := range elems {
+		elems[i] = formatSingular(fd, list.Get(i))
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+func formatSingular(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch msg := v.Message().Interface().(type) {
+		case *proto.APLValue:
+			return FormatValue(msg)
+		case *proto.APLAction:
+			return FormatAction(msg)
+		case *proto.ActionID:
+			if str, ok := formatActionID(msg); ok {
+				return str
+			}
+		case *proto.UnitReference:
+			if str, ok := formatUnitRef(msg); ok {
+				return str
+			}
+		}
+		return formatMessageLiteral(v.Message())
+	case protoreflect.EnumKind:
+		if enumVal := fd.Enum().Values().ByNumber(v.Enum()); enumVal != nil {
+			return string(enumVal.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case protoreflect.StringKind:
+		return formatString(v.String())
+	case protoreflect.BytesKind:
+		return strconv.Quote(string(v.Bytes()))
+	}
+	// Integer kinds.
+	return v.String()
+}
+
+func formatString(str string) string {
+	if identRegex.MatchString(str) {
+		return str
+	}
+	return strconv.Quote(str)
+}
+
+func formatMessageLiteral(m protoreflect.Message) string {
+	var fields []string
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fields = append(fields, string(fd.Name())+"="+formatField(fd, v))
+		return true
+	})
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+func formatActionID(id *proto.ActionID) (string, bool) {
+	var str string
+	switch rawID := id.RawId.(type) {
+	case *proto.ActionID_SpellId:
+		str = strconv.Itoa(int(rawID.SpellId))
+	case *proto.ActionID_ItemId:
+		str = fmt.Sprintf("item:%d", rawID.ItemId)
+	case *proto.ActionID_OtherId:
+		str = "other:" + rawID.OtherId.String()
+	default:
+		return "", false
+	}
+	if id.Tag != 0 {
+		str += fmt.Sprintf("#%d", id.Tag)
+	}
+	return str, true
+}
+
+func formatUnitRef(ref *proto.UnitReference) (string, bool) {
+	if ref.Owner != nil && ref.Type != proto.UnitReference_Pet {
+		return "", false
+	}
+	switch ref.Type {
+	case proto.UnitReference_Self, proto.UnitReference_CurrentTarget, proto.UnitReference_AllPlayers, proto.UnitReference_AllTargets:
+		if ref.Index != 0 || ref.Owner != nil {
+			return "", false
+		}
+		switch ref.Type {
+		case proto.UnitReference_Self:
+			return "self", true
+		case proto.UnitReference_CurrentTarget:
+			return "current_target", true
+		case proto.UnitReference_AllPlayers:
+			return "all_players", true
+		default:
+			return "all_targets", true
+		}
+	case proto.UnitReference_Player:
+		return fmt.Sprintf("player:%d", ref.Index), true
+	case proto.UnitReference_Target:
+		return fmt.Sprintf("target:%d", ref.Index), true
+	case proto.UnitReference_Pet:
+		str := fmt.Sprintf("pet:%d", ref.Index)
+		if ref.Owner != nil {
+			owner, ok := formatUnitRef(ref.Owner)
+			if !ok {
+				owner = formatMessageLiteral(ref.Owner.ProtoReflect())
+			}
+			str += "@" + owner
+		}
+		return str, true
+	}
+	return "", false
+}
+
+func formatValue(value *proto.APLValue, minPrec int) string {
+	str, prec := formatValueWithPrec(value)
+	if prec < minPrec {
+		return "(" + str + ")"
+	}
+	return str
+}
+
+// Returns the expression for value and the precedence of its outermost operator.
+func formatValueWithPrec(value *proto.APLValue) (string, int) {
+	if value == nil || value.Value == nil {
+		return "none", precPrimary
+	}
+
+	switch v := value.Value.(type) {
+	case *proto.APLValue_Const:
+		val := v.Const.Val
+		if bareConstRegex.MatchString(val) {
+			if val[0] == '-' {
+				return val, precUnary
+			}
+			return val, precPrimary
+		}
+		if val == "true" || val == "false" {
+			return val, precPrimary
+		}
+		return strconv.Quote(val), precPrimary
+	case *proto.APLValue_Or:
+		if len(v.Or.Vals) >= 2 {
+			return formatJoined(v.Or.Vals, " | ", precAnd), precOr
+		}
+	case *proto.APLValue_And:
+		if len(v.And.Vals) >= 2 {
+			return formatJoined(v.And.Vals, " & ", precCmp), precAnd
+		}
+	case *proto.APLValue_Not:
+		if v.Not.Val != nil {
+			return "!" + formatValue(v.Not.Val, precUnary), precUnary
+		}
+	case *proto.APLValue_Cmp:
+		if op := cmpOpText(v.Cmp.Op); op != "" && v.Cmp.Lhs != nil && v.Cmp.Rhs != nil {
+			return formatValue(v.Cmp.Lhs, precAdd) + " " + op + " " + formatValue(v.Cmp.Rhs, precAdd), precCmp
+		}
+	case *proto.APLValue_Math:
+		if op := mathOpText(v.Math.Op); op != "" && v.Math.Lhs != nil && v.Math.Rhs != nil {
+			prec := precAdd
+			if v.Math.Op == proto.APLValueMath_OpMul || v.Math.Op == proto.APLValueMath_OpDiv {
+				prec = precMul
+			}
+			return formatValue(v.Math.Lhs, prec) + " " + op + " " + formatValue(v.Math.Rhs, prec+1), prec
+		}
+	}
+
+	// Everything else, including operators which can't be written infix, uses call syntax.
+	m := value.ProtoReflect()
+	fd := m.WhichOneof(valueOneof)
+	args := formatArgs(m.Get(fd).Message())
+	if len(args) == 0 {
+		return string(fd.Name()), precPrimary
+	}
+	return string(fd.Name()) + "(" + strings.Join(args, ", ") + ")", precPrimary
+}
+
+func formatJoined(vals []*proto.APLValue, sep string, minPrec int) string {
+	strs := make([]string, len(vals))
+	for i, val := range vals {
+		strs[i] = formatValue(val, minPrec)
+	}
+	return strings.Join(strs, sep)
+}
+
+func cmpOpText(op proto.APLValueCompare_ComparisonOperator) string {
+	for text, cmpOp := range cmpOps {
+		if cmpOp == op {
+			return text
+		}
+	}
+	return ""
+}
+
+func mathOpText(op proto.APLValueMath_MathOperator) string {
+	for text, mathOp := range mathOps {
+		if mathOp == op {
+			return text
+		}
+	}
+	return ""
+}
@@ -278,8 +278,8 @@ func (wa *WeaponAttack) swing(sim *Simulation) time.Duration {
 	wa.swingAt = sim.CurrentTime + wa.curSwingDuration
 	attackSpell.Cast(sim, wa.unit.CurrentTarget)
 
-	if !sim.Options.Interactive && wa.unit.Rotation != nil {
-		wa.unit.Rotation.DoNextAction(sim)
+	if !wa.unit.IsInteractive(sim) && wa.unit.Rotation != nil {
+		wa.unit.ReactToEvent(sim)
 	}
 
 	return wa.swingAt
@@ -307,6 +307,10 @@ type AutoAttacks struct {
 	ranged WeaponAttack
 
 	enabled bool
+
+	// Set by HoldAutoSwingAtPull, so auto attacks don't start at the pull until
+	// EnableAutoSwing is called.
+	heldAtPull bool
 }
 
 // Options for initializing auto attacks.
@@ -438,6 +442,7 @@ func (aa *AutoAttacks) reset(sim *Simulation) {
 	}
 
 	aa.enabled = false
+	aa.heldAtPull = false
 
 	aa.mh.swingAt = NeverExpires
 	aa.oh.swingAt = NeverExpires
@@ -477,7 +482,7 @@ func (aa *AutoAttacks) startPull(sim *Simulation) {
 		return
 	}
 
-	if aa.enabled {
+	if aa.enabled || aa.heldAtPull {
 		return
 	}
 
@@ -520,12 +525,26 @@ func (aa *AutoAttacks) CancelAutoSwing(sim *Simulation) {
 	}
 }
 
+// Keeps auto attacks from starting at the pull until EnableAutoSwing is called,
+// e.g. for a rogue entering Stealth before the pull who opens with an ability.
+func (aa *AutoAttacks) HoldAutoSwingAtPull(sim *Simulation) {
+	if !aa.AutoSwingMelee && !aa.AutoSwingRanged {
+		return
+	}
+
+	aa.CancelAutoSwing(sim)
+	if sim.CurrentTime < 0 {
+		aa.heldAtPull = true
+	}
+}
+
 // Re-enables the auto swing action for the iteration
 func (aa *AutoAttacks) EnableAutoSwing(sim *Simulation) {
 	if !aa.AutoSwingMelee && !aa.AutoSwingRanged {
 		return
 	}
 
+	aa.heldAtPull = false
 	if aa.enabled {
 		return
 	}
@@ -59,6 +59,9 @@ type Aura struct {
 	// The unit this aura is attached to.
 	Unit *Unit
 
+	// The unit whose spell last applied or refreshed this aura, or nil if it wasn't applied by a spell.
+	applier *Unit
+
 	active                     bool
 	activeIndex                int32 // Position of this aura's index in the activeAuras array.
 	onCastCompleteIndex        int32 // Position of this aura's index in the onCastCompleteAuras array.
@@ -531,6 +534,7 @@ restart:
 // exists it will be replaced with the new one.
 func (aura *Aura) Activate(sim *Simulation) {
 	aura.metrics.Procs++
+	aura.applier = sim.applyingUnit
 	if aura.IsActive() {
 		if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 			aura.Unit.Log(sim, "Aura refreshed: %s", aura.ActionID)
@@ -771,13 +775,17 @@ func (at *auraTracker) OnSpellHitDealt(sim *Simulation, spell *Spell, result *Sp
 	}
 }
 func (at *auraTracker) OnSpellHitTaken(sim *Simulation, spell *Spell, result *SpellResult) {
+	// Reactions belong to the unit being hit, not the caster of the spell.
+	prevApplyingUnit := sim.applyingUnit
 	for _, aura := range at.onSpellHitTakenAuras {
 		// this check is to handle a case where auras are deactivated during iteration.
 		if !aura.active {
 			continue
 		}
+		sim.applyingUnit = aura.Unit
 		aura.OnSpellHitTaken(aura, sim, spell, result)
 	}
+	sim.applyingUnit = prevApplyingUnit
 }
 
 // Invokes the OnPeriodicDamage
@@ -790,9 +798,12 @@ func (at *auraTracker) OnPeriodicDamageDealt(sim *Simulation, spell *Spell, resu
 	}
 }
 func (at *auraTracker) OnPeriodicDamageTaken(sim *Simulation, spell *Spell, result *SpellResult) {
+	prevApplyingUnit := sim.applyingUnit
 	for _, aura := range at.onPeriodicDamageTakenAuras {
+		sim.applyingUnit = aura.Unit
 		aura.OnPeriodicDamageTaken(aura, sim, spell, result)
 	}
+	sim.applyingUnit = prevApplyingUnit
 }
 
 // Invokes the OnHeal event for all tracked Auras.
@@ -806,13 +817,17 @@ func (at *auraTracker) OnHealDealt(sim *Simulation, spell *Spell, result *SpellR
 	}
 }
 func (at *auraTracker) OnHealTaken(sim *Simulation, spell *Spell, result *SpellResult) {
+	// Reactions belong to the unit being hit, not the caster of the spell.
+	prevApplyingUnit := sim.applyingUnit
 	for _, aura := range at.onHealTakenAuras {
 		// this check is to handle a case where auras are deactivated during iteration.
 		if !aura.active {
 			continue
 		}
+		sim.applyingUnit = aura.Unit
 		aura.OnHealTaken(aura, sim, spell, result)
 	}
+	sim.applyingUnit = prevApplyingUnit
 }
 
 // Invokes the OnPeriodicHeal
@@ -825,9 +840,12 @@ func (at *auraTracker) OnPeriodicHealDealt(sim *Simulation, spell *Spell, result
 	}
 }
 func (at *auraTracker) OnPeriodicHealTaken(sim *Simulation, spell *Spell, result *SpellResult) {
+	prevApplyingUnit := sim.applyingUnit
 	for _, aura := range at.onPeriodicHealTakenAuras {
+		sim.applyingUnit = aura.Unit
 		aura.OnPeriodicHealTaken(aura, sim, spell, result)
 	}
+	sim.applyingUnit = prevApplyingUnit
 }
 
 func (at *auraTracker) GetMetricsProto() []*proto.AuraMetrics {
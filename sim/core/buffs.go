@@ -80,8 +80,8 @@ func applyBuffEffects(agent Agent, raidBuffs *proto.RaidBuffs, partyBuffs *proto
 		character.AddStats(stats.Stats{
 			stats.MeleeCrit: 5 * CritRatingPerCritChance,
 		})
-		if raidBuffs.LeaderOfThePack == proto.TristateEffect_TristateEffectImproved {
-			// TODO: healing aura from imp LotP
+		if raidBuffs.LeaderOfThePack == proto.TristateEffect_TristateEffectImproved && character.HealthIsModelled() {
+			ImprovedLeaderOfThePackAura(character)
 		}
 	}
 
@@ -279,6 +279,7 @@ func applyBuffEffects(agent Agent, raidBuffs *proto.RaidBuffs, partyBuffs *proto
 	registerPowerInfusionCD(agent, individualBuffs.PowerInfusions)
 	registerManaTideTotemCD(agent, partyBuffs.ManaTideTotems)
 	registerInnervateCD(agent, individualBuffs.Innervates)
+	registerHymnOfHopeCD(agent, individualBuffs.HymnOfHope)
 	registerDivineGuardianCD(agent, individualBuffs.DivineGuardians)
 	registerHandOfSacrificeCD(agent, individualBuffs.HandOfSacrifices)
 	registerPainSuppressionCD(agent, individualBuffs.PainSuppressions)
@@ -1145,6 +1146,73 @@ func InnervateAura(character *Character, actionTag int32) *Aura {
 	})
 }
 
+var HymnOfHopeActionID = ActionID{SpellID: 64901}
+var HymnOfHopeAuraTag = "HymnOfHope"
+
+const HymnOfHopeDuration = time.Second * 8
+const HymnOfHopeCD = time.Minute * 6
+const HymnOfHopeNumTargets = 3
+
+// Mana restored to a single target by each tick of Hymn of Hope.
+func HymnOfHopeManaPerTick(unit *Unit) float64 {
+	// This is 3%, but it increases the target's max mana by 20% for the duration
+	// so just simplify to 3 * 1.2 = 3.6%.
+	return unit.MaxMana() * 0.036
+}
+
+func registerHymnOfHopeCD(agent Agent, numHymnOfHopes int32) {
+	if numHymnOfHopes == 0 {
+		return
+	}
+
+	character := agent.GetCharacter()
+	if !character.HasManaBar() {
+		return
+	}
+
+	hymnAura := HymnOfHopeAura(character, -1)
+
+	registerExternalConsecutiveCDApproximation(
+		agent,
+		externalConsecutiveCDApproximation{
+			ActionID:         HymnOfHopeActionID.WithTag(-1),
+			AuraTag:          HymnOfHopeAuraTag,
+			CooldownPriority: CooldownPriorityDefault,
+			AuraDuration:     HymnOfHopeDuration,
+			AuraCD:           HymnOfHopeCD,
+			Type:             CooldownTypeMana,
+			ShouldActivate: func(sim *Simulation, character *Character) bool {
+				return character.CurrentManaPercent() < 0.1
+			},
+			AddAura: func(sim *Simulation, character *Character) {
+				hymnAura.Activate(sim)
+			},
+		},
+		numHymnOfHopes)
+}
+
+// Hymn of Hope received from a priest outside the sim. Priests in the raid
+// distribute their own Hymn of Hope ticks using LowestManaUnits instead.
+func HymnOfHopeAura(character *Character, actionTag int32) *Aura {
+	actionID := HymnOfHopeActionID.WithTag(actionTag)
+	manaMetrics := character.NewManaMetrics(actionID)
+	return character.GetOrRegisterAura(Aura{
+		Label:    "HymnOfHope-" + actionID.String(),
+		Tag:      HymnOfHopeAuraTag,
+		ActionID: actionID,
+		Duration: HymnOfHopeDuration,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			StartPeriodicAction(sim, PeriodicActionOptions{
+				Period:   HymnOfHopeDuration / 4,
+				NumTicks: 4,
+				OnAction: func(sim *Simulation) {
+					character.AddMana(sim, HymnOfHopeManaPerTick(&character.Unit), manaMetrics)
+				},
+			})
+		},
+	})
+}
+
 var ManaTideTotemActionID = ActionID{SpellID: 16190}
 var ManaTideTotemAuraTag = "ManaTideTotem"
 
@@ -1186,15 +1254,22 @@ func registerManaTideTotemCD(agent Agent, numManaTideTotems int32) {
 		numManaTideTotems)
 }
 
+// A non-negative actionTag is the index of the shaman casting the totem, who restores
+// mana to their whole party. Otherwise the totem is an approximation of one dropped
+// outside the sim, and only restores mana to the character it is registered on.
 func ManaTideTotemAura(character *Character, actionTag int32) *Aura {
 	actionID := ManaTideTotemActionID.WithTag(actionTag)
 
-	metrics := make([]*ResourceMetrics, len(character.Party.Players))
-	for i, player := range character.Party.Players {
-		char := player.GetCharacter()
-		if char.HasManaBar() {
-			metrics[i] = char.NewManaMetrics(actionID)
-		}
+	var targets []*Unit
+	if actionTag >= 0 {
+		targets = character.Party.GetManaUsers()
+	} else if character.HasManaBar() {
+		targets = []*Unit{&character.Unit}
+	}
+
+	metrics := make([]*ResourceMetrics, len(targets))
+	for i, unit := range targets {
+		metrics[i] = unit.NewManaMetrics(actionID)
 	}
 
 	return character.GetOrRegisterAura(Aura{
@@ -1207,11 +1282,8 @@ func ManaTideTotemAura(character *Character, actionTag int32) *Aura {
 				Period:   ManaTideTotemDuration / 4,
 				NumTicks: 4,
 				OnAction: func(sim *Simulation) {
-					for i, player := range character.Party.Players {
-						if metrics[i] != nil {
-							char := player.GetCharacter()
-							char.AddMana(sim, 0.06*char.MaxMana(), metrics[i])
-						}
+					for i, unit := range targets {
+						unit.AddMana(sim, 0.06*unit.MaxMana(), metrics[i])
 					}
 				},
 			})
@@ -1244,6 +1316,70 @@ func replenishmentAura(unit *Unit, _ ActionID) *Aura {
 	return unit.ReplenishmentAura
 }
 
+// Returns all units eligible for raid-wide mana effects (party/raid members + their pets, but no guardians).
+func (raid *Raid) GetManaUsers() []*Unit {
+	var manaUsers []*Unit
+	for _, party := range raid.Parties {
+		manaUsers = append(manaUsers, party.GetManaUsers()...)
+	}
+	return manaUsers
+}
+
+// Returns all units in this party eligible for party-wide mana effects (players + their pets, but no guardians).
+func (party *Party) GetManaUsers() []*Unit {
+	var manaUsers []*Unit
+	for _, player := range party.Players {
+		character := player.GetCharacter()
+		if character.HasManaBar() {
+			manaUsers = append(manaUsers, &character.Unit)
+		}
+	}
+	for _, petAgent := range party.Pets {
+		pet := petAgent.GetPet()
+		if pet.HasManaBar() && !pet.IsGuardian() {
+			manaUsers = append(manaUsers, &pet.Unit)
+		}
+	}
+	return manaUsers
+}
+
+// Returns up to numUnits of the given units, ordered from lowest to highest mana percent.
+// Used by effects which pick their targets based on who needs mana the most.
+func LowestManaUnits(units []*Unit, numUnits int) []*Unit {
+	sorted := slices.Clone(units)
+	slices.SortStableFunc(sorted, func(v1, v2 *Unit) int {
+		return cmp.Compare(v1.CurrentManaPercent(), v2.CurrentManaPercent())
+	})
+	return sorted[:min(numUnits, len(sorted))]
+}
+
+// Mana given by a player to other raid members. Each recipient tracks the gain in
+// its own metrics, tagged with the giving player's index.
+type RaidManaSource struct {
+	Units   []*Unit
+	metrics []*ResourceMetrics
+}
+
+func (character *Character) NewRaidManaSource(actionID ActionID) *RaidManaSource {
+	source := &RaidManaSource{
+		Units:   character.Env.Raid.GetManaUsers(),
+		metrics: make([]*ResourceMetrics, len(character.Env.AllUnits)),
+	}
+	for _, unit := range source.Units {
+		source.metrics[unit.UnitIndex] = unit.NewManaMetrics(actionID.WithTag(character.Index))
+	}
+	return source
+}
+
+// Returns up to numUnits recipients, ordered from lowest to highest mana percent.
+func (source *RaidManaSource) LowestManaUnits(numUnits int) []*Unit {
+	return LowestManaUnits(source.Units, numUnits)
+}
+
+func (source *RaidManaSource) AddMana(sim *Simulation, unit *Unit, amount float64) {
+	unit.AddMana(sim, amount, source.metrics[unit.UnitIndex])
+}
+
 type ReplenishmentSource int
 
 // Returns a new aura whose activation will give the Replenishment buff to 10 party/raid members.
@@ -1255,23 +1391,7 @@ func (raid *Raid) NewReplenishmentSource(actionID ActionID) ReplenishmentSource
 		return newReplSource
 	}
 
-	// Get the list of all eligible units (party/raid members + their pets, but no guardians).
-	var manaUsers []*Unit
-	for _, party := range raid.Parties {
-		for _, player := range party.Players {
-			character := player.GetCharacter()
-			if character.HasManaBar() {
-				manaUsers = append(manaUsers, &character.Unit)
-			}
-		}
-		for _, petAgent := range party.Pets {
-			pet := petAgent.GetPet()
-			if pet.HasManaBar() && !pet.IsGuardian() {
-				manaUsers = append(manaUsers, &pet.Unit)
-			}
-		}
-	}
-	raid.replenishmentUnits = manaUsers
+	raid.replenishmentUnits = raid.GetManaUsers()
 
 	// Initialize replenishment aura for all applicable units.
 	for _, unit := range raid.replenishmentUnits {
@@ -1321,6 +1441,38 @@ func (raid *Raid) ProcReplenishment(sim *Simulation, src ReplenishmentSource) {
 	}
 }
 
+// Self-heal granted to party members by a druid with Improved Leader of the Pack.
+// Only applied when the character's health is modelled, since otherwise it has no effect.
+func ImprovedLeaderOfThePackAura(character *Character) *Aura {
+	const label = "Improved Leader of the Pack"
+	if aura := character.GetAura(label); aura != nil {
+		// The druid providing the buff registers their own version of this aura.
+		return aura
+	}
+
+	healthMetrics := character.NewHealthMetrics(ActionID{SpellID: 34300})
+
+	icd := Cooldown{
+		Timer:    character.NewTimer(),
+		Duration: time.Second * 6,
+	}
+
+	return MakePermanent(character.RegisterAura(Aura{
+		Icd:   &icd,
+		Label: label,
+		OnSpellHitDealt: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			if !spell.ProcMask.Matches(ProcMaskMeleeOrRanged) || !result.Outcome.Matches(OutcomeCrit) {
+				return
+			}
+			if !icd.IsReady(sim) {
+				return
+			}
+			icd.Use(sim)
+			character.GainHealth(sim, character.MaxHealth()*0.04*character.PseudoStats.HealingTakenMultiplier, healthMetrics)
+		},
+	}))
+}
+
 func TotemOfWrathAura(character *Character) *Aura {
 	aura := character.GetOrRegisterAura(Aura{
 		Label:      "Totem of Wrath",
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_RestorationShaman{},
+		proto.Spec_SpecRestorationShaman,
+		newFakeManaUser,
+		func(player *proto.Player, spec interface{}) {
+			player.Spec = spec.(*proto.Player_RestorationShaman)
+		},
+	)
+}
+
+// A player with a mana bar and nothing else, for testing mana given to other raid members.
+type fakeManaUser struct {
+	Character
+	manaSource   *RaidManaSource
+	manaTideAura *Aura
+}
+
+func (fmu *fakeManaUser) GetCharacter() *Character {
+	return &fmu.Character
+}
+
+func (fmu *fakeManaUser) Initialize() {
+	if fmu.Index == 0 {
+		fmu.manaSource = fmu.NewRaidManaSource(HymnOfHopeActionID)
+		fmu.manaTideAura = ManaTideTotemAura(&fmu.Character, fmu.Index)
+	}
+}
+
+func (fmu *fakeManaUser) ApplyTalents()            {}
+func (fmu *fakeManaUser) Reset(_ *Simulation)      {}
+func (fmu *fakeManaUser) OnGCDReady(_ *Simulation) {}
+
+func newFakeManaUser(char *Character, _ *proto.Player) Agent {
+	fmu := &fakeManaUser{
+		Character: *char,
+	}
+	fmu.EnableManaBar()
+	return fmu
+}
+
+// A raid of 3 players in the first party and 1 in the second, where the first
+// player gives mana to the others.
+func setupFakeManaRaid() (*Simulation, []*fakeManaUser) {
+	newPlayer := func(name string) *proto.Player {
+		return &proto.Player{
+			Name:      name,
+			Class:     proto.Class_ClassShaman,
+			Race:      proto.Race_RaceTroll,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_RestorationShaman{},
+			Equipment: &proto.EquipmentSpec{},
+		}
+	}
+
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{Players: []*proto.Player{newPlayer("Caster"), newPlayer("A"), newPlayer("B")}, Buffs: &proto.PartyBuffs{}},
+				{Players: []*proto.Player{newPlayer("C")}, Buffs: &proto.PartyBuffs{}},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 83}},
+			Duration: 180,
+		},
+	})
+	sim.Reset()
+
+	var players []*fakeManaUser
+	for _, party := range sim.Raid.Parties {
+		for _, player := range party.Players {
+			players = append(players, player.(*fakeManaUser))
+		}
+	}
+	return sim, players
+}
+
+// Total mana gained by a unit from the given action, and whether it has metrics for it.
+func manaGainedFrom(unit *Unit, actionID ActionID) (float64, bool) {
+	for _, metrics := range unit.Metrics.resources {
+		if metrics.Type == proto.ResourceType_ResourceTypeMana && metrics.ActionID == actionID {
+			return metrics.ActualGain, true
+		}
+	}
+	return 0, false
+}
+
+func TestRaidManaSourceLowestMana(t *testing.T) {
+	sim, players := setupFakeManaRaid()
+	caster, a, b, c := players[0], players[1], players[2], players[3]
+
+	a.currentMana = a.MaxMana() * 0.5
+	b.currentMana = b.MaxMana() * 0.9
+	c.currentMana = c.MaxMana() * 0.2
+
+	targets := caster.manaSource.LowestManaUnits(HymnOfHopeNumTargets)
+	if len(targets) != 3 || targets[0] != &c.Unit || targets[1] != &a.Unit || targets[2] != &b.Unit {
+		t.Fatalf("Expected the 3 lowest mana players from either party, lowest first")
+	}
+
+	for _, unit := range targets {
+		caster.manaSource.AddMana(sim, unit, HymnOfHopeManaPerTick(unit))
+	}
+
+	// Each recipient's gain is in its own metrics, tagged with the caster's index.
+	actionID := HymnOfHopeActionID.WithTag(caster.Index)
+	for _, player := range []*fakeManaUser{a, b, c} {
+		if gain, _ := manaGainedFrom(&player.Unit, actionID); !WithinToleranceFloat64(HymnOfHopeManaPerTick(&player.Unit), gain, 0.001) {
+			t.Fatalf("Expected %s to gain %0.3f mana from the caster, got %0.3f", player.Label, HymnOfHopeManaPerTick(&player.Unit), gain)
+		}
+	}
+	if gain, _ := manaGainedFrom(&caster.Unit, actionID); gain != 0 {
+		t.Fatalf("Expected the caster with full mana to gain nothing, got %0.3f", gain)
+	}
+}
+
+func TestManaTideTotemFromCaster(t *testing.T) {
+	sim, players := setupFakeManaRaid()
+	caster, a, c := players[0], players[1], players[3]
+
+	a.currentMana = a.MaxMana() * 0.5
+	c.currentMana = c.MaxMana() * 0.5
+
+	caster.manaTideAura.Activate(sim)
+	for sim.CurrentTime <= ManaTideTotemDuration {
+		sim.Step()
+	}
+
+	// Only the caster's party gains mana, and each member tracks it under the caster's index.
+	actionID := ManaTideTotemActionID.WithTag(caster.Index)
+	if gain, _ := manaGainedFrom(&a.Unit, actionID); !WithinToleranceFloat64(0.24*a.MaxMana(), gain, 0.001) {
+		t.Fatalf("Expected a party member to gain %0.3f mana, got %0.3f", 0.24*a.MaxMana(), gain)
+	}
+	if _, ok := manaGainedFrom(&c.Unit, actionID); ok {
+		t.Fatalf("Expected a player in another party to have no Mana Tide Totem metrics")
+	}
+}
@@ -89,7 +89,7 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 		return nil, fmt.Errorf("bulksim: expected exactly 1 player, found %d", playerCount)
 	}
 	if player.GetDatabase() != nil {
-		addToDatabase(player.GetDatabase())
+		addPlayerDatabase(player.GetDatabase())
 	}
 	// reduce to just base party.
 	b.Request.BaseSettings.Raid.Parties = []*proto.Party{b.Request.BaseSettings.Raid.Parties[0]}
@@ -101,45 +101,7 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 
 	if b.Request.BulkSettings.AutoGem {
 		for _, replaceItem := range b.Request.BulkSettings.Items {
-			itemData := ItemsByID[replaceItem.Id]
-			if len(itemData.GemSockets) == 0 && itemData.Type != proto.ItemType_ItemTypeWaist {
-				continue
-			}
-
-			sockets := make([]int32, len(itemData.GemSockets))
-			if len(sockets) < len(replaceItem.Gems) {
-				// this means the extra gem was specified, just add an extra element
-				sockets = append(sockets, 0)
-			}
-			// now copy over what we have from inputs.
-			copy(sockets, replaceItem.Gems)
-			if itemData.Type == proto.ItemType_ItemTypeWaist {
-				// Assume waist always has the eternal belt buckle and add extra red gem.
-				// TODO: is there a better way to do this?
-				// Should we have a 'prismatic' standard gem in the defaults?
-				if len(sockets) == len(itemData.GemSockets) {
-					sockets = append(sockets, b.Request.BulkSettings.DefaultRedGem)
-				} else if len(sockets) > len(itemData.GemSockets) && sockets[len(sockets)-1] == 0 {
-					sockets[len(sockets)-1] = b.Request.BulkSettings.DefaultRedGem
-				}
-			}
-
-			for i, color := range itemData.GemSockets {
-				if sockets[i] > 0 {
-					// This means gem was already specified, skip autogem
-					continue
-				}
-				if ColorIntersects(color, proto.GemColor_GemColorRed) {
-					sockets[i] = b.Request.BulkSettings.DefaultRedGem
-				} else if ColorIntersects(color, proto.GemColor_GemColorYellow) {
-					sockets[i] = b.Request.BulkSettings.DefaultYellowGem
-				} else if ColorIntersects(color, proto.GemColor_GemColorBlue) {
-					sockets[i] = b.Request.BulkSettings.DefaultBlueGem
-				} else if ColorIntersects(color, proto.GemColor_GemColorMeta) {
-					sockets[i] = b.Request.BulkSettings.DefaultMetaGem
-				}
-			}
-			replaceItem.Gems = sockets
+			autoGemItem(b.Request.BulkSettings, replaceItem)
 		}
 	}
 
@@ -164,7 +126,7 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 		if !ok {
 			return nil, fmt.Errorf("unknown item with id %d in bulk settings", is.Id)
 		}
-		for _, slot := range eligibleSlotsForItem(item) {
+		for _, slot := range EligibleSlotsForItem(item) {
 			distinctItemSlotCombos = append(distinctItemSlotCombos, &itemWithSlot{
 				Item:  is,
 				Slot:  slot,
@@ -284,6 +246,8 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 	bum.Actions = nil
 	bum.Auras = nil
 	bum.Resources = nil
+	bum.Absorbs = nil
+	bum.AplEntries = nil
 	bum.Pets = nil
 
 	result = &proto.BulkSimResult{
@@ -297,6 +261,8 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 		um.Actions = nil
 		um.Auras = nil
 		um.Resources = nil
+		um.Absorbs = nil
+		um.AplEntries = nil
 		um.Pets = nil
 		result.Results = append(result.Results, &proto.BulkComboResult{
 			ItemsAdded:    r.ChangeLog.AddedItems,
@@ -314,6 +280,22 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 	return result, nil
 }
 
+// Returns a progress channel which discards everything sent to it until ctx is
+// done, for callers which don't report progress.
+func discardProgress(ctx context.Context) chan *proto.ProgressMetrics {
+	progress := make(chan *proto.ProgressMetrics, 10)
+	go func() {
+		for {
+			select {
+			case <-progress:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return progress
+}
+
 func (b *bulkSimRunner) getRankedResults(pctx context.Context, validCombos []singleBulkSim, iterations int64, progress chan *proto.ProgressMetrics) ([]*itemSubstitutionSimResult, *itemSubstitutionSimResult, error) {
 	concurrency := runtime.NumCPU() + 1
 	if concurrency <= 0 {
@@ -479,6 +461,49 @@ func (es *equipmentSubstitution) CanonicalHash() string {
 	return strings.Join(parts, ":")
 }
 
+// autoGemItem fills the empty sockets of replaceItem with the default gems from settings.
+func autoGemItem(settings *proto.BulkSettings, replaceItem *proto.ItemSpec) {
+	itemData := ItemsByID[replaceItem.Id]
+	if len(itemData.GemSockets) == 0 && itemData.Type != proto.ItemType_ItemTypeWaist {
+		return
+	}
+
+	sockets := make([]int32, len(itemData.GemSockets))
+	if len(sockets) < len(replaceItem.Gems) {
+		// this means the extra gem was specified, just add an extra element
+		sockets = append(sockets, 0)
+	}
+	// now copy over what we have from inputs.
+	copy(sockets, replaceItem.Gems)
+	if itemData.Type == proto.ItemType_ItemTypeWaist {
+		// Assume waist always has the eternal belt buckle and add extra red gem.
+		// TODO: is there a better way to do this?
+		// Should we have a 'prismatic' standard gem in the defaults?
+		if len(sockets) == len(itemData.GemSockets) {
+			sockets = append(sockets, settings.DefaultRedGem)
+		} else if len(sockets) > len(itemData.GemSockets) && sockets[len(sockets)-1] == 0 {
+			sockets[len(sockets)-1] = settings.DefaultRedGem
+		}
+	}
+
+	for i, color := range itemData.GemSockets {
+		if sockets[i] > 0 {
+			// This means gem was already specified, skip autogem
+			continue
+		}
+		if ColorIntersects(color, proto.GemColor_GemColorRed) {
+			sockets[i] = settings.DefaultRedGem
+		} else if ColorIntersects(color, proto.GemColor_GemColorYellow) {
+			sockets[i] = settings.DefaultYellowGem
+		} else if ColorIntersects(color, proto.GemColor_GemColorBlue) {
+			sockets[i] = settings.DefaultBlueGem
+		} else if ColorIntersects(color, proto.GemColor_GemColorMeta) {
+			sockets[i] = settings.DefaultMetaGem
+		}
+	}
+	replaceItem.Gems = sockets
+}
+
 // isValidEquipment returns true if the specified equipment spec is valid. An equipment spec
 // is valid if it does not reference a two-hander and off-hand weapon combo.
 func isValidEquipment(equipment *proto.EquipmentSpec) bool {
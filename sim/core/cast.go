@@ -130,7 +130,7 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 
 		if effectiveTime := spell.CurCast.EffectiveTime(); effectiveTime != 0 {
 			spell.SpellMetrics[target.UnitIndex].TotalCastTime += effectiveTime
-			spell.Unit.SetGCDTimer(sim, sim.CurrentTime+effectiveTime)
+			spell.Unit.setGCDTimerWithLatency(sim, sim.CurrentTime+effectiveTime)
 		}
 
 		// Hardcasts
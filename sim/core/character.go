@@ -88,7 +88,7 @@ type Character struct {
 
 func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character {
 	if player.Database != nil {
-		addToDatabase(player.Database)
+		addPlayerDatabase(player.Database)
 	}
 
 	character := Character{
@@ -106,6 +106,7 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 			ChannelClipDelay:     max(0, time.Duration(player.ChannelClipDelayMs)*time.Millisecond),
 			DistanceFromTarget:   player.DistanceFromTarget,
 			NibelungAverageCasts: player.NibelungAverageCasts,
+			latency:              newLatencyModel(player.Latency),
 		},
 
 		Name:  player.Name,
@@ -306,7 +307,7 @@ func (character *Character) clearBuildPhaseAuras(phase CharacterBuildPhase) {
 // Apply effects from all equipped core.
 func (character *Character) applyItemEffects(agent Agent) {
 	for slot, eq := range character.Equipment {
-		if applyItemEffect, ok := itemEffects[eq.ID]; ok {
+		if applyItemEffect, ok := itemEffects[eq.ItemEffectID()]; ok {
 			applyItemEffect(agent)
 		}
 
@@ -465,7 +466,7 @@ func (character *Character) initialize(agent Agent) {
 				return
 			}
 
-			if sim.Options.Interactive {
+			if character.IsInteractive(sim) {
 				if character.GCD.IsReady(sim) {
 					sim.NeedsInput = true
 				}
@@ -512,6 +513,7 @@ func (character *Character) FillPlayerStats(playerStats *proto.PlayerStats) {
 	}
 	character.clearBuildPhaseAuras(CharacterBuildPhaseAll)
 	playerStats.Sets = character.GetActiveSetBonusNames()
+	playerStats.Warnings = character.unimplementedEffectWarnings()
 
 	playerStats.Metadata = character.GetMetadata()
 	for _, pet := range character.Pets {
@@ -552,12 +554,12 @@ func (character *Character) HasGlyph(glyphID int32) bool {
 }
 
 func (character *Character) HasTrinketEquipped(itemID int32) bool {
-	return character.Trinket1().ID == itemID ||
-		character.Trinket2().ID == itemID
+	return character.Trinket1().ItemEffectID() == itemID ||
+		character.Trinket2().ItemEffectID() == itemID
 }
 
 func (character *Character) HasRingEquipped(itemID int32) bool {
-	return character.Finger1().ID == itemID || character.Finger2().ID == itemID
+	return character.Finger1().ItemEffectID() == itemID || character.Finger2().ItemEffectID() == itemID
 }
 
 func (character *Character) HasMetaGemEquipped(gemID int32) bool {
@@ -621,7 +623,7 @@ func (character *Character) GetProcMaskForEnchant(effectID int32) ProcMask {
 
 func (character *Character) GetProcMaskForItem(itemID int32) ProcMask {
 	return character.getProcMaskFor(func(weapon *Item) bool {
-		return weapon.ID == itemID
+		return weapon.ItemEffectID() == itemID
 	})
 }
 
@@ -669,6 +671,9 @@ func (character *Character) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = character.Name
 	metrics.UnitIndex = character.UnitIndex
 	metrics.Auras = character.auraTracker.GetMetricsProto()
+	if character.Rotation != nil {
+		metrics.AplEntries = character.Rotation.getEntryMetricsProto()
+	}
 
 	metrics.Pets = make([]*proto.UnitMetrics, len(character.Pets))
 	for i, pet := range character.Pets {
@@ -0,0 +1,473 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+type Consumable struct {
+	ID   int32
+	Name string
+	Type proto.ConsumableType
+
+	Stats          stats.Stats
+	AlchemistStats stats.Stats
+	BuffDuration   time.Duration
+
+	MinMana   float64
+	MaxMana   float64
+	MinHealth float64
+	MaxHealth float64
+
+	School    SpellSchool
+	MinDamage float64
+	MaxDamage float64
+
+	Cooldown       time.Duration
+	SharedCooldown time.Duration
+}
+
+var ConsumablesByID = map[int32]Consumable{}
+
+func ConsumableFromProto(pData *proto.SimConsumable) Consumable {
+	consumable := Consumable{
+		ID:             pData.Id,
+		Name:           pData.Name,
+		Type:           pData.Type,
+		Stats:          stats.FromFloatArray(pData.Stats),
+		AlchemistStats: stats.FromFloatArray(pData.AlchemistStats),
+		BuffDuration:   DurationFromSeconds(pData.BuffDuration),
+		MinMana:        pData.MinMana,
+		MaxMana:        pData.MaxMana,
+		MinHealth:      pData.MinHealth,
+		MaxHealth:      pData.MaxHealth,
+		School:         SpellSchoolFromProto(pData.School),
+		MinDamage:      pData.MinDamage,
+		MaxDamage:      pData.MaxDamage,
+		Cooldown:       DurationFromSeconds(pData.Cooldown),
+		SharedCooldown: DurationFromSeconds(pData.SharedCooldown),
+	}
+	if consumable.SharedCooldown == 0 && (consumable.Type == proto.ConsumableType_ConsumableTypePotion || consumable.Type == proto.ConsumableType_ConsumableTypeExplosive) {
+		consumable.SharedCooldown = time.Minute
+	}
+	return consumable
+}
+
+// Whether using this consumable does anything that can be described by data alone.
+func (consumable *Consumable) hasOnUseEffect() bool {
+	return (consumable.BuffDuration > 0 && consumable.Stats != stats.Stats{}) || consumable.MaxMana > 0 || consumable.MaxHealth > 0
+}
+
+// Looks up a catalog entry, panicking if the ID is unknown or belongs to a
+// consumable of another type. User input is checked by validateConsumes first.
+func getConsumable(id int32, consumableType proto.ConsumableType) (Consumable, bool) {
+	if id == 0 {
+		return Consumable{}, false
+	}
+	consumable, ok := ConsumablesByID[id]
+	if !ok {
+		panic(fmt.Sprintf("No consumable with id: %d", id))
+	}
+	if consumable.Type != consumableType {
+		panic(fmt.Sprintf("Consumable %d (%s) is a %s, not a %s", id, consumable.Name, consumable.Type, consumableType))
+	}
+	return consumable, true
+}
+
+// Checks that every consumable ID set in consumes is in the catalog and has the right type,
+// so bad IDs fail the request with an error instead of a panic while building the sim.
+func validateConsumes(consumes *proto.Consumes) error {
+	fields := []struct {
+		name           string
+		id             int32
+		consumableType proto.ConsumableType
+	}{
+		{"flask_id", consumes.GetFlaskId(), proto.ConsumableType_ConsumableTypeFlask},
+		{"battle_elixir_id", consumes.GetBattleElixirId(), proto.ConsumableType_ConsumableTypeBattleElixir},
+		{"guardian_elixir_id", consumes.GetGuardianElixirId(), proto.ConsumableType_ConsumableTypeGuardianElixir},
+		{"food_id", consumes.GetFoodId(), proto.ConsumableType_ConsumableTypeFood},
+		{"pet_food_id", consumes.GetPetFoodId(), proto.ConsumableType_ConsumableTypePetFood},
+		{"default_potion_id", consumes.GetDefaultPotionId(), proto.ConsumableType_ConsumableTypePotion},
+		{"prepop_potion_id", consumes.GetPrepopPotionId(), proto.ConsumableType_ConsumableTypePotion},
+		{"default_conjured_id", consumes.GetDefaultConjuredId(), proto.ConsumableType_ConsumableTypeConjured},
+		{"filler_explosive_id", consumes.GetFillerExplosiveId(), proto.ConsumableType_ConsumableTypeExplosive},
+	}
+	for _, field := range fields {
+		if field.id == 0 {
+			continue
+		}
+		consumable, ok := ConsumablesByID[field.id]
+		if !ok {
+			return fmt.Errorf("%s: no consumable with id %d", field.name, field.id)
+		}
+		if consumable.Type != field.consumableType {
+			return fmt.Errorf("%s: consumable %d (%s) is a %s, not a %s", field.name, field.id, consumable.Name, consumable.Type, field.consumableType)
+		}
+	}
+	return nil
+}
+
+func validateRaidConsumes(raid *proto.Raid) error {
+	for _, party := range raid.GetParties() {
+		for _, player := range party.GetPlayers() {
+			if err := validateConsumes(player.GetConsumes()); err != nil {
+				return fmt.Errorf("%s: %w", player.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Item IDs of a player's consumables, preferring the ID fields of Consumes and
+// falling back to the legacy enum fields.
+type ConsumableIDs struct {
+	Flask           int32
+	BattleElixir    int32
+	GuardianElixir  int32
+	Food            int32
+	PetFood         int32
+	DefaultPotion   int32
+	PrepopPotion    int32
+	DefaultConjured int32
+	FillerExplosive int32
+}
+
+func GetConsumableIDs(consumes *proto.Consumes) ConsumableIDs {
+	return ConsumableIDs{
+		Flask:           consumableID(consumes.FlaskId, legacyFlaskIDs[consumes.Flask]),
+		BattleElixir:    consumableID(consumes.BattleElixirId, legacyBattleElixirIDs[consumes.BattleElixir]),
+		GuardianElixir:  consumableID(consumes.GuardianElixirId, legacyGuardianElixirIDs[consumes.GuardianElixir]),
+		Food:            consumableID(consumes.FoodId, legacyFoodIDs[consumes.Food]),
+		PetFood:         consumableID(consumes.PetFoodId, legacyPetFoodIDs[consumes.PetFood]),
+		DefaultPotion:   consumableID(consumes.DefaultPotionId, legacyPotionIDs[consumes.DefaultPotion]),
+		PrepopPotion:    consumableID(consumes.PrepopPotionId, legacyPotionIDs[consumes.PrepopPotion]),
+		DefaultConjured: consumableID(consumes.DefaultConjuredId, legacyConjuredIDs[consumes.DefaultConjured]),
+		FillerExplosive: consumableID(consumes.FillerExplosiveId, legacyExplosiveIDs[consumes.FillerExplosive]),
+	}
+}
+
+func consumableID(id int32, legacyID int32) int32 {
+	if id != 0 {
+		return id
+	}
+	return legacyID
+}
+
+var legacyFlaskIDs = map[proto.Flask]int32{
+	proto.Flask_FlaskOfTheFrostWyrm:      46376,
+	proto.Flask_FlaskOfEndlessRage:       46377,
+	proto.Flask_FlaskOfPureMojo:          46378,
+	proto.Flask_FlaskOfStoneblood:        46379,
+	proto.Flask_LesserFlaskOfToughness:   40079,
+	proto.Flask_LesserFlaskOfResistance:  44939,
+	proto.Flask_FlaskOfBlindingLight:     22861,
+	proto.Flask_FlaskOfMightyRestoration: 22853,
+	proto.Flask_FlaskOfPureDeath:         22866,
+	proto.Flask_FlaskOfRelentlessAssault: 22854,
+	proto.Flask_FlaskOfSupremePower:      13512,
+	proto.Flask_FlaskOfFortification:     22851,
+	proto.Flask_FlaskOfChromaticWonder:   33208,
+}
+
+var legacyBattleElixirIDs = map[proto.BattleElixir]int32{
+	proto.BattleElixir_ElixirOfAccuracy:         44325,
+	proto.BattleElixir_ElixirOfArmorPiercing:    44330,
+	proto.BattleElixir_ElixirOfDeadlyStrikes:    44327,
+	proto.BattleElixir_ElixirOfExpertise:        44329,
+	proto.BattleElixir_ElixirOfLightningSpeed:   44331,
+	proto.BattleElixir_ElixirOfMightyAgility:    39666,
+	proto.BattleElixir_ElixirOfMightyStrength:   40073,
+	proto.BattleElixir_GurusElixir:              40076,
+	proto.BattleElixir_SpellpowerElixir:         40070,
+	proto.BattleElixir_WrathElixir:              40068,
+	proto.BattleElixir_AdeptsElixir:             28103,
+	proto.BattleElixir_ElixirOfDemonslaying:     9224,
+	proto.BattleElixir_ElixirOfMajorAgility:     22831,
+	proto.BattleElixir_ElixirOfMajorFirePower:   22833,
+	proto.BattleElixir_ElixirOfMajorFrostPower:  22827,
+	proto.BattleElixir_ElixirOfMajorShadowPower: 22835,
+	proto.BattleElixir_ElixirOfMajorStrength:    22824,
+	proto.BattleElixir_ElixirOfMastery:          28104,
+	proto.BattleElixir_ElixirOfTheMongoose:      13452,
+	proto.BattleElixir_FelStrengthElixir:        31679,
+	proto.BattleElixir_GreaterArcaneElixir:      13454,
+}
+
+var legacyGuardianElixirIDs = map[proto.GuardianElixir]int32{
+	proto.GuardianElixir_ElixirOfMightyDefense:   44328,
+	proto.GuardianElixir_ElixirOfMightyFortitude: 40078,
+	proto.GuardianElixir_ElixirOfMightyMageblood: 40109,
+	proto.GuardianElixir_ElixirOfMightyThoughts:  44332,
+	proto.GuardianElixir_ElixirOfProtection:      40097,
+	proto.GuardianElixir_ElixirOfSpirit:          40072,
+	proto.GuardianElixir_GiftOfArthas:            9088,
+	proto.GuardianElixir_ElixirOfDraenicWisdom:   32067,
+	proto.GuardianElixir_ElixirOfIronskin:        32068,
+	proto.GuardianElixir_ElixirOfMajorDefense:    22834,
+	proto.GuardianElixir_ElixirOfMajorFortitude:  32062,
+	proto.GuardianElixir_ElixirOfMajorMageblood:  22840,
+}
+
+var legacyFoodIDs = map[proto.Food]int32{
+	proto.Food_FoodFishFeast:             43015,
+	proto.Food_FoodGreatFeast:            34753,
+	proto.Food_FoodBlackenedDragonfin:    42999,
+	proto.Food_FoodHeartyRhino:           42995,
+	proto.Food_FoodMegaMammothMeal:       34754,
+	proto.Food_FoodSpicedWormBurger:      34756,
+	proto.Food_FoodRhinoliciousWormsteak: 42994,
+	proto.Food_FoodImperialMantaSteak:    34769,
+	proto.Food_FoodSnapperExtreme:        42996,
+	proto.Food_FoodMightyRhinoDogs:       34758,
+	proto.Food_FoodFirecrackerSalmon:     34767,
+	proto.Food_FoodCuttlesteak:           42998,
+	proto.Food_FoodDragonfinFilet:        43000,
+	proto.Food_FoodBlackenedBasilisk:     27657,
+	proto.Food_FoodGrilledMudfish:        27664,
+	proto.Food_FoodRavagerDog:            27655,
+	proto.Food_FoodRoastedClefthoof:      27658,
+	proto.Food_FoodSkullfishSoup:         33825,
+	proto.Food_FoodSpicyHotTalbuk:        33872,
+	proto.Food_FoodFishermansFeast:       33052,
+}
+
+var legacyPetFoodIDs = map[proto.PetFood]int32{
+	proto.PetFood_PetFoodSpicedMammothTreats: 43005,
+	proto.PetFood_PetFoodKiblersBits:         33874,
+}
+
+var legacyPotionIDs = map[proto.Potions]int32{
+	proto.Potions_RunicHealingPotion:   33447,
+	proto.Potions_RunicManaPotion:      33448,
+	proto.Potions_IndestructiblePotion: 40093,
+	proto.Potions_PotionOfSpeed:        40211,
+	proto.Potions_PotionOfWildMagic:    40212,
+	proto.Potions_DestructionPotion:    22839,
+	proto.Potions_SuperManaPotion:      22832,
+	proto.Potions_HastePotion:          22838,
+	proto.Potions_MightyRagePotion:     13442,
+	proto.Potions_FelManaPotion:        31677,
+	proto.Potions_InsaneStrengthPotion: 22828,
+	proto.Potions_IronshieldPotion:     22849,
+	proto.Potions_HeroicPotion:         22837,
+	proto.Potions_RunicManaInjector:    42545,
+	proto.Potions_RunicHealingInjector: 41166,
+}
+
+var legacyConjuredIDs = map[proto.Conjured]int32{
+	proto.Conjured_ConjuredDarkRune:        20520,
+	proto.Conjured_ConjuredFlameCap:        22788,
+	proto.Conjured_ConjuredHealthstone:     36892,
+	proto.Conjured_ConjuredRogueThistleTea: 7676,
+}
+
+var legacyExplosiveIDs = map[proto.Explosive]int32{
+	proto.Explosive_ExplosiveSaroniteBomb:   41119,
+	proto.Explosive_ExplosiveCobaltFragBomb: 40771,
+}
+
+func init() {
+	addToDatabase(&proto.SimDatabase{Consumables: DefaultConsumables})
+}
+
+// The built-in consumable catalog, which is also written to the UI database.
+// Consumables with effects beyond what SimConsumable can describe are listed
+// here too, and their extra effects are implemented in consumes.go.
+var DefaultConsumables = []*proto.SimConsumable{
+	// Flasks
+	{Id: 46376, Name: "Flask of the Frost Wyrm", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats:          stats.Stats{stats.SpellPower: 125}.ToFloatArray(),
+		AlchemistStats: stats.Stats{stats.SpellPower: 47}.ToFloatArray()},
+	{Id: 46377, Name: "Flask of Endless Rage", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats:          stats.Stats{stats.AttackPower: 180, stats.RangedAttackPower: 180}.ToFloatArray(),
+		AlchemistStats: stats.Stats{stats.AttackPower: 80, stats.RangedAttackPower: 80}.ToFloatArray()},
+	{Id: 46378, Name: "Flask of Pure Mojo", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats:          stats.Stats{stats.MP5: 45}.ToFloatArray(),
+		AlchemistStats: stats.Stats{stats.MP5: 20}.ToFloatArray()},
+	{Id: 46379, Name: "Flask of Stoneblood", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats:          stats.Stats{stats.Health: 1300}.ToFloatArray(),
+		AlchemistStats: stats.Stats{stats.Health: 650}.ToFloatArray()},
+	{Id: 40079, Name: "Lesser Flask of Toughness", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats:          stats.Stats{stats.Resilience: 50}.ToFloatArray(),
+		AlchemistStats: stats.Stats{stats.Resilience: 82}.ToFloatArray()},
+	{Id: 44939, Name: "Lesser Flask of Resistance", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats: stats.Stats{stats.ArcaneResistance: 50, stats.FireResistance: 50, stats.FrostResistance: 50,
+			stats.NatureResistance: 50, stats.ShadowResistance: 50}.ToFloatArray(),
+		AlchemistStats: stats.Stats{stats.ArcaneResistance: 40, stats.FireResistance: 40, stats.FrostResistance: 40,
+			stats.NatureResistance: 40, stats.ShadowResistance: 40}.ToFloatArray()},
+	{Id: 22861, Name: "Flask of Blinding Light", Type: proto.ConsumableType_ConsumableTypeFlask},
+	{Id: 22853, Name: "Flask of Mighty Restoration", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats: stats.Stats{stats.MP5: 25}.ToFloatArray()},
+	{Id: 22866, Name: "Flask of Pure Death", Type: proto.ConsumableType_ConsumableTypeFlask},
+	{Id: 22854, Name: "Flask of Relentless Assault", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats: stats.Stats{stats.AttackPower: 120, stats.RangedAttackPower: 120}.ToFloatArray()},
+	{Id: 13512, Name: "Flask of Supreme Power", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats: stats.Stats{stats.SpellPower: 70}.ToFloatArray()},
+	{Id: 22851, Name: "Flask of Fortification", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats: stats.Stats{stats.Health: 500, stats.Defense: 10}.ToFloatArray()},
+	{Id: 33208, Name: "Flask of Chromatic Wonder", Type: proto.ConsumableType_ConsumableTypeFlask,
+		Stats: stats.Stats{stats.Stamina: 18, stats.Strength: 18, stats.Agility: 18, stats.Intellect: 18, stats.Spirit: 18,
+			stats.ArcaneResistance: 35, stats.FireResistance: 35, stats.FrostResistance: 35,
+			stats.NatureResistance: 35, stats.ShadowResistance: 35}.ToFloatArray()},
+
+	// Battle Elixirs
+	{Id: 44325, Name: "Elixir of Accuracy", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.MeleeHit: 45, stats.SpellHit: 45}.ToFloatArray()},
+	{Id: 44330, Name: "Elixir of Armor Piercing", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.ArmorPenetration: 45}.ToFloatArray()},
+	{Id: 44327, Name: "Elixir of Deadly Strikes", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.MeleeCrit: 45, stats.SpellCrit: 45}.ToFloatArray()},
+	{Id: 44329, Name: "Elixir of Expertise", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.Expertise: 45}.ToFloatArray()},
+	{Id: 44331, Name: "Elixir of Lightning Speed", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.MeleeHaste: 45, stats.SpellHaste: 45}.ToFloatArray()},
+	{Id: 39666, Name: "Elixir of Mighty Agility", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.Agility: 45}.ToFloatArray()},
+	{Id: 40073, Name: "Elixir of Mighty Strength", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.Strength: 45}.ToFloatArray()},
+	{Id: 40076, Name: "Guru's Elixir", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.Agility: 20, stats.Strength: 20, stats.Stamina: 20, stats.Intellect: 20, stats.Spirit: 20}.ToFloatArray()},
+	{Id: 40070, Name: "Spellpower Elixir", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.SpellPower: 58}.ToFloatArray()},
+	{Id: 40068, Name: "Wrath Elixir", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.AttackPower: 90, stats.RangedAttackPower: 90}.ToFloatArray()},
+	{Id: 28103, Name: "Adept's Elixir", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.SpellCrit: 24, stats.SpellPower: 24}.ToFloatArray()},
+	{Id: 9224, Name: "Elixir of Demonslaying", Type: proto.ConsumableType_ConsumableTypeBattleElixir},
+	{Id: 22831, Name: "Elixir of Major Agility", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.Agility: 35, stats.MeleeCrit: 20}.ToFloatArray()},
+	// TODO: The school-specific spell power of these isn't implemented.
+	{Id: 22833, Name: "Elixir of Major Firepower", Type: proto.ConsumableType_ConsumableTypeBattleElixir},
+	{Id: 22827, Name: "Elixir of Major Frost Power", Type: proto.ConsumableType_ConsumableTypeBattleElixir},
+	{Id: 22835, Name: "Elixir of Major Shadow Power", Type: proto.ConsumableType_ConsumableTypeBattleElixir},
+	{Id: 22824, Name: "Elixir of Major Strength", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.Strength: 35}.ToFloatArray()},
+	{Id: 28104, Name: "Elixir of Mastery", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.Stamina: 15, stats.Strength: 15, stats.Agility: 15, stats.Intellect: 15, stats.Spirit: 15}.ToFloatArray()},
+	{Id: 13452, Name: "Elixir of the Mongoose", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.Agility: 25, stats.MeleeCrit: 28}.ToFloatArray()},
+	{Id: 31679, Name: "Fel Strength Elixir", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.AttackPower: 90, stats.RangedAttackPower: 90, stats.Stamina: -10}.ToFloatArray()},
+	{Id: 13454, Name: "Greater Arcane Elixir", Type: proto.ConsumableType_ConsumableTypeBattleElixir,
+		Stats: stats.Stats{stats.SpellPower: 35}.ToFloatArray()},
+
+	// Guardian Elixirs
+	{Id: 44328, Name: "Elixir of Mighty Defense", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.Defense: 45}.ToFloatArray()},
+	{Id: 40078, Name: "Elixir of Mighty Fortitude", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.Health: 350}.ToFloatArray()},
+	{Id: 40109, Name: "Elixir of Mighty Mageblood", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.MP5: 30}.ToFloatArray()},
+	{Id: 44332, Name: "Elixir of Mighty Thoughts", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.Intellect: 45}.ToFloatArray()},
+	{Id: 40097, Name: "Elixir of Protection", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats:          stats.Stats{stats.Armor: 800}.ToFloatArray(),
+		AlchemistStats: stats.Stats{stats.Armor: 280}.ToFloatArray()},
+	{Id: 40072, Name: "Elixir of Spirit", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.Spirit: 50}.ToFloatArray()},
+	{Id: 9088, Name: "Gift of Arthas", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.ShadowResistance: 10}.ToFloatArray()},
+	{Id: 32067, Name: "Elixir of Draenic Wisdom", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.Intellect: 30, stats.Spirit: 30}.ToFloatArray()},
+	{Id: 32068, Name: "Elixir of Ironskin", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.Resilience: 30}.ToFloatArray()},
+	{Id: 22834, Name: "Elixir of Major Defense", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.Armor: 550}.ToFloatArray()},
+	{Id: 32062, Name: "Elixir of Major Fortitude", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.Health: 250}.ToFloatArray()},
+	{Id: 22840, Name: "Elixir of Major Mageblood", Type: proto.ConsumableType_ConsumableTypeGuardianElixir,
+		Stats: stats.Stats{stats.MP5: 16}.ToFloatArray()},
+
+	// Food
+	{Id: 43015, Name: "Fish Feast", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.AttackPower: 80, stats.RangedAttackPower: 80, stats.SpellPower: 46, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 34753, Name: "Great Feast", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.AttackPower: 60, stats.RangedAttackPower: 60, stats.SpellPower: 35, stats.Stamina: 30}.ToFloatArray()},
+	{Id: 42999, Name: "Blackened Dragonfin", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.Agility: 40, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 42995, Name: "Hearty Rhino", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.ArmorPenetration: 40, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 34754, Name: "Mega Mammoth Meal", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.AttackPower: 80, stats.RangedAttackPower: 80, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 34756, Name: "Spiced Worm Burger", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.MeleeCrit: 40, stats.SpellCrit: 40, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 42994, Name: "Rhinolicious Wormsteak", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.Expertise: 40, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 34769, Name: "Imperial Manta Steak", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.MeleeHaste: 40, stats.SpellHaste: 40, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 42996, Name: "Snapper Extreme", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.MeleeHit: 40, stats.SpellHit: 40, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 34758, Name: "Mighty Rhino Dogs", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.MP5: 16, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 34767, Name: "Firecracker Salmon", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.SpellPower: 46, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 42998, Name: "Cuttlesteak", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.Spirit: 40, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 43000, Name: "Dragonfin Filet", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.Strength: 40, stats.Stamina: 40}.ToFloatArray()},
+	{Id: 27657, Name: "Blackened Basilisk", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.SpellPower: 23, stats.Spirit: 20}.ToFloatArray()},
+	{Id: 27664, Name: "Grilled Mudfish", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.Agility: 20, stats.Spirit: 20}.ToFloatArray()},
+	{Id: 27655, Name: "Ravager Dog", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.AttackPower: 40, stats.RangedAttackPower: 40, stats.Spirit: 20}.ToFloatArray()},
+	{Id: 27658, Name: "Roasted Clefthoof", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.Strength: 20, stats.Spirit: 20}.ToFloatArray()},
+	{Id: 33825, Name: "Skullfish Soup", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.SpellCrit: 20, stats.Spirit: 20}.ToFloatArray()},
+	{Id: 33872, Name: "Spicy Hot Talbuk", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.MeleeHit: 20, stats.Spirit: 20}.ToFloatArray()},
+	{Id: 33052, Name: "Fisherman's Feast", Type: proto.ConsumableType_ConsumableTypeFood,
+		Stats: stats.Stats{stats.Stamina: 30, stats.Spirit: 20}.ToFloatArray()},
+
+	// Pet Food
+	{Id: 43005, Name: "Spiced Mammoth Treats", Type: proto.ConsumableType_ConsumableTypePetFood,
+		Stats: stats.Stats{stats.Strength: 30, stats.Stamina: 30}.ToFloatArray()},
+	{Id: 33874, Name: "Kibler's Bits", Type: proto.ConsumableType_ConsumableTypePetFood,
+		Stats: stats.Stats{stats.Strength: 20, stats.Stamina: 20}.ToFloatArray()},
+
+	// Potions
+	{Id: 33447, Name: "Runic Healing Potion", Type: proto.ConsumableType_ConsumableTypePotion},
+	{Id: 41166, Name: "Runic Healing Injector", Type: proto.ConsumableType_ConsumableTypePotion},
+	{Id: 33448, Name: "Runic Mana Potion", Type: proto.ConsumableType_ConsumableTypePotion},
+	{Id: 42545, Name: "Runic Mana Injector", Type: proto.ConsumableType_ConsumableTypePotion},
+	{Id: 40093, Name: "Indestructible Potion", Type: proto.ConsumableType_ConsumableTypePotion,
+		Stats: stats.Stats{stats.Armor: 3500}.ToFloatArray(), BuffDuration: 120, SharedCooldown: 120},
+	{Id: 40211, Name: "Potion of Speed", Type: proto.ConsumableType_ConsumableTypePotion,
+		Stats: stats.Stats{stats.MeleeHaste: 500, stats.SpellHaste: 500}.ToFloatArray(), BuffDuration: 15},
+	{Id: 40212, Name: "Potion of Wild Magic", Type: proto.ConsumableType_ConsumableTypePotion,
+		Stats: stats.Stats{stats.SpellPower: 200, stats.SpellCrit: 200, stats.MeleeCrit: 200}.ToFloatArray(), BuffDuration: 15},
+	{Id: 22839, Name: "Destruction Potion", Type: proto.ConsumableType_ConsumableTypePotion,
+		Stats: stats.Stats{stats.SpellPower: 120, stats.SpellCrit: 2 * CritRatingPerCritChance}.ToFloatArray(), BuffDuration: 15},
+	{Id: 22832, Name: "Super Mana Potion", Type: proto.ConsumableType_ConsumableTypePotion},
+	{Id: 22838, Name: "Haste Potion", Type: proto.ConsumableType_ConsumableTypePotion,
+		Stats: stats.Stats{stats.MeleeHaste: 400}.ToFloatArray(), BuffDuration: 15},
+	{Id: 13442, Name: "Mighty Rage Potion", Type: proto.ConsumableType_ConsumableTypePotion},
+	{Id: 31677, Name: "Fel Mana Potion", Type: proto.ConsumableType_ConsumableTypePotion},
+	{Id: 22828, Name: "Insane Strength Potion", Type: proto.ConsumableType_ConsumableTypePotion,
+		Stats: stats.Stats{stats.Strength: 120, stats.Defense: -75}.ToFloatArray(), BuffDuration: 15},
+	{Id: 22849, Name: "Ironshield Potion", Type: proto.ConsumableType_ConsumableTypePotion,
+		Stats: stats.Stats{stats.Armor: 2500}.ToFloatArray(), BuffDuration: 120},
+	{Id: 22837, Name: "Heroic Potion", Type: proto.ConsumableType_ConsumableTypePotion,
+		Stats: stats.Stats{stats.Strength: 70, stats.Health: 700}.ToFloatArray(), BuffDuration: 15},
+
+	// Conjured
+	{Id: 20520, Name: "Dark Rune", Type: proto.ConsumableType_ConsumableTypeConjured},
+	{Id: 22788, Name: "Flame Cap", Type: proto.ConsumableType_ConsumableTypeConjured},
+	{Id: 36892, Name: "Fel Healthstone", Type: proto.ConsumableType_ConsumableTypeConjured,
+		MinHealth: 4280, MaxHealth: 4280, SharedCooldown: 120},
+	{Id: 7676, Name: "Thistle Tea", Type: proto.ConsumableType_ConsumableTypeConjured},
+
+	// Explosives
+	{Id: 42641, Name: "Global Thermal Sapper Charge", Type: proto.ConsumableType_ConsumableTypeExplosive,
+		School: proto.SpellSchool_SpellSchoolFire, MinDamage: 2188, MaxDamage: 2812, Cooldown: 300},
+	{Id: 40536, Name: "Explosive Decoy", Type: proto.ConsumableType_ConsumableTypeExplosive,
+		School: proto.SpellSchool_SpellSchoolPhysical, MinDamage: 1440, MaxDamage: 2160, Cooldown: 120, SharedCooldown: 120},
+	{Id: 41119, Name: "Saronite Bomb", Type: proto.ConsumableType_ConsumableTypeExplosive,
+		School: proto.SpellSchool_SpellSchoolFire, MinDamage: 1150, MaxDamage: 1500},
+	{Id: 40771, Name: "Cobalt Frag Bomb", Type: proto.ConsumableType_ConsumableTypeExplosive,
+		School: proto.SpellSchool_SpellSchoolFire, MinDamage: 750, MaxDamage: 1000},
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+func TestGetConsumableIDs(t *testing.T) {
+	ids := GetConsumableIDs(&proto.Consumes{
+		Flask:         proto.Flask_FlaskOfEndlessRage,
+		FlaskId:       46376,
+		Food:          proto.Food_FoodFishFeast,
+		DefaultPotion: proto.Potions_PotionOfSpeed,
+	})
+	if ids.Flask != 46376 || ids.Food != 43015 || ids.DefaultPotion != 40211 || ids.PrepopPotion != 0 {
+		t.Fatalf("Unexpected consumable IDs: %+v", ids)
+	}
+}
+
+func TestCustomConsumableStats(t *testing.T) {
+	const flaskID = 9000100
+	player := &proto.Player{
+		Name:      "Caster",
+		Class:     proto.Class_ClassShaman,
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{},
+		Consumes:  &proto.Consumes{FlaskId: flaskID, Food: proto.Food_FoodFirecrackerSalmon},
+		Database: &proto.SimDatabase{
+			Consumables: []*proto.SimConsumable{{
+				Id:    flaskID,
+				Name:  "Flask of Testing",
+				Type:  proto.ConsumableType_ConsumableTypeFlask,
+				Stats: stats.Stats{stats.SpellPower: 100}.ToFloatArray(),
+			}},
+		},
+	}
+	defer delete(ConsumablesByID, flaskID)
+
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{player}}}},
+	})
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+
+	consumesStats := result.RaidStats.Parties[0].Players[0].ConsumesStats.Stats
+	if consumesStats[stats.SpellPower] != 146 || consumesStats[stats.Stamina] != 40 {
+		t.Fatalf("Expected 146 spell power and 40 stamina from consumes, got %v", stats.FromFloatArray(consumesStats))
+	}
+}
+
+func TestConsumableWrongType(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected a panic for a food in the flask slot")
+		}
+	}()
+	getConsumable(43015, proto.ConsumableType_ConsumableTypeFlask)
+}
+
+func TestValidateConsumes(t *testing.T) {
+	if err := validateConsumes(&proto.Consumes{FlaskId: 46376, FoodId: 43015}); err != nil {
+		t.Fatalf("Unexpected error for valid consumes: %v", err)
+	}
+	if err := validateConsumes(&proto.Consumes{FlaskId: 43015}); err == nil || !strings.Contains(err.Error(), "flask_id") {
+		t.Fatalf("Expected an error naming flask_id for a food in the flask slot, got %v", err)
+	}
+	if err := validateConsumes(&proto.Consumes{DefaultPotionId: 1}); err == nil || !strings.Contains(err.Error(), "default_potion_id") {
+		t.Fatalf("Expected an error naming default_potion_id for an unknown ID, got %v", err)
+	}
+
+	// Bad IDs fail the sim with a plain error rather than a panic.
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid:       SinglePlayerRaidProto(&proto.Player{Name: "Caster", Consumes: &proto.Consumes{FoodId: 46376}}, nil, nil, nil),
+		Encounter:  MakeSingleTargetEncounter(0),
+		SimOptions: &proto.SimOptions{},
+	})
+	if !strings.HasPrefix(result.ErrorResult, "Caster: food_id:") || strings.Contains(result.ErrorResult, "Stack Trace") {
+		t.Fatalf("Expected a plain error naming the field, got %q", result.ErrorResult)
+	}
+}
@@ -0,0 +1,274 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sort"
+
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// A consumable the optimizer can try, identified by its catalog item ID.
+type consumableOption struct {
+	id   int32
+	name string
+}
+
+// A group of mutually exclusive consumables, e.g. all flasks.
+type consumableCategory struct {
+	name string
+
+	// Every option besides using nothing.
+	options []consumableOption
+
+	// Sets the option by item ID, clearing anything it can't be used with. An ID
+	// of 0 uses nothing from this category.
+	set func(consumes *proto.Consumes, id int32)
+}
+
+// All catalog consumables of the given type, ordered by item ID.
+func catalogConsumableOptions(consumableType proto.ConsumableType, excludeIDs ...int32) []consumableOption {
+	var options []consumableOption
+	for id, consumable := range ConsumablesByID {
+		if consumable.Type == consumableType && !slices.Contains(excludeIDs, id) {
+			options = append(options, consumableOption{id: id, name: consumable.Name})
+		}
+	}
+	slices.SortFunc(options, func(a, b consumableOption) int {
+		return int(a.id - b.id)
+	})
+	return options
+}
+
+// A consumable which is either used or not.
+func consumableToggleOptions(id int32) []consumableOption {
+	return []consumableOption{{id: id, name: ConsumablesByID[id].Name}}
+}
+
+func consumableCategories(player *proto.Player) []consumableCategory {
+	categories := []consumableCategory{
+		{name: "Flask", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypeFlask), set: func(consumes *proto.Consumes, id int32) {
+			consumes.Flask, consumes.FlaskId = proto.Flask_FlaskUnknown, id
+			consumes.BattleElixir, consumes.BattleElixirId = proto.BattleElixir_BattleElixirUnknown, 0
+			consumes.GuardianElixir, consumes.GuardianElixirId = proto.GuardianElixir_GuardianElixirUnknown, 0
+		}},
+		{name: "Battle Elixir", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypeBattleElixir), set: func(consumes *proto.Consumes, id int32) {
+			consumes.Flask, consumes.FlaskId = proto.Flask_FlaskUnknown, 0
+			consumes.BattleElixir, consumes.BattleElixirId = proto.BattleElixir_BattleElixirUnknown, id
+		}},
+		{name: "Guardian Elixir", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypeGuardianElixir), set: func(consumes *proto.Consumes, id int32) {
+			consumes.Flask, consumes.FlaskId = proto.Flask_FlaskUnknown, 0
+			consumes.GuardianElixir, consumes.GuardianElixirId = proto.GuardianElixir_GuardianElixirUnknown, id
+		}},
+		{name: "Food", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypeFood), set: func(consumes *proto.Consumes, id int32) {
+			consumes.Food, consumes.FoodId = proto.Food_FoodUnknown, id
+		}},
+		{name: "Potion", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypePotion), set: func(consumes *proto.Consumes, id int32) {
+			consumes.DefaultPotion, consumes.DefaultPotionId = proto.Potions_UnknownPotion, id
+		}},
+		{name: "Prepull Potion", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypePotion), set: func(consumes *proto.Consumes, id int32) {
+			consumes.PrepopPotion, consumes.PrepopPotionId = proto.Potions_UnknownPotion, id
+		}},
+		{name: "Conjured", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypeConjured), set: func(consumes *proto.Consumes, id int32) {
+			consumes.DefaultConjured, consumes.DefaultConjuredId = proto.Conjured_ConjuredUnknown, id
+		}},
+	}
+
+	if player.Class == proto.Class_ClassHunter || player.Class == proto.Class_ClassWarlock {
+		categories = append(categories, consumableCategory{name: "Pet Food", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypePetFood), set: func(consumes *proto.Consumes, id int32) {
+			consumes.PetFood, consumes.PetFoodId = proto.PetFood_PetFoodUnknown, id
+		}})
+	}
+
+	// Explosives only work for engineers. The thermal sapper and explosive decoy
+	// have their own cooldowns, so they're used alongside the filler explosive.
+	if player.Profession1 == proto.Profession_Engineering || player.Profession2 == proto.Profession_Engineering {
+		categories = append(categories,
+			consumableCategory{name: "Explosive", options: catalogConsumableOptions(proto.ConsumableType_ConsumableTypeExplosive, ThermalSapperActionID.ItemID, ExplosiveDecoyActionID.ItemID), set: func(consumes *proto.Consumes, id int32) {
+				consumes.FillerExplosive, consumes.FillerExplosiveId = proto.Explosive_ExplosiveUnknown, id
+			}},
+			consumableCategory{name: "Thermal Sapper", options: consumableToggleOptions(ThermalSapperActionID.ItemID), set: func(consumes *proto.Consumes, id int32) {
+				consumes.ThermalSapper = id != 0
+			}},
+			consumableCategory{name: "Explosive Decoy", options: consumableToggleOptions(ExplosiveDecoyActionID.ItemID), set: func(consumes *proto.Consumes, id int32) {
+				consumes.ExplosiveDecoy = id != 0
+			}},
+		)
+	}
+	return categories
+}
+
+type consumableOptimizer struct {
+	SingleRaidSimRunner raidSimRunner
+	Request             *proto.ConsumableOptimizerRequest
+}
+
+func ConsumableOptimizer(ctx context.Context, request *proto.ConsumableOptimizerRequest, progress chan *proto.ProgressMetrics) *proto.ConsumableOptimizerResult {
+	optimizer := &consumableOptimizer{
+		SingleRaidSimRunner: runSim,
+		Request:             request,
+	}
+
+	result, err := optimizer.Run(ctx, progress)
+	if err != nil {
+		result = &proto.ConsumableOptimizerResult{
+			ErrorResult: err.Error(),
+		}
+	}
+	return result
+}
+
+func (optimizer *consumableOptimizer) Run(ctx context.Context, progress chan *proto.ProgressMetrics) (result *proto.ConsumableOptimizerResult, resultErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.ConsumableOptimizerResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	baseSettings := goproto.Clone(optimizer.Request.GetBaseSettings()).(*proto.RaidSimRequest)
+	if len(baseSettings.GetRaid().GetParties()) == 0 || len(baseSettings.Raid.Parties[0].Players) != 1 {
+		return nil, fmt.Errorf("consumable optimizer: expected exactly 1 player")
+	}
+	baseSettings.Raid.Parties = baseSettings.Raid.Parties[:1]
+	player := baseSettings.Raid.Parties[0].Players[0]
+	if player.GetDatabase() != nil {
+		addPlayerDatabase(player.GetDatabase())
+		player.Database = nil
+	}
+	if player.Consumes == nil {
+		player.Consumes = &proto.Consumes{}
+	}
+	if baseSettings.SimOptions == nil {
+		baseSettings.SimOptions = &proto.SimOptions{}
+	}
+	baseConsumes := player.Consumes
+
+	iterations := optimizer.Request.GetIterationsPerCombo()
+	if iterations <= 0 {
+		iterations = defaultIterationsPerCombo
+	}
+
+	if progress == nil {
+		drainCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		progress = discardProgress(drainCtx)
+	}
+
+	// Sims each set of consumes once, no matter how many categories it comes up in.
+	dpsByConsumes := make(map[string]float64)
+	simAll := func(consumesList []*proto.Consumes) error {
+		keysByRequest := make(map[*proto.RaidSimRequest]string)
+		var combos []singleBulkSim
+		for _, consumes := range consumesList {
+			key := consumesKey(consumes)
+			if _, ok := dpsByConsumes[key]; ok {
+				continue
+			}
+			dpsByConsumes[key] = 0
+
+			req := goproto.Clone(baseSettings).(*proto.RaidSimRequest)
+			req.Raid.Parties[0].Players[0].Consumes = consumes
+			keysByRequest[req] = key
+			combos = append(combos, singleBulkSim{req: req, cl: &raidSimRequestChangeLog{}, eq: &equipmentSubstitution{}})
+		}
+		if len(combos) == 0 {
+			return nil
+		}
+
+		runner := &bulkSimRunner{SingleRaidSimRunner: optimizer.SingleRaidSimRunner}
+		simResults, _, err := runner.getRankedResults(ctx, combos, int64(iterations), progress)
+		if err != nil {
+			return err
+		}
+		for _, simResult := range simResults {
+			dpsByConsumes[keysByRequest[simResult.Request]] = simResult.Score()
+		}
+		return nil
+	}
+
+	withOption := func(category *consumableCategory, id int32) *proto.Consumes {
+		consumes := goproto.Clone(baseConsumes).(*proto.Consumes)
+		category.set(consumes, id)
+		return consumes
+	}
+
+	categories := consumableCategories(player)
+	consumesList := []*proto.Consumes{baseConsumes}
+	for i := range categories {
+		consumesList = append(consumesList, withOption(&categories[i], 0))
+		for _, option := range categories[i].options {
+			consumesList = append(consumesList, withOption(&categories[i], option.id))
+		}
+	}
+	if err := simAll(consumesList); err != nil {
+		return nil, err
+	}
+
+	result = &proto.ConsumableOptimizerResult{
+		BaseDps: dpsByConsumes[consumesKey(baseConsumes)],
+	}
+	bestIDs := make([]int32, len(categories))
+	bestDeltas := make([]float64, len(categories))
+	for i := range categories {
+		category := &categories[i]
+		noneDps := dpsByConsumes[consumesKey(withOption(category, 0))]
+
+		categoryResult := &proto.ConsumableCategoryResult{Name: category.name}
+		for _, categoryOption := range category.options {
+			dps := dpsByConsumes[consumesKey(withOption(category, categoryOption.id))]
+			option := &proto.ConsumableOptionResult{
+				Id:       categoryOption.id,
+				Name:     categoryOption.name,
+				Dps:      dps,
+				DpsDelta: dps - noneDps,
+				Price:    optimizer.Request.GetPrices()[categoryOption.name],
+			}
+			if option.Price > 0 {
+				option.DpsPerGold = option.DpsDelta / option.Price
+			}
+			categoryResult.Options = append(categoryResult.Options, option)
+
+			if option.DpsDelta > bestDeltas[i] {
+				bestIDs[i] = categoryOption.id
+				bestDeltas[i] = option.DpsDelta
+			}
+		}
+		sort.SliceStable(categoryResult.Options, func(a, b int) bool {
+			return categoryResult.Options[a].Dps > categoryResult.Options[b].Dps
+		})
+		result.Categories = append(result.Categories, categoryResult)
+	}
+
+	// A flask replaces both elixirs, so use whichever is worth more. The first 3
+	// categories are the flask and elixirs.
+	bestConsumes := goproto.Clone(baseConsumes).(*proto.Consumes)
+	if bestDeltas[0] >= bestDeltas[1]+bestDeltas[2] {
+		categories[0].set(bestConsumes, bestIDs[0])
+	} else {
+		categories[1].set(bestConsumes, bestIDs[1])
+		categories[2].set(bestConsumes, bestIDs[2])
+	}
+	for i := 3; i < len(categories); i++ {
+		categories[i].set(bestConsumes, bestIDs[i])
+	}
+	if err := simAll([]*proto.Consumes{bestConsumes}); err != nil {
+		return nil, err
+	}
+	result.BestConsumes = bestConsumes
+	result.BestDps = dpsByConsumes[consumesKey(bestConsumes)]
+	return result, nil
+}
+
+func consumesKey(consumes *proto.Consumes) string {
+	data, err := goproto.MarshalOptions{Deterministic: true}.Marshal(consumes)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
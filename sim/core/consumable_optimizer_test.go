@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestConsumableOptimizer(t *testing.T) {
+	// The flask is worth less than the two best elixirs together.
+	fakeRunSim := func(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool) *proto.RaidSimResult {
+		ids := GetConsumableIDs(rsr.Raid.Parties[0].Players[0].Consumes)
+		dps := 1000.0
+		if ids.Flask == 46377 { // Flask of Endless Rage
+			dps += 100
+		}
+		if ids.BattleElixir == 44325 { // Elixir of Accuracy
+			dps += 60
+		}
+		if ids.GuardianElixir == 44332 { // Elixir of Mighty Thoughts
+			dps += 50
+		}
+		if ids.DefaultPotion == 22838 { // Haste Potion
+			dps -= 10
+		}
+		return &proto.RaidSimResult{RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: dps}}}
+	}
+
+	optimizer := &consumableOptimizer{
+		SingleRaidSimRunner: fakeRunSim,
+		Request: &proto.ConsumableOptimizerRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{
+					Class:    proto.Class_ClassWarrior,
+					Consumes: &proto.Consumes{Flask: proto.Flask_FlaskOfEndlessRage, DefaultPotion: proto.Potions_HastePotion},
+				}}}}},
+			},
+			IterationsPerCombo: 1,
+			Prices:             map[string]float64{"Flask of Endless Rage": 50},
+		},
+	}
+
+	result, err := optimizer.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Consumable optimizer failed: %v", err)
+	}
+	if result.BaseDps != 1090 {
+		t.Fatalf("Expected base DPS 1090, got %f", result.BaseDps)
+	}
+
+	flask := result.Categories[0].Options[0]
+	if flask.Id != 46377 || flask.Name != "Flask of Endless Rage" || flask.DpsDelta != 100 || flask.DpsPerGold != 2 {
+		t.Fatalf("Unexpected best flask: %v", flask)
+	}
+
+	best := result.BestConsumes
+	if best.Flask != proto.Flask_FlaskUnknown || best.FlaskId != 0 || best.BattleElixirId != 44325 ||
+		best.GuardianElixirId != 44332 || best.DefaultPotion != proto.Potions_UnknownPotion || best.DefaultPotionId != 0 {
+		t.Fatalf("Unexpected best consumes: %v", best)
+	}
+	if result.BestDps != 1110 {
+		t.Fatalf("Expected best DPS 1110, got %f", result.BestDps)
+	}
+}
+
+func TestConsumableOptimizerCustomConsumable(t *testing.T) {
+	// A flask which is only in the player's database.
+	const customFlaskID = 9000100
+	defer delete(ConsumablesByID, customFlaskID)
+
+	fakeRunSim := func(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool) *proto.RaidSimResult {
+		dps := 1000.0
+		if GetConsumableIDs(rsr.Raid.Parties[0].Players[0].Consumes).Flask == customFlaskID {
+			dps += 200
+		}
+		return &proto.RaidSimResult{RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: dps}}}
+	}
+
+	optimizer := &consumableOptimizer{
+		SingleRaidSimRunner: fakeRunSim,
+		Request: &proto.ConsumableOptimizerRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{
+					Class: proto.Class_ClassWarrior,
+					Database: &proto.SimDatabase{Consumables: []*proto.SimConsumable{
+						{Id: customFlaskID, Name: "Custom Flask", Type: proto.ConsumableType_ConsumableTypeFlask},
+					}},
+				}}}}},
+			},
+			IterationsPerCombo: 1,
+		},
+	}
+
+	result, err := optimizer.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Consumable optimizer failed: %v", err)
+	}
+
+	flask := result.Categories[0].Options[0]
+	if flask.Id != customFlaskID || flask.Name != "Custom Flask" || flask.DpsDelta != 200 {
+		t.Fatalf("Unexpected best flask: %v", flask)
+	}
+	if result.BestConsumes.FlaskId != customFlaskID || result.BestDps != 1200 {
+		t.Fatalf("Unexpected best consumes: %v with %f DPS", result.BestConsumes, result.BestDps)
+	}
+}
@@ -14,429 +14,91 @@ func applyConsumeEffects(agent Agent) {
 	if consumes == nil {
 		return
 	}
+	ids := GetConsumableIDs(consumes)
 
-	if consumes.Flask != proto.Flask_FlaskUnknown {
-		switch consumes.Flask {
-		case proto.Flask_FlaskOfTheFrostWyrm:
-			character.AddStats(stats.Stats{
-				stats.SpellPower: 125,
-			})
-			if character.HasProfession(proto.Profession_Alchemy) {
-				character.AddStats(stats.Stats{
-					stats.SpellPower: 47,
-				})
-			}
-		case proto.Flask_FlaskOfEndlessRage:
-			character.AddStats(stats.Stats{
-				stats.AttackPower:       180,
-				stats.RangedAttackPower: 180,
-			})
-			if character.HasProfession(proto.Profession_Alchemy) {
-				character.AddStats(stats.Stats{
-					stats.AttackPower:       80,
-					stats.RangedAttackPower: 80,
-				})
-			}
-		case proto.Flask_FlaskOfPureMojo:
-			character.AddStats(stats.Stats{
-				stats.MP5: 45,
-			})
-			if character.HasProfession(proto.Profession_Alchemy) {
-				character.AddStats(stats.Stats{
-					stats.MP5: 20,
-				})
-			}
-		case proto.Flask_FlaskOfStoneblood:
-			character.AddStats(stats.Stats{
-				stats.Health: 1300,
-			})
-			if character.HasProfession(proto.Profession_Alchemy) {
-				character.AddStats(stats.Stats{
-					stats.Health: 650,
-				})
-			}
-		case proto.Flask_LesserFlaskOfToughness:
-			character.AddStats(stats.Stats{
-				stats.Resilience: 50,
-			})
-			if character.HasProfession(proto.Profession_Alchemy) {
-				character.AddStats(stats.Stats{
-					stats.Resilience: 82,
-				})
-			}
-		case proto.Flask_LesserFlaskOfResistance:
-			character.AddStats(stats.Stats{
-				stats.ArcaneResistance: 50,
-				stats.FireResistance:   50,
-				stats.FrostResistance:  50,
-				stats.NatureResistance: 50,
-				stats.ShadowResistance: 50,
-			})
-			if character.HasProfession(proto.Profession_Alchemy) {
-				character.AddStats(stats.Stats{
-					stats.ArcaneResistance: 40,
-					stats.FireResistance:   40,
-					stats.FrostResistance:  40,
-					stats.NatureResistance: 40,
-					stats.ShadowResistance: 40,
-				})
-			}
-		case proto.Flask_FlaskOfBlindingLight:
-			character.OnSpellRegistered(func(spell *Spell) {
-				if spell.SpellSchool.Matches(SpellSchoolArcane | SpellSchoolHoly | SpellSchoolNature) {
-					spell.BonusSpellPower += 80
-				}
-			})
-		case proto.Flask_FlaskOfMightyRestoration:
-			character.AddStats(stats.Stats{
-				stats.MP5: 25,
-			})
-		case proto.Flask_FlaskOfPureDeath:
-			character.OnSpellRegistered(func(spell *Spell) {
-				if spell.SpellSchool.Matches(SpellSchoolFire | SpellSchoolFrost | SpellSchoolShadow) {
-					spell.BonusSpellPower += 80
-				}
-			})
-		case proto.Flask_FlaskOfRelentlessAssault:
-			character.AddStats(stats.Stats{
-				stats.AttackPower:       120,
-				stats.RangedAttackPower: 120,
-			})
-		case proto.Flask_FlaskOfSupremePower:
-			character.AddStats(stats.Stats{
-				stats.SpellPower: 70,
-			})
-		case proto.Flask_FlaskOfFortification:
-			character.AddStats(stats.Stats{
-				stats.Health:  500,
-				stats.Defense: 10,
-			})
-		case proto.Flask_FlaskOfChromaticWonder:
-			character.AddStats(stats.Stats{
-				stats.Stamina:          18,
-				stats.Strength:         18,
-				stats.Agility:          18,
-				stats.Intellect:        18,
-				stats.Spirit:           18,
-				stats.ArcaneResistance: 35,
-				stats.FireResistance:   35,
-				stats.FrostResistance:  35,
-				stats.NatureResistance: 35,
-				stats.ShadowResistance: 35,
-			})
-		}
+	if flask, ok := getConsumable(ids.Flask, proto.ConsumableType_ConsumableTypeFlask); ok {
+		applyPassiveConsumable(character, flask)
 	} else {
-		switch consumes.BattleElixir {
-		case proto.BattleElixir_ElixirOfAccuracy:
-			character.AddStats(stats.Stats{
-				stats.MeleeHit: 45,
-				stats.SpellHit: 45,
-			})
-		case proto.BattleElixir_ElixirOfArmorPiercing:
-			character.AddStats(stats.Stats{
-				stats.ArmorPenetration: 45,
-			})
-		case proto.BattleElixir_ElixirOfDeadlyStrikes:
-			character.AddStats(stats.Stats{
-				stats.MeleeCrit: 45,
-				stats.SpellCrit: 45,
-			})
-		case proto.BattleElixir_ElixirOfExpertise:
-			character.AddStats(stats.Stats{
-				stats.Expertise: 45,
-			})
-		case proto.BattleElixir_ElixirOfLightningSpeed:
-			character.AddStats(stats.Stats{
-				stats.MeleeHaste: 45,
-				stats.SpellHaste: 45,
-			})
-		case proto.BattleElixir_ElixirOfMightyAgility:
-			character.AddStats(stats.Stats{
-				stats.Agility: 45,
-			})
-		case proto.BattleElixir_ElixirOfMightyStrength:
-			character.AddStats(stats.Stats{
-				stats.Strength: 45,
-			})
-		case proto.BattleElixir_GurusElixir:
-			character.AddStats(stats.Stats{
-				stats.Agility:   20,
-				stats.Strength:  20,
-				stats.Stamina:   20,
-				stats.Intellect: 20,
-				stats.Spirit:    20,
-			})
-		case proto.BattleElixir_SpellpowerElixir:
-			character.AddStats(stats.Stats{
-				stats.SpellPower: 58,
-			})
-		case proto.BattleElixir_WrathElixir:
-			character.AddStats(stats.Stats{
-				stats.AttackPower:       90,
-				stats.RangedAttackPower: 90,
-			})
-		case proto.BattleElixir_AdeptsElixir:
-			character.AddStats(stats.Stats{
-				stats.SpellCrit:  24,
-				stats.SpellPower: 24,
-			})
-		case proto.BattleElixir_ElixirOfDemonslaying:
-			if character.CurrentTarget.MobType == proto.MobType_MobTypeDemon {
-				character.PseudoStats.MobTypeAttackPower += 265
-			}
-		case proto.BattleElixir_ElixirOfMajorAgility:
-			character.AddStats(stats.Stats{
-				stats.Agility:   35,
-				stats.MeleeCrit: 20,
-			})
-		case proto.BattleElixir_ElixirOfMajorStrength:
-			character.AddStats(stats.Stats{
-				stats.Strength: 35,
-			})
-		case proto.BattleElixir_ElixirOfMastery:
-			character.AddStats(stats.Stats{
-				stats.Stamina:   15,
-				stats.Strength:  15,
-				stats.Agility:   15,
-				stats.Intellect: 15,
-				stats.Spirit:    15,
-			})
-		case proto.BattleElixir_ElixirOfTheMongoose:
-			character.AddStats(stats.Stats{
-				stats.Agility:   25,
-				stats.MeleeCrit: 28,
-			})
-		case proto.BattleElixir_FelStrengthElixir:
-			character.AddStats(stats.Stats{
-				stats.AttackPower:       90,
-				stats.RangedAttackPower: 90,
-				stats.Stamina:           -10,
-			})
-		case proto.BattleElixir_GreaterArcaneElixir:
-			character.AddStats(stats.Stats{
-				stats.SpellPower: 35,
-			})
+		if battleElixir, ok := getConsumable(ids.BattleElixir, proto.ConsumableType_ConsumableTypeBattleElixir); ok {
+			applyPassiveConsumable(character, battleElixir)
+		}
+		if guardianElixir, ok := getConsumable(ids.GuardianElixir, proto.ConsumableType_ConsumableTypeGuardianElixir); ok {
+			applyPassiveConsumable(character, guardianElixir)
 		}
+	}
 
-		switch consumes.GuardianElixir {
-		case proto.GuardianElixir_ElixirOfMightyDefense:
-			character.AddStats(stats.Stats{
-				stats.Defense: 45,
-			})
-		case proto.GuardianElixir_ElixirOfMightyFortitude:
-			character.AddStats(stats.Stats{
-				stats.Health: 350,
-			})
-		case proto.GuardianElixir_ElixirOfMightyMageblood:
-			character.AddStats(stats.Stats{
-				stats.MP5: 30,
-			})
-		case proto.GuardianElixir_ElixirOfMightyThoughts:
-			character.AddStats(stats.Stats{
-				stats.Intellect: 45,
-			})
-		case proto.GuardianElixir_ElixirOfProtection:
-			character.AddStats(stats.Stats{
-				stats.Armor: 800,
-			})
-			if character.HasProfession(proto.Profession_Alchemy) {
-				character.AddStats(stats.Stats{
-					stats.Armor: 280,
-				})
-			}
-		case proto.GuardianElixir_ElixirOfSpirit:
-			character.AddStats(stats.Stats{
-				stats.Spirit: 50,
-			})
-		case proto.GuardianElixir_ElixirOfDraenicWisdom:
-			character.AddStats(stats.Stats{
-				stats.Intellect: 30,
-				stats.Spirit:    30,
-			})
-		case proto.GuardianElixir_ElixirOfIronskin:
-			character.AddStats(stats.Stats{
-				stats.Resilience: 30,
-			})
-		case proto.GuardianElixir_ElixirOfMajorDefense:
-			character.AddStats(stats.Stats{
-				stats.Armor: 550,
-			})
-		case proto.GuardianElixir_ElixirOfMajorFortitude:
-			character.AddStats(stats.Stats{
-				stats.Health: 250,
-			})
-		case proto.GuardianElixir_ElixirOfMajorMageblood:
-			character.AddStats(stats.Stats{
-				stats.MP5: 16,
-			})
-		case proto.GuardianElixir_GiftOfArthas:
-			character.AddStats(stats.Stats{
-				stats.ShadowResistance: 10,
-			})
-
-			debuffAuras := (&character.Unit).NewEnemyAuraArray(GiftOfArthasAura)
-
-			actionID := ActionID{SpellID: 11374}
-			goaProc := character.RegisterSpell(SpellConfig{
-				ActionID:    actionID,
-				SpellSchool: SpellSchoolNature,
-				ProcMask:    ProcMaskEmpty,
-
-				ThreatMultiplier: 1,
-				FlatThreatBonus:  90,
-
-				ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
-					debuffAuras.Get(target).Activate(sim)
-					spell.CalcAndDealOutcome(sim, target, spell.OutcomeAlwaysHit)
-				},
-			})
+	if food, ok := getConsumable(ids.Food, proto.ConsumableType_ConsumableTypeFood); ok {
+		applyPassiveConsumable(character, food)
+	}
 
-			character.RegisterAura(Aura{
-				Label:    "Gift of Arthas",
-				Duration: NeverExpires,
-				OnReset: func(aura *Aura, sim *Simulation) {
-					aura.Activate(sim)
-				},
-				OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
-					if result.Landed() &&
-						spell.SpellSchool == SpellSchoolPhysical &&
-						sim.RandomFloat("Gift of Arthas") < 0.3 {
-						goaProc.Cast(sim, spell.Unit)
-					}
-				},
-			})
-		}
+	registerPotionCD(agent, ids)
+	registerConjuredCD(agent, ids)
+	registerExplosivesCD(agent, consumes, ids)
+}
+
+// Applies the stats of a flask, elixir or food, plus any effect it has that
+// can't be described by the catalog.
+func applyPassiveConsumable(character *Character, consumable Consumable) {
+	character.AddStats(consumable.Stats)
+	if character.HasProfession(proto.Profession_Alchemy) {
+		character.AddStats(consumable.AlchemistStats)
 	}
 
-	switch consumes.Food {
-	case proto.Food_FoodFishFeast:
-		character.AddStats(stats.Stats{
-			stats.AttackPower:       80,
-			stats.RangedAttackPower: 80,
-			stats.SpellPower:        46,
-			stats.Stamina:           40,
-		})
-	case proto.Food_FoodGreatFeast:
-		character.AddStats(stats.Stats{
-			stats.AttackPower:       60,
-			stats.RangedAttackPower: 60,
-			stats.SpellPower:        35,
-			stats.Stamina:           30,
-		})
-	case proto.Food_FoodBlackenedDragonfin:
-		character.AddStats(stats.Stats{
-			stats.Agility: 40,
-			stats.Stamina: 40,
-		})
-	case proto.Food_FoodHeartyRhino:
-		character.AddStats(stats.Stats{
-			stats.ArmorPenetration: 40,
-			stats.Stamina:          40,
-		})
-	case proto.Food_FoodMegaMammothMeal:
-		character.AddStats(stats.Stats{
-			stats.AttackPower:       80,
-			stats.RangedAttackPower: 80,
-			stats.Stamina:           40,
-		})
-	case proto.Food_FoodSpicedWormBurger:
-		character.AddStats(stats.Stats{
-			stats.MeleeCrit: 40,
-			stats.SpellCrit: 40,
-			stats.Stamina:   40,
-		})
-	case proto.Food_FoodRhinoliciousWormsteak:
-		character.AddStats(stats.Stats{
-			stats.Expertise: 40,
-			stats.Stamina:   40,
-		})
-	case proto.Food_FoodImperialMantaSteak:
-		character.AddStats(stats.Stats{
-			stats.MeleeHaste: 40,
-			stats.SpellHaste: 40,
-			stats.Stamina:    40,
-		})
-	case proto.Food_FoodSnapperExtreme:
-		character.AddStats(stats.Stats{
-			stats.MeleeHit: 40,
-			stats.SpellHit: 40,
-			stats.Stamina:  40,
-		})
-	case proto.Food_FoodMightyRhinoDogs:
-		character.AddStats(stats.Stats{
-			stats.MP5:     16,
-			stats.Stamina: 40,
-		})
-	case proto.Food_FoodFirecrackerSalmon:
-		character.AddStats(stats.Stats{
-			stats.SpellPower: 46,
-			stats.Stamina:    40,
-		})
-	case proto.Food_FoodCuttlesteak:
-		character.AddStats(stats.Stats{
-			stats.Spirit:  40,
-			stats.Stamina: 40,
-		})
-	case proto.Food_FoodDragonfinFilet:
-		character.AddStats(stats.Stats{
-			stats.Strength: 40,
-			stats.Stamina:  40,
-		})
-	case proto.Food_FoodBlackenedBasilisk:
-		character.AddStats(stats.Stats{
-			stats.SpellPower: 23,
-			stats.Spirit:     20,
-		})
-	case proto.Food_FoodGrilledMudfish:
-		character.AddStats(stats.Stats{
-			stats.Agility: 20,
-			stats.Spirit:  20,
-		})
-	case proto.Food_FoodRavagerDog:
-		character.AddStats(stats.Stats{
-			stats.AttackPower:       40,
-			stats.RangedAttackPower: 40,
-			stats.Spirit:            20,
-		})
-	case proto.Food_FoodRoastedClefthoof:
-		character.AddStats(stats.Stats{
-			stats.Strength: 20,
-			stats.Spirit:   20,
+	switch consumable.ID {
+	case 22861: // Flask of Blinding Light
+		character.OnSpellRegistered(func(spell *Spell) {
+			if spell.SpellSchool.Matches(SpellSchoolArcane | SpellSchoolHoly | SpellSchoolNature) {
+				spell.BonusSpellPower += 80
+			}
 		})
-	case proto.Food_FoodSkullfishSoup:
-		character.AddStats(stats.Stats{
-			stats.SpellCrit: 20,
-			stats.Spirit:    20,
+	case 22866: // Flask of Pure Death
+		character.OnSpellRegistered(func(spell *Spell) {
+			if spell.SpellSchool.Matches(SpellSchoolFire | SpellSchoolFrost | SpellSchoolShadow) {
+				spell.BonusSpellPower += 80
+			}
 		})
-	case proto.Food_FoodSpicyHotTalbuk:
-		character.AddStats(stats.Stats{
-			stats.MeleeHit: 20,
-			stats.Spirit:   20,
+	case 9224: // Elixir of Demonslaying
+		if character.CurrentTarget.MobType == proto.MobType_MobTypeDemon {
+			character.PseudoStats.MobTypeAttackPower += 265
+		}
+	case 9088: // Gift of Arthas
+		debuffAuras := (&character.Unit).NewEnemyAuraArray(GiftOfArthasAura)
+
+		actionID := ActionID{SpellID: 11374}
+		goaProc := character.RegisterSpell(SpellConfig{
+			ActionID:    actionID,
+			SpellSchool: SpellSchoolNature,
+			ProcMask:    ProcMaskEmpty,
+
+			ThreatMultiplier: 1,
+			FlatThreatBonus:  90,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				debuffAuras.Get(target).Activate(sim)
+				spell.CalcAndDealOutcome(sim, target, spell.OutcomeAlwaysHit)
+			},
 		})
-	case proto.Food_FoodFishermansFeast:
-		character.AddStats(stats.Stats{
-			stats.Stamina: 30,
-			stats.Spirit:  20,
+
+		character.RegisterAura(Aura{
+			Label:    "Gift of Arthas",
+			Duration: NeverExpires,
+			OnReset: func(aura *Aura, sim *Simulation) {
+				aura.Activate(sim)
+			},
+			OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+				if result.Landed() &&
+					spell.SpellSchool == SpellSchoolPhysical &&
+					sim.RandomFloat("Gift of Arthas") < 0.3 {
+					goaProc.Cast(sim, spell.Unit)
+				}
+			},
 		})
 	}
-
-	registerPotionCD(agent, consumes)
-	registerConjuredCD(agent, consumes)
-	registerExplosivesCD(agent, consumes)
 }
 
 func ApplyPetConsumeEffects(pet *Character, ownerConsumes *proto.Consumes) {
-	switch ownerConsumes.PetFood {
-	case proto.PetFood_PetFoodSpicedMammothTreats:
-		pet.AddStats(stats.Stats{
-			stats.Strength: 30,
-			stats.Stamina:  30,
-		})
-	case proto.PetFood_PetFoodKiblersBits:
-		pet.AddStats(stats.Stats{
-			stats.Strength: 20,
-			stats.Stamina:  20,
-		})
+	if petFood, ok := getConsumable(GetConsumableIDs(ownerConsumes).PetFood, proto.ConsumableType_ConsumableTypePetFood); ok {
+		pet.AddStats(petFood.Stats)
 	}
 
 	pet.AddStat(stats.Agility, []float64{0, 5, 9, 13, 17, 20}[ownerConsumes.PetScrollOfAgility])
@@ -445,21 +107,21 @@ func ApplyPetConsumeEffects(pet *Character, ownerConsumes *proto.Consumes) {
 
 var PotionAuraTag = "Potion"
 
-func registerPotionCD(agent Agent, consumes *proto.Consumes) {
+func registerPotionCD(agent Agent, ids ConsumableIDs) {
 	character := agent.GetCharacter()
-	defaultPotion := consumes.DefaultPotion
-	startingPotion := consumes.PrepopPotion
+	defaultPotion := ids.DefaultPotion
+	startingPotion := ids.PrepopPotion
 
 	potionCD := character.NewTimer()
 	if character.Spec == proto.Spec_SpecBalanceDruid {
 		// Create both pots spells so they will be selectable in APL UI regardless of settings.
-		speedMCD := makePotionActivation(proto.Potions_PotionOfSpeed, character, potionCD)
-		wildMagicMCD := makePotionActivation(proto.Potions_PotionOfWildMagic, character, potionCD)
+		speedMCD := makePotionActivation(legacyPotionIDs[proto.Potions_PotionOfSpeed], character, potionCD)
+		wildMagicMCD := makePotionActivation(legacyPotionIDs[proto.Potions_PotionOfWildMagic], character, potionCD)
 		speedMCD.Spell.Flags |= SpellFlagAPL | SpellFlagMCD
 		wildMagicMCD.Spell.Flags |= SpellFlagAPL | SpellFlagMCD
 	}
 
-	if defaultPotion == proto.Potions_UnknownPotion && startingPotion == proto.Potions_UnknownPotion {
+	if defaultPotion == 0 && startingPotion == 0 {
 		return
 	}
 
@@ -490,8 +152,13 @@ func (character *Character) HasAlchStone() bool {
 	return character.HasProfession(proto.Profession_Alchemy) && alchStoneEquipped
 }
 
-func makePotionActivation(potionType proto.Potions, character *Character, potionCD *Timer) MajorCooldown {
-	mcd := makePotionActivationInternal(potionType, character, potionCD)
+func makePotionActivation(itemID int32, character *Character, potionCD *Timer) MajorCooldown {
+	potion, ok := getConsumable(itemID, proto.ConsumableType_ConsumableTypePotion)
+	if !ok {
+		return MajorCooldown{}
+	}
+
+	mcd := makePotionActivationInternal(potion, character, potionCD)
 	if mcd.Spell != nil {
 		// Mark as 'Encounter Only' so that users are forced to select the generic Potion
 		// placeholder action instead of specific potion spells, in APL prepull. This
@@ -501,11 +168,7 @@ func makePotionActivation(potionType proto.Potions, character *Character, potion
 		mcd.Spell.ApplyEffects = func(sim *Simulation, target *Unit, spell *Spell) {
 			oldApplyEffects(sim, target, spell)
 			if sim.CurrentTime < 0 {
-				if potionType == proto.Potions_IndestructiblePotion {
-					potionCD.Set(sim.CurrentTime + 2*time.Minute)
-				} else {
-					potionCD.Set(sim.CurrentTime + time.Minute)
-				}
+				potionCD.Set(sim.CurrentTime + potion.SharedCooldown)
 				character.UpdateMajorCooldowns()
 			}
 		}
@@ -513,7 +176,15 @@ func makePotionActivation(potionType proto.Potions, character *Character, potion
 	return mcd
 }
 
-func makePotionActivationInternal(potionType proto.Potions, character *Character, potionCD *Timer) MajorCooldown {
+// Only uses a random roll for ranges, so fixed amounts don't shift the RNG.
+func rollConsumable(sim *Simulation, min float64, max float64, label string) float64 {
+	if min >= max {
+		return max
+	}
+	return sim.RollWithLabel(min, max, label)
+}
+
+func makePotionActivationInternal(potion Consumable, character *Character, potionCD *Timer) MajorCooldown {
 	alchStoneEquipped := character.HasAlchStone()
 	hasEngi := character.HasProfession(proto.Profession_Engineering)
 
@@ -524,12 +195,10 @@ func makePotionActivationInternal(potionType proto.Potions, character *Character
 		},
 	}
 
-	if potionType == proto.Potions_RunicHealingPotion || potionType == proto.Potions_RunicHealingInjector {
-		itemId := map[proto.Potions]int32{
-			proto.Potions_RunicHealingPotion:   33447,
-			proto.Potions_RunicHealingInjector: 41166,
-		}[potionType]
-		actionID := ActionID{ItemID: itemId}
+	actionID := ActionID{ItemID: potion.ID}
+	switch potion.ID {
+	case 33447, 41166: // Runic Healing Potion, Runic Healing Injector
+		isInjector := potion.ID == 41166
 		healthMetrics := character.NewHealthMetrics(actionID)
 		return MajorCooldown{
 			Type: CooldownTypeSurvival,
@@ -540,21 +209,17 @@ func makePotionActivationInternal(potionType proto.Potions, character *Character
 				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
 					healthGain := sim.RollWithLabel(2700, 4500, "RunicHealingPotion")
 
-					if alchStoneEquipped && potionType == proto.Potions_RunicHealingPotion {
+					if alchStoneEquipped && !isInjector {
 						healthGain *= 1.40
-					} else if hasEngi && potionType == proto.Potions_RunicHealingInjector {
+					} else if hasEngi && isInjector {
 						healthGain *= 1.25
 					}
 					character.GainHealth(sim, healthGain*character.PseudoStats.HealingTakenMultiplier, healthMetrics)
 				},
 			}),
 		}
-	} else if potionType == proto.Potions_RunicManaPotion || potionType == proto.Potions_RunicManaInjector {
-		itemId := map[proto.Potions]int32{
-			proto.Potions_RunicManaPotion:   33448,
-			proto.Potions_RunicManaInjector: 42545,
-		}[potionType]
-		actionID := ActionID{ItemID: itemId}
+	case 33448, 42545: // Runic Mana Potion, Runic Mana Injector
+		isInjector := potion.ID == 42545
 		manaMetrics := character.NewManaMetrics(actionID)
 		return MajorCooldown{
 			Type: CooldownTypeMana,
@@ -562,9 +227,9 @@ func makePotionActivationInternal(potionType proto.Potions, character *Character
 				// Only pop if we have less than the max mana provided by the potion minus 1mp5 tick.
 				totalRegen := character.ManaRegenPerSecondWhileCasting() * 5
 				manaGain := 4400.0
-				if alchStoneEquipped && potionType == proto.Potions_RunicManaPotion {
+				if alchStoneEquipped && !isInjector {
 					manaGain *= 1.4
-				} else if hasEngi && potionType == proto.Potions_RunicManaInjector {
+				} else if hasEngi && isInjector {
 					manaGain *= 1.25
 				}
 				return character.MaxMana()-(character.CurrentMana()+totalRegen) >= manaGain
@@ -575,74 +240,16 @@ func makePotionActivationInternal(potionType proto.Potions, character *Character
 				Cast:     potionCast,
 				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
 					manaGain := sim.RollWithLabel(4200, 4400, "RunicManaPotion")
-					if alchStoneEquipped && potionType == proto.Potions_RunicManaPotion {
+					if alchStoneEquipped && !isInjector {
 						manaGain *= 1.4
-					} else if hasEngi && potionType == proto.Potions_RunicManaInjector {
+					} else if hasEngi && isInjector {
 						manaGain *= 1.25
 					}
 					character.AddMana(sim, manaGain, manaMetrics)
 				},
 			}),
 		}
-	} else if potionType == proto.Potions_IndestructiblePotion {
-		actionID := ActionID{ItemID: 40093}
-		aura := character.NewTemporaryStatsAura("Indestructible Potion", actionID, stats.Stats{stats.Armor: 3500}, time.Minute*2)
-		return MajorCooldown{
-			Type: CooldownTypeDPS,
-			Spell: character.GetOrRegisterSpell(SpellConfig{
-				ActionID: actionID,
-				Flags:    SpellFlagNoOnCastComplete,
-				Cast:     potionCast,
-				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
-					aura.Activate(sim)
-				},
-			}),
-		}
-	} else if potionType == proto.Potions_PotionOfSpeed {
-		actionID := ActionID{ItemID: 40211}
-		aura := character.NewTemporaryStatsAura("Potion of Speed", actionID, stats.Stats{stats.MeleeHaste: 500, stats.SpellHaste: 500}, time.Second*15)
-		return MajorCooldown{
-			Type: CooldownTypeDPS,
-			Spell: character.GetOrRegisterSpell(SpellConfig{
-				ActionID: actionID,
-				Flags:    SpellFlagNoOnCastComplete,
-				Cast:     potionCast,
-				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
-					aura.Activate(sim)
-				},
-			}),
-		}
-	} else if potionType == proto.Potions_PotionOfWildMagic {
-		actionID := ActionID{ItemID: 40212}
-		aura := character.NewTemporaryStatsAura("Potion of Wild Magic", actionID, stats.Stats{stats.SpellPower: 200, stats.SpellCrit: 200, stats.MeleeCrit: 200}, time.Second*15)
-		return MajorCooldown{
-			Type: CooldownTypeDPS,
-			Spell: character.GetOrRegisterSpell(SpellConfig{
-				ActionID: actionID,
-				Flags:    SpellFlagNoOnCastComplete,
-				Cast:     potionCast,
-				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
-					aura.Activate(sim)
-				},
-			}),
-		}
-	} else if potionType == proto.Potions_DestructionPotion {
-		actionID := ActionID{ItemID: 22839}
-		aura := character.NewTemporaryStatsAura("Destruction Potion", actionID, stats.Stats{stats.SpellPower: 120, stats.SpellCrit: 2 * CritRatingPerCritChance}, time.Second*15)
-		return MajorCooldown{
-			Type: CooldownTypeDPS,
-			Spell: character.GetOrRegisterSpell(SpellConfig{
-				ActionID: actionID,
-				Flags:    SpellFlagNoOnCastComplete,
-				Cast:     potionCast,
-				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
-					aura.Activate(sim)
-				},
-			}),
-		}
-	} else if potionType == proto.Potions_SuperManaPotion {
-		alchStoneEquipped := character.HasAlchStone()
-		actionID := ActionID{ItemID: 22832}
+	case 22832: // Super Mana Potion
 		manaMetrics := character.NewManaMetrics(actionID)
 		return MajorCooldown{
 			Type: CooldownTypeMana,
@@ -665,22 +272,7 @@ func makePotionActivationInternal(potionType proto.Potions, character *Character
 				},
 			}),
 		}
-	} else if potionType == proto.Potions_HastePotion {
-		actionID := ActionID{ItemID: 22838}
-		aura := character.NewTemporaryStatsAura("Haste Potion", actionID, stats.Stats{stats.MeleeHaste: 400}, time.Second*15)
-		return MajorCooldown{
-			Type: CooldownTypeDPS,
-			Spell: character.GetOrRegisterSpell(SpellConfig{
-				ActionID: actionID,
-				Flags:    SpellFlagNoOnCastComplete,
-				Cast:     potionCast,
-				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
-					aura.Activate(sim)
-				},
-			}),
-		}
-	} else if potionType == proto.Potions_MightyRagePotion {
-		actionID := ActionID{ItemID: 13442}
+	case 13442: // Mighty Rage Potion
 		aura := character.NewTemporaryStatsAura("Mighty Rage Potion", actionID, stats.Stats{stats.Strength: 60}, time.Second*15)
 		rageMetrics := character.NewRageMetrics(actionID)
 		return MajorCooldown{
@@ -704,12 +296,9 @@ func makePotionActivationInternal(potionType proto.Potions, character *Character
 				},
 			}),
 		}
-	} else if potionType == proto.Potions_FelManaPotion {
-		actionID := ActionID{ItemID: 31677}
-
+	case 31677: // Fel Mana Potion
 		// Restores 3200 mana over 24 seconds.
 		manaGain := 3200.0
-		alchStoneEquipped := character.HasAlchStone()
 		if alchStoneEquipped {
 			manaGain *= 1.4
 		}
@@ -736,60 +325,77 @@ func makePotionActivationInternal(potionType proto.Potions, character *Character
 				},
 			}),
 		}
-	} else if potionType == proto.Potions_InsaneStrengthPotion {
-		actionID := ActionID{ItemID: 22828}
-		aura := character.NewTemporaryStatsAura("Insane Strength Potion", actionID, stats.Stats{stats.Strength: 120, stats.Defense: -75}, time.Second*15)
-		return MajorCooldown{
-			Type: CooldownTypeDPS,
-			Spell: character.GetOrRegisterSpell(SpellConfig{
-				ActionID: actionID,
-				Flags:    SpellFlagNoOnCastComplete,
-				Cast:     potionCast,
-				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
-					aura.Activate(sim)
-				},
-			}),
-		}
-	} else if potionType == proto.Potions_IronshieldPotion {
-		actionID := ActionID{ItemID: 22849}
-		aura := character.NewTemporaryStatsAura("Ironshield Potion", actionID, stats.Stats{stats.Armor: 2500}, time.Minute*2)
-		return MajorCooldown{
-			Type: CooldownTypeDPS,
-			Spell: character.GetOrRegisterSpell(SpellConfig{
-				ActionID: actionID,
-				Flags:    SpellFlagNoOnCastComplete,
-				Cast:     potionCast,
-				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
-					aura.Activate(sim)
-				},
-			}),
-		}
-	} else if potionType == proto.Potions_HeroicPotion {
-		actionID := ActionID{ItemID: 22837}
-		aura := character.NewTemporaryStatsAura("Heroic Potion", actionID, stats.Stats{stats.Strength: 70, stats.Health: 700}, time.Second*15)
-		return MajorCooldown{
-			Type: CooldownTypeDPS,
-			Spell: character.GetOrRegisterSpell(SpellConfig{
-				ActionID: actionID,
-				Flags:    SpellFlagNoOnCastComplete,
-				Cast:     potionCast,
-				ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
+	default:
+		return character.newConsumableActivation(potion, potionCast)
+	}
+}
+
+// Creates the on-use effect of a catalog consumable from its data alone: a
+// temporary stat buff and/or a mana or health restore.
+func (character *Character) newConsumableActivation(consumable Consumable, cast CastConfig) MajorCooldown {
+	if !consumable.hasOnUseEffect() {
+		return MajorCooldown{}
+	}
+
+	actionID := ActionID{ItemID: consumable.ID}
+	var aura *Aura
+	if consumable.BuffDuration > 0 && consumable.Stats != (stats.Stats{}) {
+		aura = character.NewTemporaryStatsAura(consumable.Name, actionID, consumable.Stats, consumable.BuffDuration)
+	}
+	var manaMetrics *ResourceMetrics
+	if consumable.MaxMana > 0 {
+		manaMetrics = character.NewManaMetrics(actionID)
+	}
+	var healthMetrics *ResourceMetrics
+	if consumable.MaxHealth > 0 {
+		healthMetrics = character.NewHealthMetrics(actionID)
+	}
+
+	mcd := MajorCooldown{
+		Type: CooldownTypeDPS,
+		Spell: character.GetOrRegisterSpell(SpellConfig{
+			ActionID: actionID,
+			Flags:    SpellFlagNoOnCastComplete,
+			Cast:     cast,
+			ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
+				if aura != nil {
 					aura.Activate(sim)
-				},
-			}),
+				}
+				if manaMetrics != nil {
+					character.AddMana(sim, rollConsumable(sim, consumable.MinMana, consumable.MaxMana, consumable.Name), manaMetrics)
+				}
+				if healthMetrics != nil {
+					healthGain := rollConsumable(sim, consumable.MinHealth, consumable.MaxHealth, consumable.Name)
+					character.GainHealth(sim, healthGain*character.PseudoStats.HealingTakenMultiplier, healthMetrics)
+				}
+			},
+		}),
+	}
+
+	if manaMetrics != nil {
+		mcd.Type = CooldownTypeMana
+		mcd.ShouldActivate = func(sim *Simulation, character *Character) bool {
+			// Only pop if we have less than the max mana provided minus 1mp5 tick.
+			totalRegen := character.ManaRegenPerSecondWhileCasting() * 5
+			return character.MaxMana()-(character.CurrentMana()+totalRegen) >= consumable.MaxMana
 		}
-	} else {
-		return MajorCooldown{}
+	} else if aura == nil {
+		mcd.Type = CooldownTypeSurvival
 	}
+	return mcd
 }
 
 var ConjuredAuraTag = "Conjured"
 
-func registerConjuredCD(agent Agent, consumes *proto.Consumes) {
+func registerConjuredCD(agent Agent, ids ConsumableIDs) {
 	character := agent.GetCharacter()
-	conjuredType := consumes.DefaultConjured
+	conjured, ok := getConsumable(ids.DefaultConjured, proto.ConsumableType_ConsumableTypeConjured)
+	if !ok {
+		return
+	}
 
-	if conjuredType == proto.Conjured_ConjuredDarkRune {
+	switch conjured.ID {
+	case 20520: // Dark Rune
 		actionID := ActionID{ItemID: 20520}
 		manaMetrics := character.NewManaMetrics(actionID)
 		// damageTakenManaMetrics := character.NewManaMetrics(ActionID{SpellID: 33776})
@@ -825,7 +431,7 @@ func registerConjuredCD(agent Agent, consumes *proto.Consumes) {
 				return character.MaxMana()-(character.CurrentMana()+totalRegen) >= 1500
 			},
 		})
-	} else if conjuredType == proto.Conjured_ConjuredFlameCap {
+	case 22788: // Flame Cap
 		actionID := ActionID{ItemID: 22788}
 
 		flameCapProc := character.RegisterSpell(SpellConfig{
@@ -893,27 +499,23 @@ func registerConjuredCD(agent Agent, consumes *proto.Consumes) {
 			Spell: spell,
 			Type:  CooldownTypeDPS,
 		})
-	} else if conjuredType == proto.Conjured_ConjuredHealthstone {
-		actionID := ActionID{ItemID: 36892}
-		healthMetrics := character.NewHealthMetrics(actionID)
-
-		spell := character.RegisterSpell(SpellConfig{
-			ActionID: actionID,
-			Flags:    SpellFlagNoOnCastComplete,
-			Cast: CastConfig{
-				CD: Cooldown{
-					Timer:    character.GetConjuredCD(),
-					Duration: time.Minute * 2,
-				},
+	default:
+		cast := CastConfig{
+			CD: Cooldown{
+				Timer:    character.GetConjuredCD(),
+				Duration: conjured.SharedCooldown,
 			},
-			ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
-				character.GainHealth(sim, 4280*character.PseudoStats.HealingTakenMultiplier, healthMetrics)
-			},
-		})
-		character.AddMajorCooldown(MajorCooldown{
-			Spell: spell,
-			Type:  CooldownTypeSurvival,
-		})
+		}
+		if conjured.Cooldown > 0 {
+			cast.SharedCD = cast.CD
+			cast.CD = Cooldown{
+				Timer:    character.NewTimer(),
+				Duration: conjured.Cooldown,
+			}
+		}
+		if mcd := character.newConsumableActivation(conjured, cast); mcd.Spell != nil {
+			character.AddMajorCooldown(mcd)
+		}
 	}
 }
 
@@ -922,9 +524,9 @@ var ExplosiveDecoyActionID = ActionID{ItemID: 40536}
 var SaroniteBombActionID = ActionID{ItemID: 41119}
 var CobaltFragBombActionID = ActionID{ItemID: 40771}
 
-func registerExplosivesCD(agent Agent, consumes *proto.Consumes) {
+func registerExplosivesCD(agent Agent, consumes *proto.Consumes, ids ConsumableIDs) {
 	character := agent.GetCharacter()
-	hasFiller := consumes.FillerExplosive != proto.Explosive_ExplosiveUnknown
+	filler, hasFiller := getConsumable(ids.FillerExplosive, proto.ConsumableType_ConsumableTypeExplosive)
 	if !character.HasProfession(proto.Profession_Engineering) {
 		return
 	}
@@ -935,7 +537,7 @@ func registerExplosivesCD(agent Agent, consumes *proto.Consumes) {
 
 	if consumes.ThermalSapper {
 		character.AddMajorCooldown(MajorCooldown{
-			Spell:    character.newThermalSapperSpell(sharedTimer),
+			Spell:    character.newExplosiveSpell(sharedTimer, ConsumablesByID[ThermalSapperActionID.ItemID]),
 			Type:     CooldownTypeDPS | CooldownTypeExplosive,
 			Priority: CooldownPriorityLow + 30,
 		})
@@ -943,7 +545,7 @@ func registerExplosivesCD(agent Agent, consumes *proto.Consumes) {
 
 	if consumes.ExplosiveDecoy {
 		character.AddMajorCooldown(MajorCooldown{
-			Spell:    character.newExplosiveDecoySpell(sharedTimer),
+			Spell:    character.newExplosiveSpell(sharedTimer, ConsumablesByID[ExplosiveDecoyActionID.ItemID]),
 			Type:     CooldownTypeDPS | CooldownTypeExplosive,
 			Priority: CooldownPriorityLow + 20,
 			ShouldActivate: func(sim *Simulation, character *Character) bool {
@@ -955,36 +557,35 @@ func registerExplosivesCD(agent Agent, consumes *proto.Consumes) {
 	}
 
 	if hasFiller {
-		var filler *Spell
-		switch consumes.FillerExplosive {
-		case proto.Explosive_ExplosiveSaroniteBomb:
-			filler = character.newSaroniteBombSpell(sharedTimer)
-		case proto.Explosive_ExplosiveCobaltFragBomb:
-			filler = character.newCobaltFragBombSpell(sharedTimer)
-		}
-
 		character.AddMajorCooldown(MajorCooldown{
-			Spell:    filler,
+			Spell:    character.newExplosiveSpell(sharedTimer, filler),
 			Type:     CooldownTypeDPS | CooldownTypeExplosive,
 			Priority: CooldownPriorityLow + 10,
 		})
 	}
 }
 
-// Creates a spell object for the common explosive case.
-func (character *Character) newBasicExplosiveSpellConfig(sharedTimer *Timer, actionID ActionID, school SpellSchool, minDamage float64, maxDamage float64, cooldown Cooldown, _ float64, _ float64) SpellConfig {
+// Creates a spell object for an explosive, which damages all targets.
+func (character *Character) newExplosiveSpell(sharedTimer *Timer, explosive Consumable) *Spell {
+	actionID := ActionID{ItemID: explosive.ID}
 	dealSelfDamage := actionID.SameAction(ThermalSapperActionID)
+	minDamage, maxDamage := explosive.MinDamage, explosive.MaxDamage
+
+	var cooldown Cooldown
+	if explosive.Cooldown > 0 {
+		cooldown = Cooldown{Timer: character.NewTimer(), Duration: explosive.Cooldown}
+	}
 
-	return SpellConfig{
+	return character.GetOrRegisterSpell(SpellConfig{
 		ActionID:    actionID,
-		SpellSchool: school,
+		SpellSchool: explosive.School,
 		ProcMask:    ProcMaskEmpty,
 
 		Cast: CastConfig{
 			CD: cooldown,
 			SharedCD: Cooldown{
 				Timer:    sharedTimer,
-				Duration: TernaryDuration(actionID.SameAction(ExplosiveDecoyActionID), time.Minute*2, time.Minute),
+				Duration: explosive.SharedCooldown,
 			},
 		},
 
@@ -1005,17 +606,5 @@ func (character *Character) newBasicExplosiveSpellConfig(sharedTimer *Timer, act
 				spell.CalcAndDealDamage(sim, &character.Unit, baseDamage, spell.OutcomeMagicHitAndCrit)
 			}
 		},
-	}
-}
-func (character *Character) newThermalSapperSpell(sharedTimer *Timer) *Spell {
-	return character.GetOrRegisterSpell(character.newBasicExplosiveSpellConfig(sharedTimer, ThermalSapperActionID, SpellSchoolFire, 2188, 2812, Cooldown{Timer: character.NewTimer(), Duration: time.Minute * 5}, 2188, 2812))
-}
-func (character *Character) newExplosiveDecoySpell(sharedTimer *Timer) *Spell {
-	return character.GetOrRegisterSpell(character.newBasicExplosiveSpellConfig(sharedTimer, ExplosiveDecoyActionID, SpellSchoolPhysical, 1440, 2160, Cooldown{Timer: character.NewTimer(), Duration: time.Minute * 2}, 0, 0))
-}
-func (character *Character) newSaroniteBombSpell(sharedTimer *Timer) *Spell {
-	return character.GetOrRegisterSpell(character.newBasicExplosiveSpellConfig(sharedTimer, SaroniteBombActionID, SpellSchoolFire, 1150, 1500, Cooldown{}, 0, 0))
-}
-func (character *Character) newCobaltFragBombSpell(sharedTimer *Timer) *Spell {
-	return character.GetOrRegisterSpell(character.newBasicExplosiveSpellConfig(sharedTimer, CobaltFragBombActionID, SpellSchoolFire, 750, 1000, Cooldown{}, 0, 0))
+	})
 }
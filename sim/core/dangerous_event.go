@@ -0,0 +1,81 @@
+package core
+
+import (
+	"time"
+)
+
+// An upcoming boss ability which players should prepare for, e.g. by using a survival cooldown.
+type DangerousEvent struct {
+	// The ability responsible for the event.
+	ActionID ActionID
+
+	// When the event starts, in sim time. May be in the past for events which are still ongoing.
+	Time time.Duration
+
+	// How long the event lasts, 0 for a single hit.
+	Duration time.Duration
+
+	// Expected damage of each hit taken during the event, before mitigation.
+	Damage float64
+	School SpellSchool
+
+	// The unit which will take the damage, or nil if the whole raid is hit.
+	Target *Unit
+}
+
+// Returns when the event ends, NeverExpires for events lasting the rest of the fight.
+func (event DangerousEvent) EndsAt() time.Duration {
+	if event.Duration >= NeverExpires-event.Time {
+		return NeverExpires
+	}
+	return event.Time + event.Duration
+}
+
+func (event DangerousEvent) IsActive(sim *Simulation) bool {
+	return event.Time <= sim.CurrentTime && sim.CurrentTime <= event.EndsAt()
+}
+
+// Returns the time until the event starts, or 0 if it is already active.
+func (event DangerousEvent) TimeUntil(sim *Simulation) time.Duration {
+	return max(0, event.Time-sim.CurrentTime)
+}
+
+// Predicts the next occurrence of a dangerous ability. Returns false if it
+// won't happen again this iteration.
+type DangerousEventPredictor func(sim *Simulation) (DangerousEvent, bool)
+
+// Publishes a boss ability for players to plan around. Should be called from
+// the AI's Initialize.
+func (target *Target) RegisterDangerousEvent(predictor DangerousEventPredictor) {
+	target.dangerousEventPredictors = append(target.dangerousEventPredictors, predictor)
+}
+
+// Whether any target publishes dangerous events.
+func (env *Environment) HasDangerousEvents() bool {
+	for _, target := range env.Encounter.Targets {
+		if len(target.dangerousEventPredictors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the earliest dangerous event from any target which hits unit,
+// including events which are already active.
+func (env *Environment) NextDangerousEvent(sim *Simulation, unit *Unit) (DangerousEvent, bool) {
+	var next DangerousEvent
+	found := false
+	for _, target := range env.Encounter.Targets {
+		for _, predictor := range target.dangerousEventPredictors {
+			event, ok := predictor(sim)
+			if !ok || event.EndsAt() < sim.CurrentTime || (event.Target != nil && event.Target != unit) {
+				continue
+			}
+			if !found || event.Time < next.Time {
+				next = event
+				found = true
+			}
+		}
+	}
+	return next, found
+}
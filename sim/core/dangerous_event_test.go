@@ -0,0 +1,76 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSurvivalCooldownWaitsForDangerousEvent(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.Spell.CD = Cooldown{Timer: fa.NewTimer(), Duration: time.Minute * 5}
+
+	sim.Encounter.Targets[0].RegisterDangerousEvent(func(sim *Simulation) (DangerousEvent, bool) {
+		return DangerousEvent{
+			ActionID: ActionID{SpellID: 1},
+			Time:     time.Second * 30,
+			Damage:   10000,
+			Target:   &fa.Unit,
+		}, true
+	})
+
+	mcd := &MajorCooldown{
+		Spell:          fa.Spell,
+		Type:           CooldownTypeSurvival,
+		ShouldActivate: func(sim *Simulation, character *Character) bool { return true },
+	}
+	if mcd.shouldActivateHelper(sim, &fa.Character) {
+		t.Fatalf("Expected the cooldown to be saved for the dangerous event")
+	}
+
+	// A cooldown which will be ready again in time is used freely.
+	fa.Spell.CD.Duration = time.Second * 20
+	if !mcd.shouldActivateHelper(sim, &fa.Character) {
+		t.Fatalf("Expected a short cooldown to be used before the dangerous event")
+	}
+	fa.Spell.CD.Duration = time.Minute * 5
+
+	sim.CurrentTime = time.Second * 29
+	if !mcd.shouldActivateHelper(sim, &fa.Character) {
+		t.Fatalf("Expected the cooldown to be used just before the dangerous event")
+	}
+
+	event, ok := sim.Environment.NextDangerousEvent(sim, &fa.Unit)
+	if !ok || event.TimeUntil(sim) != time.Second {
+		t.Fatalf("Expected the next dangerous event in 1s, got %v", event)
+	}
+	if _, ok := sim.Environment.NextDangerousEvent(sim, sim.GetTargetUnit(0)); ok {
+		t.Fatalf("Expected no dangerous event for other units")
+	}
+}
+
+func TestDangerousEventLastingForever(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	// Like an enrage, which lasts for the rest of the fight once it starts.
+	sim.Encounter.Targets[0].RegisterDangerousEvent(func(sim *Simulation) (DangerousEvent, bool) {
+		return DangerousEvent{
+			ActionID: ActionID{SpellID: 1},
+			Time:     time.Second * 30,
+			Duration: NeverExpires,
+			Damage:   10000,
+		}, true
+	})
+
+	event, ok := sim.Environment.NextDangerousEvent(sim, &fa.Unit)
+	if !ok || event.EndsAt() != NeverExpires || event.IsActive(sim) {
+		t.Fatalf("Expected an upcoming event which never ends, got %v", event)
+	}
+
+	sim.CurrentTime = time.Minute * 2
+	event, ok = sim.Environment.NextDangerousEvent(sim, &fa.Unit)
+	if !ok || !event.IsActive(sim) {
+		t.Fatalf("Expected the event to still be active, got %v", event)
+	}
+}
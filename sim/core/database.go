@@ -2,6 +2,10 @@ package core
 
 import (
 	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
 	"github.com/wowsims/wotlk/sim/core/proto"
 	"github.com/wowsims/wotlk/sim/core/stats"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -13,26 +17,65 @@ var ItemsByID = map[int32]Item{}
 var GemsByID = map[int32]Gem{}
 var EnchantsByEffectID = map[int32]Enchant{}
 
+// IDs of the entries which came from a player's database rather than the
+// built-in one.
+var customItemIDs = map[int32]bool{}
+var customEnchantIDs = map[int32]bool{}
+var customGemIDs = map[int32]bool{}
+var customConsumableIDs = map[int32]bool{}
+
 func addToDatabase(newDB *proto.SimDatabase) {
+	addDatabase(newDB, false)
+}
+
+// Adds the entries of a player's database. These never replace built-in
+// entries, but do replace earlier custom ones, so a custom item can be redefined.
+func addPlayerDatabase(newDB *proto.SimDatabase) {
+	addDatabase(newDB, true)
+}
+
+// Guards writes to the database maps. Sims read the maps without locking, so requests add
+// their player's database before starting any sims, e.g. see CalcStatWeight.
+var databaseMutex sync.Mutex
+
+func addDatabase(newDB *proto.SimDatabase, custom bool) {
+	databaseMutex.Lock()
+	defer databaseMutex.Unlock()
+
 	for _, v := range newDB.Items {
-		if _, ok := ItemsByID[v.Id]; !ok {
-			ItemsByID[v.Id] = ItemFromProto(v)
-		}
+		addEntry(ItemsByID, customItemIDs, v.Id, custom, func() Item { return ItemFromProto(v) })
 	}
 
 	for _, v := range newDB.Enchants {
-		if _, ok := EnchantsByEffectID[v.EffectId]; !ok {
-			EnchantsByEffectID[v.EffectId] = EnchantFromProto(v)
-		}
+		addEntry(EnchantsByEffectID, customEnchantIDs, v.EffectId, custom, func() Enchant { return EnchantFromProto(v) })
 	}
 
 	for _, v := range newDB.Gems {
-		if _, ok := GemsByID[v.Id]; !ok {
-			GemsByID[v.Id] = GemFromProto(v)
-		}
+		addEntry(GemsByID, customGemIDs, v.Id, custom, func() Gem { return GemFromProto(v) })
+	}
+
+	for _, v := range newDB.Consumables {
+		addEntry(ConsumablesByID, customConsumableIDs, v.Id, custom, func() Consumable { return ConsumableFromProto(v) })
 	}
 }
 
+// Entries which are already present and identical aren't written again, because each
+// character of a sim adds its player's database while other sims may be running.
+func addEntry[T any](entriesByID map[int32]T, customIDs map[int32]bool, id int32, custom bool, fromProto func() T) {
+	existing, ok := entriesByID[id]
+	if ok && !(custom && customIDs[id]) {
+		return
+	}
+	entry := fromProto()
+	if ok && reflect.DeepEqual(existing, entry) {
+		return
+	}
+	if custom {
+		customIDs[id] = true
+	}
+	entriesByID[id] = entry
+}
+
 type Item struct {
 	ID        int32
 	Type      proto.ItemType
@@ -46,6 +89,7 @@ type Item struct {
 	SwingSpeed       float64
 
 	Name    string
+	Ilvl    int32
 	Stats   stats.Stats // Stats applied to wearer
 	Quality proto.ItemQuality
 	SetName string // Empty string if not part of a set.
@@ -53,6 +97,11 @@ type Item struct {
 	GemSockets  []proto.GemColor
 	SocketBonus stats.Stats
 
+	HasSpecialEffect bool // Has an effect in the database beyond plain stats.
+
+	// For custom items, the ID of the item whose effect this item has. 0 means the item's own.
+	EffectID int32
+
 	// Modified for each instance of the item.
 	Gems    []Gem
 	Enchant Enchant
@@ -65,6 +114,8 @@ func ItemFromProto(pData *proto.SimItem) Item {
 	return Item{
 		ID:               pData.Id,
 		Name:             pData.Name,
+		Ilvl:             pData.Ilvl,
+		Quality:          pData.Quality,
 		Type:             pData.Type,
 		ArmorType:        pData.ArmorType,
 		WeaponType:       pData.WeaponType,
@@ -77,26 +128,43 @@ func ItemFromProto(pData *proto.SimItem) Item {
 		GemSockets:       pData.GemSockets,
 		SocketBonus:      stats.FromFloatArray(pData.SocketBonus),
 		SetName:          pData.SetName,
+		HasSpecialEffect: pData.HasSpecialEffect,
+		EffectID:         pData.EffectId,
 	}
 }
 
 func (item *Item) ToItemSpecProto() *proto.ItemSpec {
-	return &proto.ItemSpec{
+	itemSpec := &proto.ItemSpec{
 		Id:      item.ID,
 		Enchant: item.Enchant.EffectID,
 		Gems:    MapSlice(item.Gems, func(gem Gem) int32 { return gem.ID }),
 	}
+	if dbItem, ok := ItemsByID[item.ID]; ok && dbItem.Ilvl != item.Ilvl {
+		itemSpec.Ilvl = item.Ilvl
+	}
+	return itemSpec
+}
+
+// The ID of the item effect which applies to this item.
+func (item *Item) ItemEffectID() int32 {
+	if item.EffectID != 0 {
+		return item.EffectID
+	}
+	return item.ID
 }
 
 type Enchant struct {
 	EffectID int32 // Used by UI to apply effect to tooltip
 	Stats    stats.Stats
+
+	HasSpecialEffect bool
 }
 
 func EnchantFromProto(pData *proto.SimEnchant) Enchant {
 	return Enchant{
-		EffectID: pData.EffectId,
-		Stats:    stats.FromFloatArray(pData.Stats),
+		EffectID:         pData.EffectId,
+		Stats:            stats.FromFloatArray(pData.Stats),
+		HasSpecialEffect: pData.HasSpecialEffect,
 	}
 }
 
@@ -105,14 +173,17 @@ type Gem struct {
 	Name  string
 	Stats stats.Stats
 	Color proto.GemColor
+
+	HasSpecialEffect bool
 }
 
 func GemFromProto(pData *proto.SimGem) Gem {
 	return Gem{
-		ID:    pData.Id,
-		Name:  pData.Name,
-		Stats: stats.FromFloatArray(pData.Stats),
-		Color: pData.Color,
+		ID:               pData.Id,
+		Name:             pData.Name,
+		Stats:            stats.FromFloatArray(pData.Stats),
+		Color:            pData.Color,
+		HasSpecialEffect: pData.HasSpecialEffect,
 	}
 }
 
@@ -120,6 +191,7 @@ type ItemSpec struct {
 	ID      int32
 	Enchant int32
 	Gems    []int32
+	Ilvl    int32 // If set, the item is scaled to this item level.
 }
 
 type Equipment [proto.ItemSlot_ItemSlotRanged + 1]Item
@@ -214,6 +286,7 @@ func ProtoToEquipmentSpec(es *proto.EquipmentSpec) EquipmentSpec {
 			ID:      item.Id,
 			Enchant: item.Enchant,
 			Gems:    item.Gems,
+			Ilvl:    item.Ilvl,
 		}
 	}
 	return coreEquip
@@ -227,6 +300,10 @@ func NewItem(itemSpec ItemSpec) Item {
 		panic(fmt.Sprintf("No item with id: %d", itemSpec.ID))
 	}
 
+	if itemSpec.Ilvl != 0 && itemSpec.Ilvl != item.Ilvl {
+		item = item.ScaledToIlvl(itemSpec.Ilvl)
+	}
+
 	if itemSpec.Enchant != 0 {
 		if enchant, ok := EnchantsByEffectID[itemSpec.Enchant]; ok {
 			item.Enchant = enchant
@@ -369,7 +446,8 @@ var itemTypeToSlotsMap = map[proto.ItemType][]proto.ItemSlot{
 	// ItemType_ItemTypeWeapon is excluded intentionally - the slot cannot be decided based on type alone for weapons.
 }
 
-func eligibleSlotsForItem(item Item) []proto.ItemSlot {
+// Returns the slots an item can be equipped in, in order of preference.
+func EligibleSlotsForItem(item Item) []proto.ItemSlot {
 	if slots, ok := itemTypeToSlotsMap[item.Type]; ok {
 		return slots
 	}
@@ -388,6 +466,131 @@ func eligibleSlotsForItem(item Item) []proto.ItemSlot {
 	return nil
 }
 
+// See classToMaxArmorType in proto_utils/utils.ts.
+var classToMaxArmorType = map[proto.Class]proto.ArmorType{
+	proto.Class_ClassDruid:       proto.ArmorType_ArmorTypeLeather,
+	proto.Class_ClassHunter:      proto.ArmorType_ArmorTypeMail,
+	proto.Class_ClassMage:        proto.ArmorType_ArmorTypeCloth,
+	proto.Class_ClassPaladin:     proto.ArmorType_ArmorTypePlate,
+	proto.Class_ClassPriest:      proto.ArmorType_ArmorTypeCloth,
+	proto.Class_ClassRogue:       proto.ArmorType_ArmorTypeLeather,
+	proto.Class_ClassShaman:      proto.ArmorType_ArmorTypeMail,
+	proto.Class_ClassWarlock:     proto.ArmorType_ArmorTypeCloth,
+	proto.Class_ClassWarrior:     proto.ArmorType_ArmorTypePlate,
+	proto.Class_ClassDeathknight: proto.ArmorType_ArmorTypePlate,
+}
+
+// See classToEligibleRangedWeaponTypes in proto_utils/utils.ts.
+var classToEligibleRangedWeaponTypes = map[proto.Class][]proto.RangedWeaponType{
+	proto.Class_ClassDruid:       {proto.RangedWeaponType_RangedWeaponTypeIdol},
+	proto.Class_ClassHunter:      {proto.RangedWeaponType_RangedWeaponTypeBow, proto.RangedWeaponType_RangedWeaponTypeCrossbow, proto.RangedWeaponType_RangedWeaponTypeGun},
+	proto.Class_ClassMage:        {proto.RangedWeaponType_RangedWeaponTypeWand},
+	proto.Class_ClassPaladin:     {proto.RangedWeaponType_RangedWeaponTypeLibram},
+	proto.Class_ClassPriest:      {proto.RangedWeaponType_RangedWeaponTypeWand},
+	proto.Class_ClassRogue:       {proto.RangedWeaponType_RangedWeaponTypeBow, proto.RangedWeaponType_RangedWeaponTypeCrossbow, proto.RangedWeaponType_RangedWeaponTypeGun, proto.RangedWeaponType_RangedWeaponTypeThrown},
+	proto.Class_ClassShaman:      {proto.RangedWeaponType_RangedWeaponTypeTotem},
+	proto.Class_ClassWarlock:     {proto.RangedWeaponType_RangedWeaponTypeWand},
+	proto.Class_ClassWarrior:     {proto.RangedWeaponType_RangedWeaponTypeBow, proto.RangedWeaponType_RangedWeaponTypeCrossbow, proto.RangedWeaponType_RangedWeaponTypeGun, proto.RangedWeaponType_RangedWeaponTypeThrown},
+	proto.Class_ClassDeathknight: {proto.RangedWeaponType_RangedWeaponTypeSigil},
+}
+
+// See classToEligibleWeaponTypes in proto_utils/utils.ts. Values are whether
+// two-handed weapons of that type can be used.
+var classToEligibleWeaponTypes = map[proto.Class]map[proto.WeaponType]bool{
+	proto.Class_ClassDruid: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+		proto.WeaponType_WeaponTypePolearm: true,
+	},
+	proto.Class_ClassHunter: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypePolearm: true,
+		proto.WeaponType_WeaponTypeSword:   true,
+		proto.WeaponType_WeaponTypeStaff:   true,
+	},
+	proto.Class_ClassMage: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+		proto.WeaponType_WeaponTypeSword:   false,
+	},
+	proto.Class_ClassPaladin: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypePolearm: true,
+		proto.WeaponType_WeaponTypeShield:  false,
+		proto.WeaponType_WeaponTypeSword:   true,
+	},
+	proto.Class_ClassPriest: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeMace:    false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+	},
+	proto.Class_ClassRogue: {
+		proto.WeaponType_WeaponTypeAxe:     false,
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeMace:    false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeSword:   false,
+	},
+	proto.Class_ClassShaman: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeShield:  false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+	},
+	proto.Class_ClassWarlock: {
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+		proto.WeaponType_WeaponTypeSword:   false,
+	},
+	proto.Class_ClassWarrior: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeDagger:  false,
+		proto.WeaponType_WeaponTypeFist:    false,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypeOffHand: false,
+		proto.WeaponType_WeaponTypePolearm: true,
+		proto.WeaponType_WeaponTypeShield:  false,
+		proto.WeaponType_WeaponTypeStaff:   true,
+		proto.WeaponType_WeaponTypeSword:   true,
+	},
+	proto.Class_ClassDeathknight: {
+		proto.WeaponType_WeaponTypeAxe:     true,
+		proto.WeaponType_WeaponTypeMace:    true,
+		proto.WeaponType_WeaponTypePolearm: true,
+		proto.WeaponType_WeaponTypeSword:   true,
+	},
+}
+
+// Whether class has the proficiency to equip item. See canEquipItem in proto_utils/utils.ts.
+func canEquipItem(item Item, class proto.Class) bool {
+	switch item.Type {
+	case proto.ItemType_ItemTypeFinger, proto.ItemType_ItemTypeTrinket, proto.ItemType_ItemTypeNeck:
+		return true
+	case proto.ItemType_ItemTypeWeapon:
+		canUseTwoHand, ok := classToEligibleWeaponTypes[class][item.WeaponType]
+		return ok && (item.HandType != proto.HandType_HandTypeTwoHand || canUseTwoHand)
+	case proto.ItemType_ItemTypeRanged:
+		return slices.Contains(classToEligibleRangedWeaponTypes[class], item.RangedWeaponType)
+	default:
+		return item.ArmorType <= classToMaxArmorType[class]
+	}
+}
+
 func ColorIntersects(g proto.GemColor, o proto.GemColor) bool {
 	if g == o {
 		return true
@@ -17,12 +17,16 @@ func init() {
 		Items:    make([]*proto.SimItem, len(db.Items)),
 		Enchants: make([]*proto.SimEnchant, len(db.Enchants)),
 		Gems:     make([]*proto.SimGem, len(db.Gems)),
+
+		Consumables: db.Consumables,
 	}
 
 	for i, item := range db.Items {
 		simDB.Items[i] = &proto.SimItem{
 			Id:               item.Id,
 			Name:             item.Name,
+			Ilvl:             item.Ilvl,
+			Quality:          item.Quality,
 			Type:             item.Type,
 			ArmorType:        item.ArmorType,
 			WeaponType:       item.WeaponType,
@@ -35,24 +39,28 @@ func init() {
 			WeaponDamageMax:  item.WeaponDamageMax,
 			WeaponSpeed:      item.WeaponSpeed,
 			SetName:          item.SetName,
+			HasSpecialEffect: item.HasSpecialEffect,
 		}
 	}
 
 	for i, enchant := range db.Enchants {
 		simDB.Enchants[i] = &proto.SimEnchant{
-			EffectId: enchant.EffectId,
-			Stats:    enchant.Stats,
+			EffectId:         enchant.EffectId,
+			Stats:            enchant.Stats,
+			HasSpecialEffect: enchant.HasSpecialEffect,
 		}
 	}
 
 	for i, gem := range db.Gems {
 		simDB.Gems[i] = &proto.SimGem{
-			Id:    gem.Id,
-			Name:  gem.Name,
-			Color: gem.Color,
-			Stats: gem.Stats,
+			Id:               gem.Id,
+			Name:             gem.Name,
+			Color:            gem.Color,
+			Stats:            gem.Stats,
+			HasSpecialEffect: gem.HasSpecialEffect,
 		}
 	}
 
 	addToDatabase(simDB)
+	addToLootDatabase(db)
 }
@@ -202,7 +202,10 @@ func (dot *Dot) TakeSnapshot(sim *Simulation, doRollover bool) {
 // the tick is simply an extra tick.
 func (dot *Dot) TickOnce(sim *Simulation) {
 	dot.lastTickTime = sim.CurrentTime
+	prevApplyingUnit := sim.applyingUnit
+	sim.applyingUnit = dot.Spell.Unit
 	dot.OnTick(sim, dot.Unit, dot)
+	sim.applyingUnit = prevApplyingUnit
 
 	if dot.isChanneled {
 		// Note: even if the clip delay is 0ms, need a WaitUntil so that APL is called after the channel aura fully fades.
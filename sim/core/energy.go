@@ -135,8 +135,8 @@ func (eb *energyBar) onEnergyGain(sim *Simulation, crossedThreshold bool) {
 		return
 	}
 
-	if !sim.Options.Interactive && crossedThreshold {
-		eb.unit.Rotation.DoNextAction(sim)
+	if !eb.unit.IsInteractive(sim) && crossedThreshold {
+		eb.unit.ReactToEvent(sim)
 	}
 }
 
@@ -42,15 +42,33 @@ func (unit *Unit) SetGCDTimer(sim *Simulation, gcdReadyAt time.Duration) {
 	}
 
 	unit.GCD.Set(gcdReadyAt)
+	unit.scheduleGCDAction(sim, gcdReadyAt)
+}
+
+// Like SetGCDTimer, but for a GCD or cast started by the rotation. The GCD is ready at
+// gcdReadyAt, but the next action is held back by the unit's latency profile.
+func (unit *Unit) setGCDTimerWithLatency(sim *Simulation, gcdReadyAt time.Duration) {
+	if unit.latency == nil {
+		unit.SetGCDTimer(sim, gcdReadyAt)
+		return
+	}
+	if unit.gcdAction == nil {
+		return
+	}
+
+	unit.GCD.Set(gcdReadyAt)
+	unit.scheduleGCDAction(sim, gcdReadyAt+unit.latency.queuedDelay(sim))
+}
 
+func (unit *Unit) scheduleGCDAction(sim *Simulation, actionAt time.Duration) {
 	if unit.gcdAction.consumed {
 		unit.gcdAction.cancelled = false
-		unit.gcdAction.NextActionAt = gcdReadyAt
+		unit.gcdAction.NextActionAt = actionAt
 	} else {
 		unit.gcdAction.Cancel(sim)
 		oldAction := unit.gcdAction.OnAction
 		unit.gcdAction = &PendingAction{
-			NextActionAt: gcdReadyAt,
+			NextActionAt: actionAt,
 			Priority:     ActionPriorityGCD,
 			OnAction:     oldAction,
 		}
@@ -12,6 +12,9 @@ type healthBar struct {
 
 	currentHealth float64
 
+	// Whether incoming damage is applied to this health bar.
+	modelled bool
+
 	DamageTakenHealthMetrics *ResourceMetrics
 }
 
@@ -26,6 +29,13 @@ func (unit *Unit) HasHealthBar() bool {
 	return unit.healthBar.unit != nil
 }
 
+// Whether incoming damage reduces this unit's health, either because it is tanking
+// or because raid damage intake is configured in its healing model. Health-dependent
+// effects which are skipped for DPS players can use this to enable themselves.
+func (unit *Unit) HealthIsModelled() bool {
+	return unit.healthBar.modelled
+}
+
 func (hb *healthBar) reset(_ *Simulation) {
 	if hb.unit == nil {
 		return
@@ -96,15 +106,20 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 			character.Unit.Metrics.isTanking = true
 		}
 	}
-	if !character.Unit.Metrics.isTanking {
+	if healingModel == nil {
 		return
 	}
 
-	if healingModel == nil {
+	if !character.Unit.Metrics.isTanking && healingModel.RaidDtps == 0 {
 		return
 	}
 
-	character.Unit.Metrics.tmiBin = healingModel.BurstWindow
+	character.healthBar.modelled = true
+	if character.Unit.Metrics.isTanking {
+		character.Unit.Metrics.tmiBin = healingModel.BurstWindow
+	} else {
+		character.applyRaidDamageIntake(healingModel.RaidDtps)
+	}
 
 	character.RegisterAura(Aura{
 		Label:    ChanceOfDeathAuraLabel,
@@ -143,6 +158,33 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 	}
 }
 
+const RaidDamageTickPeriod = time.Second * 2
+
+// Applies generic raid-wide boss damage to a player who is not tanking, so that
+// self-heals and leech effects have missing health to restore.
+func (character *Character) applyRaidDamageIntake(raidDtps float64) {
+	boss := character.Env.Encounter.TargetUnits[0]
+	raidDamageSpell := boss.GetOrRegisterSpell(SpellConfig{
+		ActionID:    ActionID{OtherID: proto.OtherAction_OtherActionRaidDamage},
+		SpellSchool: SpellSchoolShadow,
+		ProcMask:    ProcMaskEmpty,
+		Flags:       SpellFlagIgnoreResists | SpellFlagIgnoreAttackerModifiers,
+
+		DamageMultiplier: 1,
+	})
+
+	damagePerTick := raidDtps * RaidDamageTickPeriod.Seconds()
+
+	character.RegisterResetEffect(func(sim *Simulation) {
+		StartPeriodicAction(sim, PeriodicActionOptions{
+			Period: RaidDamageTickPeriod,
+			OnAction: func(sim *Simulation) {
+				raidDamageSpell.CalcAndDealDamage(sim, &character.Unit, damagePerTick, raidDamageSpell.OutcomeAlwaysHit)
+			},
+		})
+	})
+}
+
 func (character *Character) applyHealingModel(healingModel *proto.HealingModel) {
 	// Store variance parameters for healing cadence. Note that low rolls on
 	// cadence are special cased here so that the model is still well-behaved
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_EnhancementShaman{},
+		proto.Spec_SpecEnhancementShaman,
+		newFakeMeleeDps,
+		func(player *proto.Player, spec interface{}) {
+			player.Spec = spec.(*proto.Player_EnhancementShaman)
+		},
+	)
+}
+
+// A non-tanking player with a single melee attack that always crits.
+type fakeMeleeDps struct {
+	Character
+	critStrike *Spell
+}
+
+func (fmd *fakeMeleeDps) GetCharacter() *Character {
+	return &fmd.Character
+}
+
+func (fmd *fakeMeleeDps) Initialize() {
+	fmd.critStrike = fmd.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 44},
+		SpellSchool: SpellSchoolPhysical,
+		ProcMask:    ProcMaskMeleeMHSpecial,
+		Flags:       SpellFlagIgnoreResists,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   2,
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.CalcAndDealDamage(sim, target, 100, func(_ *Simulation, result *SpellResult, _ *AttackTable) {
+				result.Outcome = OutcomeCrit
+			})
+		},
+	})
+}
+
+func (fmd *fakeMeleeDps) ApplyTalents()            {}
+func (fmd *fakeMeleeDps) Reset(_ *Simulation)      {}
+func (fmd *fakeMeleeDps) OnGCDReady(_ *Simulation) {}
+
+func newFakeMeleeDps(char *Character, _ *proto.Player) Agent {
+	return &fakeMeleeDps{
+		Character: *char,
+	}
+}
+
+func setupFakeMeleeDps(healingModel *proto.HealingModel) (*Simulation, *fakeMeleeDps) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{{
+						Name:         "Dps",
+						Class:        proto.Class_ClassShaman,
+						Race:         proto.Race_RaceTroll,
+						Consumes:     &proto.Consumes{},
+						Buffs:        &proto.IndividualBuffs{},
+						Spec:         &proto.Player_EnhancementShaman{},
+						Equipment:    &proto.EquipmentSpec{},
+						HealingModel: healingModel,
+					}},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+			Buffs: &proto.RaidBuffs{
+				LeaderOfThePack: proto.TristateEffect_TristateEffectImproved,
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 83}},
+			Duration: 180,
+		},
+	})
+	sim.Reset()
+
+	return sim, sim.Raid.Parties[0].Players[0].(*fakeMeleeDps)
+}
+
+func TestRaidDamageIntake(t *testing.T) {
+	sim, dps := setupFakeMeleeDps(&proto.HealingModel{RaidDtps: 500})
+
+	if !dps.HealthIsModelled() {
+		t.Fatalf("Expected health to be modelled for a non-tank with raid damage intake")
+	}
+	if dps.GetAura("Improved Leader of the Pack") == nil {
+		t.Fatalf("Expected Improved Leader of the Pack to be applied when health is modelled")
+	}
+
+	for sim.CurrentTime < RaidDamageTickPeriod*3 {
+		sim.Step()
+	}
+
+	// Three ticks of raid damage, each covering a full tick period.
+	expectedHealth := dps.MaxHealth() - 3*500*RaidDamageTickPeriod.Seconds()
+	if !WithinToleranceFloat64(expectedHealth, dps.CurrentHealth(), 0.001) {
+		t.Fatalf("Expected %0.3f health after 3 raid damage ticks, got %0.3f", expectedHealth, dps.CurrentHealth())
+	}
+
+	// A melee crit triggers the Improved Leader of the Pack self-heal once per 6s.
+	dps.critStrike.Cast(sim, dps.CurrentTarget)
+	dps.critStrike.Cast(sim, dps.CurrentTarget)
+	expectedHealth += dps.MaxHealth() * 0.04
+	if !WithinToleranceFloat64(expectedHealth, dps.CurrentHealth(), 0.001) {
+		t.Fatalf("Expected %0.3f health after the Improved Leader of the Pack heal, got %0.3f", expectedHealth, dps.CurrentHealth())
+	}
+}
+
+func TestNoRaidDamageIntake(t *testing.T) {
+	_, dps := setupFakeMeleeDps(&proto.HealingModel{})
+
+	if dps.HealthIsModelled() {
+		t.Fatalf("Expected health not to be modelled without raid damage intake")
+	}
+	if dps.GetAura("Improved Leader of the Pack") != nil {
+		t.Fatalf("Expected Improved Leader of the Pack to be skipped when health is not modelled")
+	}
+}
@@ -0,0 +1,170 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// The JSON written by the WowSimsExporter addon.
+type addonExport struct {
+	Name        string `json:"name"`
+	Class       string `json:"class"`
+	Race        string `json:"race"`
+	Talents     string `json:"talents"`
+	Professions []struct {
+		Name  string `json:"name"`
+		Level int32  `json:"level"`
+	} `json:"professions"`
+	Glyphs struct {
+		Major []json.RawMessage `json:"major"`
+		Minor []json.RawMessage `json:"minor"`
+	} `json:"glyphs"`
+	Gear struct {
+		Items []*struct {
+			ID      int32   `json:"id"`
+			Enchant int32   `json:"enchant"`
+			Gems    []int32 `json:"gems"`
+		} `json:"items"`
+	} `json:"gear"`
+}
+
+// Older versions of the addon export glyphs by English name only, newer ones
+// also include the glyph spell ID.
+type addonGlyph struct {
+	Name    string `json:"name"`
+	SpellID int32  `json:"spellID"`
+}
+
+// Imports the JSON export of the WowSimsExporter addon.
+func (imp *Importer) AddonJSON(data []byte) (*Import, error) {
+	export := &addonExport{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, fmt.Errorf("invalid addon export: %w", err)
+	}
+
+	player := &proto.Player{
+		Name:          export.Name,
+		TalentsString: export.Talents,
+	}
+	result := &Import{Player: player}
+
+	var ok bool
+	if player.Class, ok = parseClass(export.Class); !ok {
+		return nil, fmt.Errorf("unknown class %q", export.Class)
+	}
+	if player.Race, ok = parseRace(export.Race); !ok {
+		return nil, fmt.Errorf("unknown race %q", export.Race)
+	}
+
+	for i, profession := range export.Professions {
+		value, ok := parseProfession(profession.Name)
+		if !ok {
+			return nil, fmt.Errorf("unknown profession %q", profession.Name)
+		}
+		switch i {
+		case 0:
+			player.Profession1 = value
+		case 1:
+			player.Profession2 = value
+		}
+	}
+
+	majors := imp.glyphs(result, export.Glyphs.Major)
+	minors := imp.glyphs(result, export.Glyphs.Minor)
+	player.Glyphs = &proto.Glyphs{
+		Major1: majors[0],
+		Major2: majors[1],
+		Major3: majors[2],
+		Minor1: minors[0],
+		Minor2: minors[1],
+		Minor3: minors[2],
+	}
+
+	// Items are exported in slot order, with null for empty slots.
+	var specs []itemSpec
+	for i, item := range export.Gear.Items {
+		if item == nil {
+			continue
+		}
+		if i >= len(proto.ItemSlot_name) {
+			result.unknown("item %d: no slot %d", item.ID, i)
+			continue
+		}
+		specs = append(specs, itemSpec{id: item.ID, enchant: item.Enchant, gems: item.Gems, slot: proto.ItemSlot(i)})
+	}
+	imp.equip(result, specs)
+	return result, nil
+}
+
+// Returns the item IDs of up to 3 glyphs.
+func (imp *Importer) glyphs(result *Import, glyphsJSON []json.RawMessage) [3]int32 {
+	var ids [3]int32
+	for i, glyphJSON := range glyphsJSON {
+		if i >= len(ids) || string(glyphJSON) == "null" {
+			continue
+		}
+
+		glyph := addonGlyph{}
+		if err := json.Unmarshal(glyphJSON, &glyph.Name); err != nil {
+			if err := json.Unmarshal(glyphJSON, &glyph); err != nil {
+				result.unknown("glyph %s", glyphJSON)
+				continue
+			}
+		}
+
+		if itemID, ok := imp.glyphsBySpell[glyph.SpellID]; ok && glyph.SpellID != 0 {
+			ids[i] = itemID
+		} else if itemID, ok := imp.glyphsByName[strings.ToLower(glyph.Name)]; ok {
+			ids[i] = itemID
+		} else if glyph.Name != "" || glyph.SpellID != 0 {
+			result.unknown("glyph %q (spell %d)", glyph.Name, glyph.SpellID)
+		}
+	}
+	return ids
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "", "'", "").Replace(name))
+}
+
+func parseClass(name string) (proto.Class, bool) {
+	normalized := normalizeName(name)
+	for value, enumName := range proto.Class_name {
+		if value != 0 && normalizeName(strings.TrimPrefix(enumName, "Class")) == normalized {
+			return proto.Class(value), true
+		}
+	}
+	return proto.Class_ClassUnknown, false
+}
+
+// Names the game uses for races which differ from ours.
+var raceAliases = map[string]proto.Race{
+	"bloodelf": proto.Race_RaceSindorei,
+	"scourge":  proto.Race_RaceUndead,
+}
+
+func parseRace(name string) (proto.Race, bool) {
+	normalized := normalizeName(name)
+	if race, ok := raceAliases[normalized]; ok {
+		return race, true
+	}
+	for value, enumName := range proto.Race_name {
+		if value != 0 && normalizeName(strings.TrimPrefix(enumName, "Race")) == normalized {
+			return proto.Race(value), true
+		}
+	}
+	return proto.Race_RaceUnknown, false
+}
+
+func parseProfession(name string) (proto.Profession, bool) {
+	normalized := normalizeName(name)
+	for value, enumName := range proto.Profession_name {
+		if value != 0 && normalizeName(enumName) == normalized {
+			return proto.Profession(value), true
+		}
+	}
+	return proto.Profession_ProfessionUnknown, false
+}
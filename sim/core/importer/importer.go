@@ -0,0 +1,184 @@
+// Package importer builds a proto.Player from characters exported in-game,
+// either as the JSON written by the WowSimsExporter addon or as a list of
+// item links such as
+//
+//	|cffa335ee|Hitem:40395:3834:40113:0:0:0:0:0:80|h[Torch of Holy Fire]|h|r
+//
+// Everything is checked against a UIDatabase. Items, enchants, gems and glyphs
+// it doesn't know are left out and reported, rather than failing the import.
+package importer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type Importer struct {
+	items         map[int32]*proto.UIItem
+	enchants      map[int32]bool
+	gems          map[int32]bool
+	glyphsBySpell map[int32]int32
+	glyphsByName  map[string]int32
+}
+
+func New(db *proto.UIDatabase) *Importer {
+	imp := &Importer{
+		items:         make(map[int32]*proto.UIItem, len(db.Items)),
+		enchants:      make(map[int32]bool, len(db.Enchants)),
+		gems:          make(map[int32]bool, len(db.Gems)),
+		glyphsBySpell: make(map[int32]int32, len(db.GlyphIds)),
+		glyphsByName:  make(map[string]int32),
+	}
+	for _, item := range db.Items {
+		imp.items[item.Id] = item
+	}
+	for _, enchant := range db.Enchants {
+		imp.enchants[enchant.EffectId] = true
+	}
+	for _, gem := range db.Gems {
+		imp.gems[gem.Id] = true
+	}
+
+	glyphItems := make(map[int32]bool, len(db.GlyphIds))
+	for _, glyph := range db.GlyphIds {
+		imp.glyphsBySpell[glyph.SpellId] = glyph.ItemId
+		glyphItems[glyph.ItemId] = true
+	}
+	for _, icon := range db.ItemIcons {
+		if glyphItems[icon.Id] {
+			imp.glyphsByName[strings.ToLower(icon.Name)] = icon.Id
+		}
+	}
+	return imp
+}
+
+// The result of an import. Player only has the fields the export contained.
+type Import struct {
+	Player *proto.Player
+
+	// Things from the export that aren't in the database, and were left out.
+	Unknown []string
+}
+
+func (result *Import) unknown(format string, args ...interface{}) {
+	result.Unknown = append(result.Unknown, fmt.Sprintf(format, args...))
+}
+
+type itemSpec struct {
+	id      int32
+	enchant int32
+	gems    []int32
+
+	// Slot the item was exported from, or -1 if the export doesn't say.
+	slot proto.ItemSlot
+}
+
+// Assigns each item to the slot it was exported from, or else to the first free
+// slot it fits in, in the order they were exported, like the UI does.
+func (imp *Importer) equip(result *Import, specs []itemSpec) {
+	equipment := &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, len(proto.ItemSlot_name))}
+	for i := range equipment.Items {
+		equipment.Items[i] = &proto.ItemSpec{}
+	}
+
+	for _, spec := range specs {
+		if spec.id == 0 {
+			continue
+		}
+		uiItem, ok := imp.items[spec.id]
+		if !ok {
+			result.unknown("item %d", spec.id)
+			continue
+		}
+
+		slots := imp.eligibleSlots(uiItem, equipment)
+		slot := spec.slot
+		if slot < 0 {
+			slotIdx := slices.IndexFunc(slots, func(slot proto.ItemSlot) bool { return equipment.Items[slot].Id == 0 })
+			if slotIdx == -1 {
+				result.unknown("item %d (%s): no free slot", spec.id, uiItem.Name)
+				continue
+			}
+			slot = slots[slotIdx]
+		} else if !slices.Contains(slots, slot) {
+			result.unknown("item %d (%s): doesn't fit in slot %d", spec.id, uiItem.Name, slot)
+			continue
+		}
+
+		itemSpec := &proto.ItemSpec{Id: spec.id}
+		if spec.enchant != 0 {
+			if imp.enchants[spec.enchant] {
+				itemSpec.Enchant = spec.enchant
+			} else {
+				result.unknown("enchant %d on %s", spec.enchant, uiItem.Name)
+			}
+		}
+		for _, gemID := range spec.gems {
+			if gemID != 0 && !imp.gems[gemID] {
+				result.unknown("gem %d in %s", gemID, uiItem.Name)
+				gemID = 0
+			}
+			itemSpec.Gems = append(itemSpec.Gems, gemID)
+		}
+		// Empty sockets at the end are left off, like the UI exports them.
+		for len(itemSpec.Gems) > 0 && itemSpec.Gems[len(itemSpec.Gems)-1] == 0 {
+			itemSpec.Gems = itemSpec.Gems[:len(itemSpec.Gems)-1]
+		}
+
+		equipment.Items[slot] = itemSpec
+	}
+	result.Player.Equipment = equipment
+}
+
+// Like core.EligibleSlotsForItem, but a two-hander also fits in the off hand when the main
+// hand already has one, as for warriors with Titan's Grip.
+func (imp *Importer) eligibleSlots(uiItem *proto.UIItem, equipment *proto.EquipmentSpec) []proto.ItemSlot {
+	slots := core.EligibleSlotsForItem(core.ItemFromProto(&proto.SimItem{Id: uiItem.Id, Type: uiItem.Type, HandType: uiItem.HandType}))
+	if !isTwoHander(uiItem) {
+		return slots
+	}
+	if mainHand, ok := imp.items[equipment.Items[proto.ItemSlot_ItemSlotMainHand].Id]; ok && isTwoHander(mainHand) {
+		return append(slices.Clone(slots), proto.ItemSlot_ItemSlotOffHand)
+	}
+	return slots
+}
+
+func isTwoHander(uiItem *proto.UIItem) bool {
+	return uiItem.Type == proto.ItemType_ItemTypeWeapon && uiItem.HandType == proto.HandType_HandTypeTwoHand
+}
+
+// Copies everything the import contains onto player, e.g. the first player of
+// a template RaidSimRequest, keeping the rest of its settings.
+func (result *Import) ApplyTo(player *proto.Player) error {
+	imported := result.Player
+	if imported.Class != proto.Class_ClassUnknown {
+		if player.Class != proto.Class_ClassUnknown && player.Class != imported.Class {
+			return fmt.Errorf("imported character is a %s, but the template player is a %s", imported.Class, player.Class)
+		}
+		player.Class = imported.Class
+	}
+	if imported.Race != proto.Race_RaceUnknown {
+		player.Race = imported.Race
+	}
+	if imported.Name != "" {
+		player.Name = imported.Name
+	}
+	if imported.TalentsString != "" {
+		player.TalentsString = imported.TalentsString
+	}
+	if imported.Glyphs != nil {
+		player.Glyphs = imported.Glyphs
+	}
+	if imported.Profession1 != proto.Profession_ProfessionUnknown || imported.Profession2 != proto.Profession_ProfessionUnknown {
+		player.Profession1 = imported.Profession1
+		player.Profession2 = imported.Profession2
+	}
+	if imported.Equipment != nil {
+		player.Equipment = imported.Equipment
+	}
+	return nil
+}
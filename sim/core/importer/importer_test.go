@@ -0,0 +1,152 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+var testDB = &proto.UIDatabase{
+	Items: []*proto.UIItem{
+		{Id: 100, Name: "Helm", Type: proto.ItemType_ItemTypeHead},
+		{Id: 101, Name: "Ring", Type: proto.ItemType_ItemTypeFinger},
+		{Id: 102, Name: "Other Ring", Type: proto.ItemType_ItemTypeFinger},
+		{Id: 103, Name: "Sword", Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeOneHand},
+		{Id: 104, Name: "Dagger", Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeOneHand},
+		{Id: 106, Name: "Greataxe", Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeTwoHand},
+		{Id: 107, Name: "Other Greataxe", Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeTwoHand},
+	},
+	Enchants:  []*proto.UIEnchant{{EffectId: 3834}},
+	Gems:      []*proto.UIGem{{Id: 40113}},
+	GlyphIds:  []*proto.GlyphID{{ItemId: 41517, SpellId: 55439}, {ItemId: 41532, SpellId: 55453}},
+	ItemIcons: []*proto.IconData{{Id: 41532, Name: "Glyph of Lava"}},
+}
+
+func TestItemLinks(t *testing.T) {
+	result, err := New(testDB).ItemLinks(`
+/dump GetInventoryItemLink("player", 1)
+[1]="|cffa335ee|Hitem:100:3834:40113:0:0:0:0:0:80|h[Helm]|h|r"
+|cffa335ee|Hitem:101::0:0:0:0:0:0:80|h[Ring]|h|r |cffa335ee|Hitem:102:1:0:0:0:0:0:0:80|h[Other Ring]|h|r
+|cffa335ee|Hitem:103:0:0:0:0:0:0:0:80|h[Sword]|h|r |cffa335ee|Hitem:104:0:99999:0:0:0:0:0:80|h[Dagger]|h|r
+|cffa335ee|Hitem:105:0:0:0:0:0:0:0:80|h[Mystery]|h|r`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := result.Player.Equipment.Items
+	expected := map[proto.ItemSlot]*proto.ItemSpec{
+		proto.ItemSlot_ItemSlotHead:     {Id: 100, Enchant: 3834, Gems: []int32{40113}},
+		proto.ItemSlot_ItemSlotFinger1:  {Id: 101},
+		proto.ItemSlot_ItemSlotFinger2:  {Id: 102},
+		proto.ItemSlot_ItemSlotMainHand: {Id: 103},
+		proto.ItemSlot_ItemSlotOffHand:  {Id: 104},
+	}
+	for slot, spec := range expected {
+		if !goproto.Equal(items[slot], spec) {
+			t.Errorf("Expected %v in %s, got %v", spec, slot, items[slot])
+		}
+	}
+
+	expectedUnknown := []string{"enchant 1 on Other Ring", "gem 99999 in Dagger", "item 105"}
+	if !slices.Equal(result.Unknown, expectedUnknown) {
+		t.Errorf("Expected unknown %q, got %q", expectedUnknown, result.Unknown)
+	}
+}
+
+func TestAddonJSON(t *testing.T) {
+	result, err := New(testDB).AddonJSON([]byte(`{
+		"name": "Tester",
+		"class": "shaman",
+		"race": "Draenei",
+		"talents": "0532001523212351322301351-005052031",
+		"professions": [{"name": "Engineering", "level": 450}, {"name": "Jewelcrafting", "level": 450}],
+		"glyphs": {
+			"major": [{"name": "Glyph of Flame Shock", "spellID": 55439}, "Glyph of Lava", {"name": "Glyph of Nothing", "spellID": 1}],
+			"minor": ["", null]
+		},
+		"gear": {"items": [{"id": 100, "enchant": 3834, "gems": [40113, null]}, null, null, null, null, null, null, null, null, null, {"id": 101}]}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	player := result.Player
+	if player.Name != "Tester" || player.Class != proto.Class_ClassShaman || player.Race != proto.Race_RaceDraenei ||
+		player.Profession1 != proto.Profession_Engineering || player.Profession2 != proto.Profession_Jewelcrafting ||
+		player.TalentsString != "0532001523212351322301351-005052031" {
+		t.Fatalf("Unexpected player: %v", player)
+	}
+	if player.Glyphs.Major1 != 41517 || player.Glyphs.Major2 != 41532 || player.Glyphs.Major3 != 0 {
+		t.Errorf("Unexpected glyphs: %v", player.Glyphs)
+	}
+	if !goproto.Equal(player.Equipment.Items[proto.ItemSlot_ItemSlotHead], &proto.ItemSpec{Id: 100, Enchant: 3834, Gems: []int32{40113}}) ||
+		player.Equipment.Items[proto.ItemSlot_ItemSlotFinger1].Id != 101 {
+		t.Errorf("Unexpected equipment: %v", player.Equipment)
+	}
+	if len(result.Unknown) != 1 {
+		t.Errorf("Expected only the unknown glyph, got %q", result.Unknown)
+	}
+}
+
+func TestAddonJSONWeaponSlots(t *testing.T) {
+	// The export has an empty main hand, so the dagger stays in the off hand.
+	offHandOnly := `{"class": "rogue", "race": "human", "gear": {"items": [` + strings.Repeat("null, ", 15) + `{"id": 104}]}}`
+	result, err := New(testDB).AddonJSON([]byte(offHandOnly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := result.Player.Equipment.Items
+	if items[proto.ItemSlot_ItemSlotMainHand].Id != 0 || items[proto.ItemSlot_ItemSlotOffHand].Id != 104 {
+		t.Errorf("Expected only the dagger in the off hand, got %v", result.Player.Equipment)
+	}
+
+	titansGrip := `{"class": "warrior", "race": "orc", "gear": {"items": [` + strings.Repeat("null, ", 14) + `{"id": 106}, {"id": 107}]}}`
+	result, err = New(testDB).AddonJSON([]byte(titansGrip))
+	if err != nil {
+		t.Fatal(err)
+	}
+	items = result.Player.Equipment.Items
+	if items[proto.ItemSlot_ItemSlotMainHand].Id != 106 || items[proto.ItemSlot_ItemSlotOffHand].Id != 107 || len(result.Unknown) != 0 {
+		t.Errorf("Expected a two-hander in each hand, got %v (unknown %q)", result.Player.Equipment, result.Unknown)
+	}
+
+	// Items which don't fit the slot they were exported from are left out.
+	wrongSlot := `{"class": "warrior", "race": "orc", "gear": {"items": [{"id": 101}]}}`
+	result, err = New(testDB).AddonJSON([]byte(wrongSlot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Player.Equipment.Items[proto.ItemSlot_ItemSlotHead].Id != 0 || len(result.Unknown) != 1 {
+		t.Errorf("Expected the ring in the head slot to be left out, got %v (unknown %q)", result.Player.Equipment, result.Unknown)
+	}
+}
+
+func TestItemLinksTitansGrip(t *testing.T) {
+	result, err := New(testDB).ItemLinks(`|cffa335ee|Hitem:106:0:0:0:0:0:0:0:80|h[Greataxe]|h|r |cffa335ee|Hitem:107:0:0:0:0:0:0:0:80|h[Other Greataxe]|h|r`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := result.Player.Equipment.Items
+	if items[proto.ItemSlot_ItemSlotMainHand].Id != 106 || items[proto.ItemSlot_ItemSlotOffHand].Id != 107 {
+		t.Errorf("Expected a two-hander in each hand, got %v (unknown %q)", result.Player.Equipment, result.Unknown)
+	}
+}
+
+func TestApplyTo(t *testing.T) {
+	result := &Import{Player: &proto.Player{Class: proto.Class_ClassShaman, TalentsString: "123"}}
+
+	player := &proto.Player{Class: proto.Class_ClassShaman, TalentsString: "456", Race: proto.Race_RaceOrc}
+	if err := result.ApplyTo(player); err != nil {
+		t.Fatal(err)
+	}
+	if player.TalentsString != "123" || player.Race != proto.Race_RaceOrc {
+		t.Errorf("Unexpected player after import: %v", player)
+	}
+
+	if err := result.ApplyTo(&proto.Player{Class: proto.Class_ClassMage}); err == nil {
+		t.Error("Expected an error for a class mismatch")
+	}
+}
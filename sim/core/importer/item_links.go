@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Matches the item string of a link: item:itemID:enchantID:gem1:gem2:gem3:gem4:...
+var itemLinkRegex = regexp.MustCompile(`item:(-?\d+(?::-?\d*)*)`)
+
+// Imports gear from text containing item links, e.g. a /dump of
+// GetInventoryItemLink for each slot. Anything else in the text is ignored.
+func (imp *Importer) ItemLinks(text string) (*Import, error) {
+	var specs []itemSpec
+	for _, match := range itemLinkRegex.FindAllStringSubmatch(text, -1) {
+		spec, err := parseItemString(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid item link %q: %w", match[0], err)
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no item links found")
+	}
+
+	result := &Import{Player: &proto.Player{}}
+	imp.equip(result, specs)
+	return result, nil
+}
+
+func parseItemString(itemString string) (itemSpec, error) {
+	fields := strings.Split(itemString, ":")
+	values := make([]int32, 6)
+	for i := 0; i < len(fields) && i < len(values); i++ {
+		if fields[i] == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(fields[i], 10, 32)
+		if err != nil {
+			return itemSpec{}, err
+		}
+		values[i] = int32(value)
+	}
+	return itemSpec{
+		id:      values[0],
+		enchant: values[1],
+		gems:    values[2:6],
+		slot:    -1,
+	}, nil
+}
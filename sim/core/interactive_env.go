@@ -0,0 +1,427 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+const defaultEnvWait = time.Millisecond * 100
+
+// Totals for the controlled player and its pets over the current episode.
+type EnvTotals struct {
+	Damage  float64
+	Healing float64
+	Threat  float64
+}
+
+// Computes the reward for a step from the totals before and after it.
+type EnvRewardFunc func(env *InteractiveEnv, before EnvTotals, after EnvTotals) float64
+
+var envRewardFuncs = map[proto.EnvReward]EnvRewardFunc{
+	proto.EnvReward_EnvRewardDamage: func(_ *InteractiveEnv, before EnvTotals, after EnvTotals) float64 {
+		return after.Damage - before.Damage
+	},
+	proto.EnvReward_EnvRewardHealing: func(_ *InteractiveEnv, before EnvTotals, after EnvTotals) float64 {
+		return after.Healing - before.Healing
+	},
+	proto.EnvReward_EnvRewardThreat: func(_ *InteractiveEnv, before EnvTotals, after EnvTotals) float64 {
+		return after.Threat - before.Threat
+	},
+}
+
+// InteractiveEnv is a step-based environment in which an external agent chooses the
+// actions of one player, while the rest of the raid runs its rotations as usual.
+//
+// The agent is consulted whenever the player's GCD is ready. Casting an off-GCD spell
+// returns straight away so more actions can be taken at the same time; anything else
+// advances the sim to the next decision point.
+type InteractiveEnv struct {
+	Sim       *Simulation
+	Character *Character
+
+	// Spells the agent may cast. Step actions index into this list.
+	Actions []*Spell
+
+	// Computes the reward for each step. Can be replaced to shape rewards.
+	Reward EnvRewardFunc
+
+	auraLabels       []string
+	auras            []*Aura
+	targetAuraLabels []string
+
+	totals  EnvTotals
+	started bool
+	done    bool
+
+	// Serializes calls coming through the API.
+	mu sync.Mutex
+}
+
+func NewInteractiveEnv(request *proto.EnvResetRequest) (*InteractiveEnv, error) {
+	settings := request.GetSettings()
+	if settings.GetRaid() == nil {
+		return nil, errors.New("env: missing settings")
+	}
+	reward, ok := envRewardFuncs[request.Reward]
+	if !ok {
+		return nil, fmt.Errorf("env: unknown reward %s", request.Reward)
+	}
+	if err := validateRaidConsumes(settings.Raid); err != nil {
+		return nil, fmt.Errorf("env: %w", err)
+	}
+
+	simOptions := &proto.SimOptions{}
+	if settings.SimOptions != nil {
+		simOptions = goproto.Clone(settings.SimOptions).(*proto.SimOptions)
+	}
+	simOptions.Iterations = 1
+	simOptions.Interactive = false
+	simOptions.Debug = false
+	simOptions.DebugFirstIteration = false
+	encounter := settings.Encounter
+	if encounter == nil {
+		encounter = &proto.Encounter{}
+	}
+
+	sim := NewSim(&proto.RaidSimRequest{
+		Raid:       settings.Raid,
+		Encounter:  encounter,
+		SimOptions: simOptions,
+	})
+
+	var character *Character
+	for _, party := range sim.Raid.Parties {
+		for _, player := range party.Players {
+			if player.GetCharacter().Index == request.RaidIndex {
+				character = player.GetCharacter()
+			}
+		}
+	}
+	if character == nil {
+		return nil, fmt.Errorf("env: no player at raid index %d", request.RaidIndex)
+	}
+	character.interactive = true
+
+	env := &InteractiveEnv{
+		Sim:              sim,
+		Character:        character,
+		Reward:           reward,
+		auraLabels:       request.Auras,
+		targetAuraLabels: request.TargetAuras,
+	}
+	for _, spell := range character.Spellbook {
+		if spell.Flags.Matches(SpellFlagAPL) {
+			env.Actions = append(env.Actions, spell)
+		}
+	}
+	for _, label := range request.Auras {
+		env.auras = append(env.auras, character.GetAura(label))
+	}
+	return env, nil
+}
+
+func (env *InteractiveEnv) Spec() *proto.EnvSpec {
+	spec := &proto.EnvSpec{
+		Auras:       env.auraLabels,
+		TargetAuras: env.targetAuraLabels,
+	}
+	for _, spell := range env.Actions {
+		spec.Actions = append(spec.Actions, spell.ActionID.ToProto())
+	}
+	return spec
+}
+
+// Starts a new episode. Episodes with the same seed are identical given the same actions.
+func (env *InteractiveEnv) Reset(seed int64) *proto.EnvObservation {
+	sim := env.Sim
+	if env.started && !env.done {
+		// Expire everything left over from the unfinished episode.
+		sim.Cleanup()
+	}
+	env.started = true
+
+	sim.Options.RandomSeed = seed
+	sim.reseedRands(0)
+	sim.reset()
+	sim.PrePull()
+
+	sim.NeedsInput = false
+	env.done = false
+	env.advance()
+	env.totals = env.Totals()
+	return env.Observe()
+}
+
+// Casts the given action, or waits if it is -1 or can't be cast, then runs the sim until
+// the next decision point.
+func (env *InteractiveEnv) Step(action int32, wait time.Duration) (*proto.EnvStepResult, error) {
+	if env.done {
+		return nil, errors.New("env: episode is over, reset the environment")
+	}
+	sim, character := env.Sim, env.Character
+
+	cast := false
+	if action >= 0 && int(action) < len(env.Actions) && env.canCast(env.Actions[action]) {
+		cast = env.Actions[action].Cast(sim, character.CurrentTarget)
+	}
+	if !cast {
+		if wait <= 0 {
+			wait = defaultEnvWait
+		}
+		character.WaitUntil(sim, sim.CurrentTime+wait)
+	}
+	if !character.GCD.IsReady(sim) {
+		sim.NeedsInput = false
+		env.advance()
+	}
+
+	before := env.totals
+	env.totals = env.Totals()
+	return &proto.EnvStepResult{
+		Observation: env.Observe(),
+		Reward:      env.Reward(env, before, env.totals),
+		Done:        env.done,
+		Cast:        cast,
+	}, nil
+}
+
+func (env *InteractiveEnv) Done() bool {
+	return env.done
+}
+
+func (env *InteractiveEnv) advance() {
+	sim := env.Sim
+	for !sim.NeedsInput {
+		if finished := sim.Step(); finished {
+			sim.Cleanup()
+			env.done = true
+			return
+		}
+	}
+}
+
+// Matches the readiness check of the APL Cast Spell action.
+func (env *InteractiveEnv) canCast(spell *Spell) bool {
+	return spell.CanCast(env.Sim, env.Character.CurrentTarget) && (!spell.Flags.Matches(SpellFlagMCD) || env.Character.GCD.IsReady(env.Sim))
+}
+
+func (env *InteractiveEnv) Totals() EnvTotals {
+	var totals EnvTotals
+	addSpellbook := func(spellbook []*Spell) {
+		for _, spell := range spellbook {
+			for _, metrics := range spell.SpellMetrics {
+				totals.Damage += metrics.TotalDamage
+				totals.Healing += metrics.TotalHealing + metrics.TotalShielding
+				totals.Threat += metrics.TotalThreat
+			}
+		}
+	}
+	addSpellbook(env.Character.Spellbook)
+	for _, pet := range env.Character.Pets {
+		addSpellbook(pet.Spellbook)
+	}
+	return totals
+}
+
+func (env *InteractiveEnv) Observe() *proto.EnvObservation {
+	sim, character := env.Sim, env.Character
+	obs := &proto.EnvObservation{
+		CurrentTime:         sim.CurrentTime.Seconds(),
+		RemainingTime:       sim.GetRemainingDuration().Seconds(),
+		TargetHealthPercent: sim.GetRemainingDurationPercent(),
+		GcdRemaining:        character.GCD.TimeToReady(sim).Seconds(),
+	}
+	if target := character.CurrentTarget; target != nil && target.HasHealthBar() {
+		obs.TargetHealthPercent = target.CurrentHealthPercent()
+	}
+
+	if character.HasManaBar() {
+		obs.Mana = character.CurrentMana()
+		obs.ManaPercent = character.CurrentManaPercent()
+	}
+	if character.HasRageBar() {
+		obs.Rage = character.CurrentRage()
+	}
+	if character.HasEnergyBar() {
+		obs.Energy = character.CurrentEnergy()
+		obs.ComboPoints = character.ComboPoints()
+	}
+	if character.HasRunicPowerBar() {
+		obs.RunicPower = character.CurrentRunicPower()
+		obs.BloodRunes = int32(character.CurrentBloodRunes())
+		obs.FrostRunes = int32(character.CurrentFrostRunes())
+		obs.UnholyRunes = int32(character.CurrentUnholyRunes())
+		obs.DeathRunes = int32(character.CurrentDeathRunes())
+	}
+	if character.HasFocusBar() {
+		obs.Focus = character.CurrentFocus()
+	} else {
+		for _, pet := range character.Pets {
+			if pet.HasFocusBar() {
+				obs.Focus = pet.CurrentFocus()
+				break
+			}
+		}
+	}
+
+	for _, spell := range env.Actions {
+		obs.Cooldowns = append(obs.Cooldowns, spell.TimeToReady(sim).Seconds())
+		obs.ActionMask = append(obs.ActionMask, !env.done && sim.NeedsInput && env.canCast(spell))
+	}
+
+	for _, aura := range env.auras {
+		remaining, stacks := envAuraState(sim, aura)
+		obs.Auras = append(obs.Auras, remaining)
+		obs.AuraStacks = append(obs.AuraStacks, stacks)
+	}
+	for _, label := range env.targetAuraLabels {
+		var aura *Aura
+		if character.CurrentTarget != nil {
+			aura = character.CurrentTarget.GetAura(label)
+		}
+		remaining, stacks := envAuraState(sim, aura)
+		obs.TargetAuras = append(obs.TargetAuras, remaining)
+		obs.TargetAuraStacks = append(obs.TargetAuraStacks, stacks)
+	}
+	return obs
+}
+
+// Returns the remaining duration of the aura, capped to the remaining encounter time so
+// permanent auras stay in range, and its stacks.
+func envAuraState(sim *Simulation, aura *Aura) (float64, int32) {
+	if !aura.IsActive() {
+		return 0, 0
+	}
+	remaining := min(aura.RemainingDuration(sim), sim.GetRemainingDuration())
+	return remaining.Seconds(), aura.GetStacks()
+}
+
+// Flattens the scalar fields of an observation followed by its repeated fields, in
+// field order, for callers that want a plain feature vector.
+func EnvObservationVector(obs *proto.EnvObservation) []float64 {
+	vec := []float64{
+		obs.CurrentTime,
+		obs.RemainingTime,
+		obs.TargetHealthPercent,
+		obs.GcdRemaining,
+		obs.Mana,
+		obs.ManaPercent,
+		obs.Rage,
+		obs.Energy,
+		float64(obs.ComboPoints),
+		obs.RunicPower,
+		float64(obs.BloodRunes),
+		float64(obs.FrostRunes),
+		float64(obs.UnholyRunes),
+		float64(obs.DeathRunes),
+		obs.Focus,
+	}
+	vec = append(vec, obs.Cooldowns...)
+	for _, canCast := range obs.ActionMask {
+		vec = append(vec, TernaryFloat64(canCast, 1, 0))
+	}
+	vec = append(vec, obs.Auras...)
+	for _, stacks := range obs.AuraStacks {
+		vec = append(vec, float64(stacks))
+	}
+	vec = append(vec, obs.TargetAuras...)
+	for _, stacks := range obs.TargetAuraStacks {
+		vec = append(vec, float64(stacks))
+	}
+	return vec
+}
+
+// Environments created through the API, by ID.
+var (
+	interactiveEnvs     = map[string]*InteractiveEnv{}
+	interactiveEnvsLock sync.Mutex
+	nextInteractiveEnv  int64
+)
+
+func getInteractiveEnv(envID string) (*InteractiveEnv, error) {
+	interactiveEnvsLock.Lock()
+	defer interactiveEnvsLock.Unlock()
+	env, ok := interactiveEnvs[envID]
+	if !ok {
+		return nil, fmt.Errorf("env: no environment with id '%s'", envID)
+	}
+	return env, nil
+}
+
+func envPanicError(err interface{}) string {
+	return fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack()))
+}
+
+func resetInteractiveEnv(request *proto.EnvResetRequest) (result *proto.EnvResetResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.EnvResetResult{ErrorResult: envPanicError(err)}
+		}
+	}()
+
+	envID := request.EnvId
+	var env *InteractiveEnv
+	var err error
+	if envID != "" {
+		env, err = getInteractiveEnv(envID)
+	} else {
+		env, err = NewInteractiveEnv(request)
+		if err == nil {
+			interactiveEnvsLock.Lock()
+			nextInteractiveEnv++
+			envID = "env-" + strconv.FormatInt(nextInteractiveEnv, 10)
+			interactiveEnvs[envID] = env
+			interactiveEnvsLock.Unlock()
+		}
+	}
+	if err != nil {
+		return &proto.EnvResetResult{ErrorResult: err.Error()}
+	}
+
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	return &proto.EnvResetResult{
+		EnvId:       envID,
+		Spec:        env.Spec(),
+		Observation: env.Reset(request.Seed),
+	}
+}
+
+func stepInteractiveEnv(request *proto.EnvStepRequest) (result *proto.EnvStepResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.EnvStepResult{ErrorResult: envPanicError(err)}
+		}
+	}()
+
+	env, err := getInteractiveEnv(request.EnvId)
+	if err != nil {
+		return &proto.EnvStepResult{ErrorResult: err.Error()}
+	}
+
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	result, err = env.Step(request.Action, DurationFromSeconds(request.WaitSeconds))
+	if err != nil {
+		return &proto.EnvStepResult{ErrorResult: err.Error()}
+	}
+	return result
+}
+
+func closeInteractiveEnv(request *proto.EnvCloseRequest) *proto.EnvCloseResult {
+	interactiveEnvsLock.Lock()
+	defer interactiveEnvsLock.Unlock()
+	if _, ok := interactiveEnvs[request.EnvId]; !ok {
+		return &proto.EnvCloseResult{ErrorResult: fmt.Sprintf("env: no environment with id '%s'", request.EnvId)}
+	}
+	delete(interactiveEnvs, request.EnvId)
+	return &proto.EnvCloseResult{}
+}
@@ -0,0 +1,263 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+// Which shared cooldown an on-use effect triggers.
+type ItemSharedCD byte
+
+const (
+	ItemSharedCDNone ItemSharedCD = iota
+	ItemSharedCDOffensiveTrinket
+	ItemSharedCDDefensiveTrinket
+)
+
+// Declarative description of an item or enchant effect, for the common cases of
+// a proc or on-use stat bonus, stacking stat bonus or damage proc. These can be
+// written in Go or loaded from JSON with LoadItemEffectConfigs.
+//
+// Effects with a Cooldown are on-use. Otherwise they are procs, and Trigger must
+// have a Callback.
+type ItemEffectConfig struct {
+	Name string
+
+	// Exactly one of these must be set.
+	ItemID    int32
+	EnchantID int32
+
+	// Spell ID of the buff, defaults to the item. Required for enchants.
+	AuraID int32
+
+	// When to proc. Name, ActionID and Handler are filled in automatically.
+	// For on-use effects with MaxStacks, procs only add stacks while the buff is active.
+	Trigger ProcTrigger
+	// Spell which shouldn't trigger the proc, e.g. one which is hardcoded elsewhere.
+	IgnoreSpellID int32
+
+	Cooldown time.Duration
+	SharedCD ItemSharedCD
+
+	// Stats gained from the buff, per stack if MaxStacks is set.
+	Bonus     stats.Stats
+	Duration  time.Duration
+	MaxStacks int32
+
+	// Damage dealt by each proc, instead of a buff.
+	School    SpellSchool
+	MinDamage float64
+	MaxDamage float64
+}
+
+func (config *ItemEffectConfig) id() string {
+	if config.ItemID != 0 {
+		return "item " + strconv.Itoa(int(config.ItemID))
+	}
+	return "enchant " + strconv.Itoa(int(config.EnchantID))
+}
+
+func (config *ItemEffectConfig) isDamage() bool {
+	return config.MinDamage != 0 || config.MaxDamage != 0
+}
+
+func (config *ItemEffectConfig) validate() error {
+	if (config.ItemID == 0) == (config.EnchantID == 0) {
+		return fmt.Errorf("item effect %q: exactly one of item ID or enchant ID must be set", config.Name)
+	}
+	if config.Name == "" {
+		return fmt.Errorf("%s: missing name", config.id())
+	}
+	if config.EnchantID != 0 && config.AuraID == 0 {
+		return fmt.Errorf("%s: enchant effects need an aura ID", config.id())
+	}
+	if config.Cooldown == 0 && config.Trigger.Callback == CallbackEmpty {
+		return fmt.Errorf("%s: needs a proc callback or a cooldown", config.id())
+	}
+	if config.Trigger.ProcChance != 0 && config.Trigger.PPM != 0 {
+		return fmt.Errorf("%s: proc chance and PPM can't both be set", config.id())
+	}
+	if config.isDamage() {
+		if config.Cooldown != 0 || config.MaxStacks != 0 || config.Bonus != (stats.Stats{}) {
+			return fmt.Errorf("%s: damage procs can't have a cooldown, stacks or stat bonus", config.id())
+		}
+		if config.School == SpellSchoolNone || config.MinDamage > config.MaxDamage {
+			return fmt.Errorf("%s: damage procs need a school and min damage <= max damage", config.id())
+		}
+		return nil
+	}
+	if config.Duration <= 0 {
+		return fmt.Errorf("%s: needs a buff duration", config.id())
+	}
+	if config.Cooldown != 0 && config.MaxStacks != 0 && config.Trigger.Callback == CallbackEmpty {
+		return fmt.Errorf("%s: on-use stacking effects need a proc callback to add stacks", config.id())
+	}
+	return nil
+}
+
+// Registers config in the item or enchant effects. Panics if it is invalid, or
+// if the ID already has an effect.
+func NewItemEffectFromConfig(config ItemEffectConfig) {
+	if err := config.validate(); err != nil {
+		panic(err)
+	}
+	if config.ItemID != 0 {
+		NewItemEffect(config.ItemID, config.apply)
+	} else {
+		NewEnchantEffect(config.EnchantID, config.apply)
+	}
+}
+
+func (config *ItemEffectConfig) apply(agent Agent) {
+	character := agent.GetCharacter()
+
+	actionID := ActionID{ItemID: config.ItemID}
+	if config.EnchantID != 0 {
+		actionID = ActionID{SpellID: config.AuraID}
+	}
+	auraID := actionID
+	if config.AuraID != 0 {
+		auraID = ActionID{SpellID: config.AuraID}
+	}
+
+	trigger := config.Trigger
+	trigger.Name = config.Name
+	trigger.ActionID = actionID
+
+	switch {
+	case config.isDamage():
+		config.applyDamageProc(character, actionID, trigger)
+	case config.Cooldown != 0 && config.MaxStacks != 0:
+		config.applyStackingOnUse(character, actionID, auraID, trigger)
+	case config.Cooldown != 0:
+		label := "ItemActive-" + strconv.Itoa(int(config.ItemID))
+		if config.EnchantID != 0 {
+			label = "EnchantActive-" + strconv.Itoa(int(config.EnchantID))
+		}
+		MakeTemporaryStatsOnUseCDRegistration(
+			label,
+			config.Bonus,
+			config.Duration,
+			SpellConfig{
+				ActionID: actionID,
+			},
+			func(character *Character) Cooldown {
+				return Cooldown{
+					Timer:    character.NewTimer(),
+					Duration: config.Cooldown,
+				}
+			},
+			config.sharedCD,
+		)(agent)
+	case config.MaxStacks != 0:
+		procAura := MakeStackingAura(character, StackingStatAura{
+			Aura: Aura{
+				Label:     config.Name + " Proc",
+				ActionID:  auraID,
+				Duration:  config.Duration,
+				MaxStacks: config.MaxStacks,
+			},
+			BonusPerStack: config.Bonus,
+		})
+		trigger.Handler = config.ignoreSpell(func(sim *Simulation, _ *Spell, _ *SpellResult) {
+			procAura.Activate(sim)
+			procAura.AddStack(sim)
+		})
+		MakeProcTriggerAura(&character.Unit, trigger)
+	default:
+		procAura := character.NewTemporaryStatsAura(config.Name+" Proc", auraID, config.Bonus, config.Duration)
+		trigger.Handler = config.ignoreSpell(func(sim *Simulation, _ *Spell, _ *SpellResult) {
+			procAura.Activate(sim)
+		})
+		triggerAura := MakeProcTriggerAura(&character.Unit, trigger)
+		procAura.Icd = triggerAura.Icd
+	}
+}
+
+func (config *ItemEffectConfig) sharedCD(character *Character) Cooldown {
+	switch config.SharedCD {
+	case ItemSharedCDOffensiveTrinket:
+		return Cooldown{Timer: character.GetOffensiveTrinketCD(), Duration: config.Duration}
+	case ItemSharedCDDefensiveTrinket:
+		return Cooldown{Timer: character.GetDefensiveTrinketCD(), Duration: config.Duration}
+	default:
+		return Cooldown{}
+	}
+}
+
+func (config *ItemEffectConfig) ignoreSpell(handler ProcHandler) ProcHandler {
+	if config.IgnoreSpellID == 0 {
+		return handler
+	}
+	ignoreSpellID := config.IgnoreSpellID
+	return func(sim *Simulation, spell *Spell, result *SpellResult) {
+		if !spell.IsSpellAction(ignoreSpellID) {
+			handler(sim, spell, result)
+		}
+	}
+}
+
+func (config *ItemEffectConfig) applyDamageProc(character *Character, actionID ActionID, trigger ProcTrigger) {
+	minDmg := config.MinDamage
+	maxDmg := config.MaxDamage
+	damageSpell := character.RegisterSpell(SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: config.School,
+		ProcMask:    ProcMaskEmpty,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   character.DefaultSpellCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.CalcAndDealDamage(sim, target, sim.Roll(minDmg, maxDmg), spell.OutcomeMagicHitAndCrit)
+		},
+	})
+
+	trigger.Handler = config.ignoreSpell(func(sim *Simulation, _ *Spell, _ *SpellResult) {
+		damageSpell.Cast(sim, character.CurrentTarget)
+	})
+	MakeProcTriggerAura(&character.Unit, trigger)
+}
+
+func (config *ItemEffectConfig) applyStackingOnUse(character *Character, actionID ActionID, auraID ActionID, trigger ProcTrigger) {
+	buffAura := MakeStackingAura(character, StackingStatAura{
+		Aura: Aura{
+			Label:     config.Name + " Aura",
+			ActionID:  auraID,
+			Duration:  config.Duration,
+			MaxStacks: config.MaxStacks,
+		},
+		BonusPerStack: config.Bonus,
+	})
+
+	trigger.Handler = config.ignoreSpell(func(sim *Simulation, _ *Spell, _ *SpellResult) {
+		buffAura.AddStack(sim)
+	})
+	ApplyProcTriggerCallback(&character.Unit, buffAura, trigger)
+
+	spell := character.RegisterSpell(SpellConfig{
+		ActionID: actionID,
+		Flags:    SpellFlagNoOnCastComplete,
+
+		Cast: CastConfig{
+			CD: Cooldown{
+				Timer:    character.NewTimer(),
+				Duration: config.Cooldown,
+			},
+			SharedCD: config.sharedCD(character),
+		},
+
+		ApplyEffects: func(sim *Simulation, _ *Unit, spell *Spell) {
+			buffAura.Activate(sim)
+		},
+	})
+
+	character.AddMajorCooldown(MajorCooldown{
+		Spell: spell,
+		Type:  CooldownTypeDPS,
+	})
+}
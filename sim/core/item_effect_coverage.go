@@ -0,0 +1,87 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Whether an enchant has an effect registered, either for the wearer or for the enchanted weapon.
+func HasAnyEnchantEffect(effectID int32) bool {
+	return HasEnchantEffect(effectID) || HasWeaponEffect(effectID)
+}
+
+// Returns a warning for each equipped item, gem or enchant which has an effect
+// in the database with nothing registered to implement it, so only its stats
+// are simmed.
+func (character *Character) unimplementedEffectWarnings() []string {
+	var warnings []string
+	for slot, item := range character.Equipment {
+		if item.ID == 0 {
+			continue
+		}
+		slotName := strings.TrimPrefix(proto.ItemSlot(slot).String(), "ItemSlot")
+
+		if item.HasSpecialEffect && !HasItemEffect(item.ItemEffectID()) {
+			warnings = append(warnings, fmt.Sprintf("%s: %s (%d) has an effect which is not implemented, only its stats are simmed.", slotName, item.Name, item.ID))
+		}
+		for _, gem := range item.Gems {
+			if gem.ID != 0 && gem.HasSpecialEffect && !HasItemEffect(gem.ID) {
+				warnings = append(warnings, fmt.Sprintf("%s: gem %s (%d) has an effect which is not implemented, only its stats are simmed.", slotName, gem.Name, gem.ID))
+			}
+		}
+		if item.Enchant.EffectID != 0 && item.Enchant.HasSpecialEffect && !HasAnyEnchantEffect(item.Enchant.EffectID) {
+			warnings = append(warnings, fmt.Sprintf("%s: enchant %d has an effect which is not implemented, only its stats are simmed.", slotName, item.Enchant.EffectID))
+		}
+	}
+	return warnings
+}
+
+// An item, gem or enchant in the database with an effect that isn't implemented.
+type UnimplementedEffect struct {
+	Kind  string // "item", "gem" or "enchant"
+	ID    int32  // Item ID, or effect ID for enchants.
+	Name  string
+	Type  proto.ItemType // Unknown for gems.
+	Phase int32
+}
+
+// Returns every item, gem and enchant in db with an effect that isn't implemented,
+// sorted by kind, type, phase and ID.
+func FindUnimplementedEffects(db *proto.UIDatabase) []UnimplementedEffect {
+	var missing []UnimplementedEffect
+	for _, item := range db.Items {
+		if item.HasSpecialEffect && !HasItemEffect(item.Id) {
+			missing = append(missing, UnimplementedEffect{Kind: "item", ID: item.Id, Name: item.Name, Type: item.Type, Phase: item.Phase})
+		}
+	}
+	for _, gem := range db.Gems {
+		if gem.HasSpecialEffect && !HasItemEffect(gem.Id) {
+			missing = append(missing, UnimplementedEffect{Kind: "gem", ID: gem.Id, Name: gem.Name, Phase: gem.Phase})
+		}
+	}
+	seenEnchants := make(map[int32]bool)
+	for _, enchant := range db.Enchants {
+		if enchant.HasSpecialEffect && !HasAnyEnchantEffect(enchant.EffectId) && !seenEnchants[enchant.EffectId] {
+			seenEnchants[enchant.EffectId] = true
+			missing = append(missing, UnimplementedEffect{Kind: "enchant", ID: enchant.EffectId, Name: enchant.Name, Type: enchant.Type, Phase: enchant.Phase})
+		}
+	}
+
+	kindOrder := map[string]int{"item": 0, "gem": 1, "enchant": 2}
+	slices.SortStableFunc(missing, func(a, b UnimplementedEffect) int {
+		if a.Kind != b.Kind {
+			return kindOrder[a.Kind] - kindOrder[b.Kind]
+		}
+		if a.Type != b.Type {
+			return int(a.Type - b.Type)
+		}
+		if a.Phase != b.Phase {
+			return int(a.Phase - b.Phase)
+		}
+		return int(a.ID - b.ID)
+	})
+	return missing
+}
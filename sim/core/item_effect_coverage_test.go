@@ -0,0 +1,66 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestUnimplementedEffectWarnings(t *testing.T) {
+	const implementedID = 9000010
+	NewItemEffect(implementedID, func(Agent) {})
+	defer func() {
+		delete(itemEffects, implementedID)
+		itemEffectsForTest = slices.DeleteFunc(itemEffectsForTest, func(id int32) bool { return id == implementedID })
+	}()
+
+	character := &Character{}
+	character.Equipment[proto.ItemSlot_ItemSlotTrinket1] = Item{ID: 9000011, Name: "Unknown Trinket", HasSpecialEffect: true}
+	character.Equipment[proto.ItemSlot_ItemSlotTrinket2] = Item{ID: implementedID, Name: "Known Trinket", HasSpecialEffect: true}
+	character.Equipment[proto.ItemSlot_ItemSlotHead] = Item{
+		ID:      9000012,
+		Name:    "Plain Helm",
+		Gems:    []Gem{{ID: 9000013, Name: "Odd Diamond", HasSpecialEffect: true}},
+		Enchant: Enchant{EffectID: 9000014, HasSpecialEffect: true},
+	}
+
+	warnings := character.unimplementedEffectWarnings()
+	if len(warnings) != 3 {
+		t.Fatalf("Expected 3 warnings, got %d: %v", len(warnings), warnings)
+	}
+	if !strings.HasPrefix(warnings[0], "Head: gem Odd Diamond") || !strings.HasPrefix(warnings[1], "Head: enchant 9000014") ||
+		!strings.HasPrefix(warnings[2], "Trinket1: Unknown Trinket") {
+		t.Fatalf("Unexpected warnings: %v", warnings)
+	}
+}
+
+func TestFindUnimplementedEffects(t *testing.T) {
+	db := &proto.UIDatabase{
+		Items: []*proto.UIItem{
+			{Id: 9000021, Name: "B", Type: proto.ItemType_ItemTypeTrinket, Phase: 2, HasSpecialEffect: true},
+			{Id: 9000020, Name: "A", Type: proto.ItemType_ItemTypeTrinket, Phase: 1, HasSpecialEffect: true},
+			{Id: 9000022, Name: "Plain", Type: proto.ItemType_ItemTypeHead, Phase: 1},
+		},
+		Gems: []*proto.UIGem{
+			{Id: 9000023, Name: "Diamond", Phase: 1, HasSpecialEffect: true},
+		},
+		Enchants: []*proto.UIEnchant{
+			{EffectId: 9000024, Name: "Proc", Type: proto.ItemType_ItemTypeWeapon, HasSpecialEffect: true},
+			{EffectId: 9000024, Name: "Proc", Type: proto.ItemType_ItemTypeRanged, HasSpecialEffect: true},
+		},
+	}
+
+	missing := FindUnimplementedEffects(db)
+	ids := MapSlice(missing, func(effect UnimplementedEffect) int32 { return effect.ID })
+	expected := []int32{9000020, 9000021, 9000023, 9000024}
+	if len(ids) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+	for i := range ids {
+		if ids[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, ids)
+		}
+	}
+}
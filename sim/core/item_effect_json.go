@@ -0,0 +1,263 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+// JSON form of an ItemEffectConfig. Flags are lists of names without their Go
+// prefix, e.g. "OnSpellHitDealt" or "MeleeOrRanged", durations use Go syntax
+// like "45s" and stats use their names, e.g. "AttackPower".
+type itemEffectJSON struct {
+	Name      string `json:"name"`
+	ItemID    int32  `json:"itemId"`
+	EnchantID int32  `json:"enchantId"`
+	AuraID    int32  `json:"auraId"`
+
+	Trigger struct {
+		Callbacks  []string `json:"callbacks"`
+		ProcMask   []string `json:"procMask"`
+		Outcome    []string `json:"outcome"`
+		Harmful    bool     `json:"harmful"`
+		ProcChance float64  `json:"procChance"`
+		PPM        float64  `json:"ppm"`
+		ICD        string   `json:"icd"`
+	} `json:"trigger"`
+	IgnoreSpellID int32 `json:"ignoreSpellId"`
+
+	Cooldown       string `json:"cooldown"`
+	SharedCooldown string `json:"sharedCooldown"`
+
+	Stats     map[string]float64 `json:"stats"`
+	Duration  string             `json:"duration"`
+	MaxStacks int32              `json:"maxStacks"`
+
+	School    string  `json:"school"`
+	MinDamage float64 `json:"minDamage"`
+	MaxDamage float64 `json:"maxDamage"`
+}
+
+var itemEffectCallbacksByName = map[string]AuraCallback{
+	"OnSpellHitDealt":       CallbackOnSpellHitDealt,
+	"OnSpellHitTaken":       CallbackOnSpellHitTaken,
+	"OnPeriodicDamageDealt": CallbackOnPeriodicDamageDealt,
+	"OnHealDealt":           CallbackOnHealDealt,
+	"OnPeriodicHealDealt":   CallbackOnPeriodicHealDealt,
+	"OnCastComplete":        CallbackOnCastComplete,
+}
+
+var itemEffectProcMasksByName = map[string]ProcMask{
+	"Empty":                ProcMaskEmpty,
+	"MeleeMHAuto":          ProcMaskMeleeMHAuto,
+	"MeleeOHAuto":          ProcMaskMeleeOHAuto,
+	"MeleeMHSpecial":       ProcMaskMeleeMHSpecial,
+	"MeleeOHSpecial":       ProcMaskMeleeOHSpecial,
+	"RangedAuto":           ProcMaskRangedAuto,
+	"RangedSpecial":        ProcMaskRangedSpecial,
+	"SpellDamage":          ProcMaskSpellDamage,
+	"SpellHealing":         ProcMaskSpellHealing,
+	"Proc":                 ProcMaskProc,
+	"WeaponProc":           ProcMaskWeaponProc,
+	"MeleeMH":              ProcMaskMeleeMH,
+	"MeleeOH":              ProcMaskMeleeOH,
+	"MeleeWhiteHit":        ProcMaskMeleeWhiteHit,
+	"WhiteHit":             ProcMaskWhiteHit,
+	"MeleeSpecial":         ProcMaskMeleeSpecial,
+	"MeleeOrRangedSpecial": ProcMaskMeleeOrRangedSpecial,
+	"Melee":                ProcMaskMelee,
+	"Ranged":               ProcMaskRanged,
+	"MeleeOrRanged":        ProcMaskMeleeOrRanged,
+	"Direct":               ProcMaskDirect,
+	"Special":              ProcMaskSpecial,
+	"MeleeOrProc":          ProcMaskMeleeOrProc,
+	"SpellOrProc":          ProcMaskSpellOrProc,
+}
+
+var itemEffectOutcomesByName = map[string]HitOutcome{
+	"Miss":   OutcomeMiss,
+	"Hit":    OutcomeHit,
+	"Dodge":  OutcomeDodge,
+	"Glance": OutcomeGlance,
+	"Parry":  OutcomeParry,
+	"Block":  OutcomeBlock,
+	"Crit":   OutcomeCrit,
+	"Crush":  OutcomeCrush,
+	"Landed": OutcomeLanded,
+}
+
+var itemEffectSchoolsByName = map[string]SpellSchool{
+	"Physical": SpellSchoolPhysical,
+	"Arcane":   SpellSchoolArcane,
+	"Fire":     SpellSchoolFire,
+	"Frost":    SpellSchoolFrost,
+	"Holy":     SpellSchoolHoly,
+	"Nature":   SpellSchoolNature,
+	"Shadow":   SpellSchoolShadow,
+}
+
+var itemEffectSharedCDsByName = map[string]ItemSharedCD{
+	"":          ItemSharedCDNone,
+	"offensive": ItemSharedCDOffensiveTrinket,
+	"defensive": ItemSharedCDDefensiveTrinket,
+}
+
+func parseItemEffectFlags[T ~uint32 | ~uint16 | ~uint8](kind string, names []string, byName map[string]T) (T, error) {
+	var flags T
+	for _, name := range names {
+		flag, ok := byName[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown %s %q", kind, name)
+		}
+		flags |= flag
+	}
+	return flags, nil
+}
+
+func parseItemEffectDuration(kind string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", kind, err)
+	}
+	return duration, nil
+}
+
+func (data *itemEffectJSON) toConfig() (ItemEffectConfig, error) {
+	config := ItemEffectConfig{
+		Name:          data.Name,
+		ItemID:        data.ItemID,
+		EnchantID:     data.EnchantID,
+		AuraID:        data.AuraID,
+		IgnoreSpellID: data.IgnoreSpellID,
+		MaxStacks:     data.MaxStacks,
+		MinDamage:     data.MinDamage,
+		MaxDamage:     data.MaxDamage,
+	}
+	config.Trigger.Harmful = data.Trigger.Harmful
+	config.Trigger.ProcChance = data.Trigger.ProcChance
+	config.Trigger.PPM = data.Trigger.PPM
+
+	var err error
+	if config.Trigger.Callback, err = parseItemEffectFlags("callback", data.Trigger.Callbacks, itemEffectCallbacksByName); err != nil {
+		return config, err
+	}
+	if config.Trigger.ProcMask, err = parseItemEffectFlags("proc mask", data.Trigger.ProcMask, itemEffectProcMasksByName); err != nil {
+		return config, err
+	}
+	if config.Trigger.Outcome, err = parseItemEffectFlags("outcome", data.Trigger.Outcome, itemEffectOutcomesByName); err != nil {
+		return config, err
+	}
+	if config.Trigger.ICD, err = parseItemEffectDuration("icd", data.Trigger.ICD); err != nil {
+		return config, err
+	}
+	if config.Cooldown, err = parseItemEffectDuration("cooldown", data.Cooldown); err != nil {
+		return config, err
+	}
+	if config.Duration, err = parseItemEffectDuration("duration", data.Duration); err != nil {
+		return config, err
+	}
+
+	sharedCD, ok := itemEffectSharedCDsByName[data.SharedCooldown]
+	if !ok {
+		return config, fmt.Errorf("unknown shared cooldown %q, expected offensive or defensive", data.SharedCooldown)
+	}
+	config.SharedCD = sharedCD
+
+	if data.School != "" {
+		if config.School, ok = itemEffectSchoolsByName[data.School]; !ok {
+			return config, fmt.Errorf("unknown school %q", data.School)
+		}
+	}
+
+	for name, value := range data.Stats {
+		stat, ok := statByName(name)
+		if !ok {
+			return config, fmt.Errorf("unknown stat %q", name)
+		}
+		config.Bonus[stat] = value
+	}
+	return config, nil
+}
+
+func (config *ItemEffectConfig) inDatabase() bool {
+	if config.EnchantID != 0 {
+		_, ok := EnchantsByEffectID[config.EnchantID]
+		return ok
+	}
+	_, hasItem := ItemsByID[config.ItemID]
+	_, hasGem := GemsByID[config.ItemID]
+	return hasItem || hasGem
+}
+
+func statByName(name string) (stats.Stat, bool) {
+	for stat := stats.Stat(0); stat < stats.Len; stat++ {
+		if strings.EqualFold(stat.StatName(), name) {
+			return stat, true
+		}
+	}
+	return 0, false
+}
+
+// Parses a JSON array of item effects and registers them. Every effect is
+// checked before any are registered, so nothing is added if one is invalid or
+// its ID already has an effect.
+func LoadItemEffectConfigs(data []byte) error {
+	var entries []itemEffectJSON
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&entries); err != nil {
+		return fmt.Errorf("invalid item effects json: %w", err)
+	}
+
+	configs := make([]ItemEffectConfig, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		config, err := entry.toConfig()
+		if err == nil {
+			err = config.validate()
+		}
+		if err != nil {
+			return fmt.Errorf("item effect %d (%s): %w", i, entry.Name, err)
+		}
+
+		id := config.id()
+		if config.ItemID != 0 && HasItemEffect(config.ItemID) {
+			return fmt.Errorf("%s (%s) already has an effect defined in Go", id, config.Name)
+		}
+		if config.EnchantID != 0 && (HasEnchantEffect(config.EnchantID) || HasWeaponEffect(config.EnchantID)) {
+			return fmt.Errorf("%s (%s) already has an effect defined in Go", id, config.Name)
+		}
+		if WITH_DB && !config.inDatabase() {
+			return fmt.Errorf("%s (%s) is not in the database", id, config.Name)
+		}
+		if seen[id] {
+			return fmt.Errorf("%s (%s) is defined more than once", id, config.Name)
+		}
+		seen[id] = true
+		configs = append(configs, config)
+	}
+
+	for _, config := range configs {
+		NewItemEffectFromConfig(config)
+	}
+	return nil
+}
+
+func LoadItemEffectConfigsFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read item effects file %q: %w", path, err)
+	}
+	if err := LoadItemEffectConfigs(data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+func TestLoadItemEffectConfigs(t *testing.T) {
+	const itemID = 9000001
+	defer func() {
+		delete(itemEffects, itemID)
+		itemEffectsForTest = slices.DeleteFunc(itemEffectsForTest, func(id int32) bool { return id == itemID })
+	}()
+
+	data := []byte(`[{
+		"name": "Test Trinket",
+		"itemId": 9000001,
+		"auraId": 123,
+		"trigger": {"callbacks": ["OnSpellHitDealt"], "procMask": ["MeleeOrRanged"], "outcome": ["Landed"], "harmful": true, "procChance": 0.1, "icd": "45s"},
+		"stats": {"AttackPower": 1000, "ArmorPenetration": 50},
+		"duration": "10s"
+	}]`)
+	if err := LoadItemEffectConfigs(data); err != nil {
+		t.Fatalf("Failed to load item effects: %s", err)
+	}
+	if !HasItemEffect(itemID) {
+		t.Fatalf("Expected item %d to have an effect", itemID)
+	}
+
+	err := LoadItemEffectConfigs(data)
+	if err == nil || !strings.Contains(err.Error(), "already has an effect") {
+		t.Fatalf("Expected a collision error, got %v", err)
+	}
+}
+
+func TestItemEffectJSONToConfig(t *testing.T) {
+	entry := itemEffectJSON{
+		Name:           "Test On Use",
+		ItemID:         1,
+		Cooldown:       "2m",
+		SharedCooldown: "defensive",
+		Stats:          map[string]float64{"Stamina": 500},
+		Duration:       "20s",
+		MaxStacks:      5,
+	}
+	entry.Trigger.Callbacks = []string{"OnSpellHitTaken"}
+	entry.Trigger.Outcome = []string{"Hit", "Crit"}
+
+	config, err := entry.toConfig()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if config.Cooldown != time.Minute*2 || config.Duration != time.Second*20 || config.SharedCD != ItemSharedCDDefensiveTrinket {
+		t.Fatalf("Wrong cooldowns: %+v", config)
+	}
+	if config.Trigger.Callback != CallbackOnSpellHitTaken || config.Trigger.Outcome != OutcomeHit|OutcomeCrit {
+		t.Fatalf("Wrong trigger: %+v", config.Trigger)
+	}
+	if config.Bonus[stats.Stamina] != 500 {
+		t.Fatalf("Wrong bonus: %s", config.Bonus)
+	}
+	if err := config.validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %s", err)
+	}
+}
+
+func TestLoadItemEffectConfigsErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":    `[{"name": "A", "itemId": 9000002, "duration": "10s", "cooldown": "1m", "bogus": 1}]`,
+		"unknown callback": `[{"name": "A", "itemId": 9000002, "duration": "10s", "trigger": {"callbacks": ["OnTuesday"]}}]`,
+		"unknown stat":     `[{"name": "A", "itemId": 9000002, "duration": "10s", "cooldown": "1m", "stats": {"Luck": 1}}]`,
+		"no trigger":       `[{"name": "A", "itemId": 9000002, "duration": "10s"}]`,
+		"duplicate":        `[{"name": "A", "itemId": 9000002, "duration": "10s", "cooldown": "1m"}, {"name": "B", "itemId": 9000002, "duration": "10s", "cooldown": "1m"}]`,
+	}
+	for name, data := range cases {
+		if err := LoadItemEffectConfigs([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if HasItemEffect(9000002) {
+		t.Fatalf("Invalid item effects should not be registered")
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// The stat budget of an item grows linearly with its item level, at a rate
+// depending on its quality: budget = (ilvl - offset) / divisor.
+type itemBudgetFormula struct {
+	offset  float64
+	divisor float64
+}
+
+var itemBudgetFormulas = map[proto.ItemQuality]itemBudgetFormula{
+	proto.ItemQuality_ItemQualityUncommon: {offset: 4, divisor: 2},
+	proto.ItemQuality_ItemQualityRare:     {offset: 1.84, divisor: 1.6},
+	proto.ItemQuality_ItemQualityEpic:     {offset: 1.3, divisor: 1.3},
+}
+
+// Returns the stat budget of an item of the given level and quality.
+func ItemBudget(ilvl int32, quality proto.ItemQuality) float64 {
+	formula, ok := itemBudgetFormulas[quality]
+	if !ok {
+		if quality > proto.ItemQuality_ItemQualityEpic {
+			formula = itemBudgetFormulas[proto.ItemQuality_ItemQualityEpic]
+		} else {
+			formula = itemBudgetFormulas[proto.ItemQuality_ItemQualityUncommon]
+		}
+	}
+	return (float64(ilvl) - formula.offset) / formula.divisor
+}
+
+// Returns a copy of the item with its stats and weapon damage scaled to the
+// stat budget of another item level. Sockets and the socket bonus don't
+// change with item level, and effects are left as they are.
+func (item Item) ScaledToIlvl(ilvl int32) Item {
+	if item.Ilvl <= 0 {
+		panic(fmt.Sprintf("Cannot scale item %d, its item level is unknown", item.ID))
+	}
+	if ilvl <= 0 {
+		panic(fmt.Sprintf("Cannot scale item %d to item level %d", item.ID, ilvl))
+	}
+
+	ratio := ItemBudget(ilvl, item.Quality) / ItemBudget(item.Ilvl, item.Quality)
+	for i := range item.Stats {
+		item.Stats[i] = math.Round(item.Stats[i] * ratio)
+	}
+	item.WeaponDamageMin = math.Round(item.WeaponDamageMin * ratio)
+	item.WeaponDamageMax = math.Round(item.WeaponDamageMax * ratio)
+	item.Ilvl = ilvl
+	return item
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+func TestScaledToIlvl(t *testing.T) {
+	item := Item{
+		ID:              1,
+		Ilvl:            226,
+		Quality:         proto.ItemQuality_ItemQualityEpic,
+		Stats:           stats.Stats{stats.Stamina: 100, stats.AttackPower: 150},
+		SocketBonus:     stats.Stats{stats.Stamina: 6},
+		WeaponDamageMin: 400,
+		WeaponDamageMax: 600,
+	}
+
+	scaled := item.ScaledToIlvl(239)
+	if scaled.Ilvl != 239 || scaled.Stats[stats.Stamina] != 106 || scaled.Stats[stats.AttackPower] != 159 {
+		t.Fatalf("Unexpected scaled stats: %v", scaled.Stats)
+	}
+	if scaled.WeaponDamageMin != 423 || scaled.WeaponDamageMax != 635 || scaled.SocketBonus[stats.Stamina] != 6 {
+		t.Fatalf("Unexpected scaled weapon damage or socket bonus: %v-%v, %v", scaled.WeaponDamageMin, scaled.WeaponDamageMax, scaled.SocketBonus)
+	}
+	if item.Stats[stats.Stamina] != 100 {
+		t.Fatal("Scaling modified the original item")
+	}
+}
+
+func TestCustomItemWithEffect(t *testing.T) {
+	const itemID = 9000200
+	const effectItemID = 9000201
+
+	AddEffectsToTest = false
+	NewItemEffect(effectItemID, func(agent Agent) {
+		agent.GetCharacter().AddStat(stats.SpellPower, 1000)
+	})
+	AddEffectsToTest = true
+	defer delete(itemEffects, effectItemID)
+	defer delete(ItemsByID, itemID)
+
+	player := &proto.Player{
+		Name:  "Caster",
+		Class: proto.Class_ClassShaman,
+		Spec:  &proto.Player_ElementalShaman{},
+		Equipment: &proto.EquipmentSpec{Items: []*proto.ItemSpec{
+			{Id: itemID, Ilvl: 239},
+		}},
+		Database: &proto.SimDatabase{
+			Items: []*proto.SimItem{{
+				Id:       itemID,
+				Name:     "Custom Circlet",
+				Type:     proto.ItemType_ItemTypeHead,
+				Ilvl:     226,
+				Quality:  proto.ItemQuality_ItemQualityEpic,
+				Stats:    stats.Stats{stats.SpellPower: 100}.ToFloatArray(),
+				EffectId: effectItemID,
+			}},
+		},
+	}
+
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{player}}}},
+	})
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+
+	playerStats := result.RaidStats.Parties[0].Players[0]
+	gearSpellPower := playerStats.GearStats.Stats[stats.SpellPower] - playerStats.BaseStats.Stats[stats.SpellPower]
+	if gearSpellPower != 1106 {
+		t.Fatalf("Expected 106 spell power from the scaled item and 1000 from its effect, got %v", gearSpellPower)
+	}
+}
+
+func TestCustomItemsWithProcEffects(t *testing.T) {
+	const weaponID = 9000210
+	const weaponEffectID = 9000211
+	const trinketID = 9000212
+	const trinketEffectID = 9000213
+
+	var procMask ProcMask
+	var hasTrinket bool
+	AddEffectsToTest = false
+	NewItemEffect(weaponEffectID, func(agent Agent) {
+		procMask = agent.GetCharacter().GetProcMaskForItem(weaponEffectID)
+	})
+	NewItemEffect(trinketEffectID, func(agent Agent) {
+		hasTrinket = agent.GetCharacter().HasTrinketEquipped(trinketEffectID)
+	})
+	AddEffectsToTest = true
+	defer delete(itemEffects, weaponEffectID)
+	defer delete(itemEffects, trinketEffectID)
+	defer delete(ItemsByID, weaponID)
+	defer delete(ItemsByID, trinketID)
+
+	equipment := &proto.EquipmentSpec{}
+	for range proto.ItemSlot_name {
+		equipment.Items = append(equipment.Items, &proto.ItemSpec{})
+	}
+	equipment.Items[proto.ItemSlot_ItemSlotTrinket1].Id = trinketID
+	equipment.Items[proto.ItemSlot_ItemSlotMainHand].Id = weaponID
+
+	player := &proto.Player{
+		Name:      "Caster",
+		Class:     proto.Class_ClassShaman,
+		Spec:      &proto.Player_ElementalShaman{},
+		Equipment: equipment,
+		Database: &proto.SimDatabase{
+			Items: []*proto.SimItem{
+				{
+					Id:              weaponID,
+					Name:            "Custom Mace",
+					Type:            proto.ItemType_ItemTypeWeapon,
+					WeaponType:      proto.WeaponType_WeaponTypeMace,
+					HandType:        proto.HandType_HandTypeMainHand,
+					WeaponDamageMin: 100,
+					WeaponDamageMax: 200,
+					WeaponSpeed:     2,
+					EffectId:        weaponEffectID,
+				},
+				{Id: trinketID, Name: "Custom Trinket", Type: proto.ItemType_ItemTypeTrinket, EffectId: trinketEffectID},
+			},
+		},
+	}
+
+	result := ComputeStats(&proto.ComputeStatsRequest{
+		Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{player}}}},
+	})
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+	if procMask != ProcMaskMeleeMH {
+		t.Fatalf("Expected the borrowed weapon effect to proc from the main hand, got %v", procMask)
+	}
+	if !hasTrinket {
+		t.Fatal("Expected the custom trinket to count as the trinket whose effect it borrows")
+	}
+}
+
+func TestCustomItemRedefinition(t *testing.T) {
+	const builtinID = 9000220
+	const customID = 9000221
+	defer delete(ItemsByID, builtinID)
+	defer delete(ItemsByID, customID)
+	defer delete(customItemIDs, customID)
+
+	addToDatabase(&proto.SimDatabase{Items: []*proto.SimItem{{Id: builtinID, Name: "Built-in"}}})
+	addPlayerDatabase(&proto.SimDatabase{Items: []*proto.SimItem{{Id: customID, Name: "Custom"}}})
+	addPlayerDatabase(&proto.SimDatabase{Items: []*proto.SimItem{
+		{Id: builtinID, Name: "Replaced Built-in"},
+		{Id: customID, Name: "Redefined Custom"},
+	}})
+
+	if name := ItemsByID[builtinID].Name; name != "Built-in" {
+		t.Fatalf("Expected a player database not to replace a built-in item, got %s", name)
+	}
+	if name := ItemsByID[customID].Name; name != "Redefined Custom" {
+		t.Fatalf("Expected a player database to replace an earlier custom item, got %s", name)
+	}
+}
+
+func TestConcurrentPlayerDatabase(t *testing.T) {
+	const customID = 9000222
+	defer delete(ItemsByID, customID)
+	defer delete(customItemIDs, customID)
+
+	swr := &proto.StatWeightsRequest{
+		Player: &proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassShaman,
+			Consumes:  &proto.Consumes{},
+			Buffs:     &proto.IndividualBuffs{},
+			Spec:      &proto.Player_ElementalShaman{},
+			Equipment: &proto.EquipmentSpec{},
+			Database:  &proto.SimDatabase{Items: []*proto.SimItem{{Id: customID, Name: "Custom", Type: proto.ItemType_ItemTypeHead}}},
+		},
+		PartyBuffs:   &proto.PartyBuffs{},
+		RaidBuffs:    &proto.RaidBuffs{},
+		Debuffs:      &proto.Debuffs{},
+		Encounter:    &proto.Encounter{Duration: 10, Targets: []*proto.Target{NewDefaultTarget()}},
+		SimOptions:   &proto.SimOptions{Iterations: 2, RandomSeed: 100},
+		StatsToWeigh: []proto.Stat{proto.Stat_StatSpellPower, proto.Stat_StatStamina, proto.Stat_StatIntellect, proto.Stat_StatSpirit},
+	}
+	// Runs its sims concurrently, which must not write to the database maps.
+	CalcStatWeight(swr, stats.SpellPower, nil)
+	if swr.Player.Database != nil || ItemsByID[customID].Name != "Custom" {
+		t.Fatalf("Expected the player database to be added once before the sims")
+	}
+
+	// Characters still add their player's database, which is identical here and isn't written again.
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for j := 0; j < 100; j++ {
+				addPlayerDatabase(&proto.SimDatabase{Items: []*proto.SimItem{{Id: customID, Name: "Custom", Type: proto.ItemType_ItemTypeHead}}})
+				if ItemsByID[customID].Name != "Custom" {
+					t.Errorf("Expected the custom item to stay in the database")
+				}
+			}
+		}()
+	}
+	waitGroup.Wait()
+}
@@ -282,5 +282,6 @@ func toItem(itemSpec *proto.ItemSpec) Item {
 		ID:      itemSpec.Id,
 		Gems:    itemSpec.Gems,
 		Enchant: itemSpec.Enchant,
+		Ilvl:    itemSpec.Ilvl,
 	})
 }
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Models the delay between a player being able to act and the action reaching the server:
+// network latency, human input delay, and the spell queue window which hides part of both
+// when the next action can be anticipated.
+type latencyModel struct {
+	networkLatency   time.Duration
+	inputDelayMean   time.Duration
+	inputDelayStdev  time.Duration
+	spellQueueWindow time.Duration
+
+	// Runs the rotation once the player has reacted to an event.
+	reactionAction *PendingAction
+
+	// Set while the player has a free GCD and nothing to press, so whatever becomes
+	// available next must be reacted to rather than queued.
+	idle bool
+
+	// Set while reactionAction is running the rotation.
+	reacting bool
+}
+
+// Returns nil for an empty profile, so players without one behave exactly as before.
+func newLatencyModel(profile *proto.LatencyProfile) *latencyModel {
+	if profile == nil || (profile.NetworkLatencyMs <= 0 && profile.InputDelayMeanMs <= 0 && profile.InputDelayStdevMs <= 0) {
+		return nil
+	}
+
+	return &latencyModel{
+		networkLatency:   max(0, time.Duration(profile.NetworkLatencyMs)*time.Millisecond),
+		inputDelayMean:   max(0, time.Duration(profile.InputDelayMeanMs)*time.Millisecond),
+		inputDelayStdev:  max(0, time.Duration(profile.InputDelayStdevMs)*time.Millisecond),
+		spellQueueWindow: max(0, time.Duration(profile.SpellQueueWindowMs)*time.Millisecond),
+	}
+}
+
+func (lm *latencyModel) reset() {
+	lm.reactionAction = nil
+	lm.idle = false
+	lm.reacting = false
+}
+
+func (lm *latencyModel) inputDelay(sim *Simulation) time.Duration {
+	if lm.inputDelayStdev == 0 {
+		return lm.inputDelayMean
+	}
+	delay := float64(lm.inputDelayMean) + rand.New(sim.labelRand("Input Delay")).NormFloat64()*float64(lm.inputDelayStdev)
+	return max(0, time.Duration(delay))
+}
+
+// Delay after the end of a GCD or cast before the next action starts. The next press can be
+// sent during the spell queue window, which hides that much of the delay.
+func (lm *latencyModel) queuedDelay(sim *Simulation) time.Duration {
+	return max(0, lm.networkLatency+lm.inputDelay(sim)-lm.spellQueueWindow)
+}
+
+// Delay before responding to an event which can't be anticipated, like a proc or resource
+// gain: half the round trip for the event to reach the player, the input delay, and the
+// other half for the action to reach the server.
+func (lm *latencyModel) reactionDelay(sim *Simulation) time.Duration {
+	return lm.networkLatency + lm.inputDelay(sim)
+}
+
+// Whether the rotation should hold off on an action it just found, because the player
+// hasn't noticed it yet.
+func (lm *latencyModel) shouldDelayAction() bool {
+	return lm.idle && !lm.reacting
+}
+
+// Runs the rotation in response to an event such as a resource gain or proc. With a latency
+// profile the rotation runs after the player's reaction delay instead of straight away.
+func (unit *Unit) ReactToEvent(sim *Simulation) {
+	if unit.latency == nil {
+		unit.Rotation.DoNextAction(sim)
+		return
+	}
+	unit.scheduleReaction(sim)
+}
+
+func (unit *Unit) scheduleReaction(sim *Simulation) {
+	lm := unit.latency
+	if lm.reactionAction != nil && !lm.reactionAction.consumed && !lm.reactionAction.cancelled {
+		return
+	}
+
+	lm.reactionAction = &PendingAction{
+		NextActionAt: sim.CurrentTime + lm.reactionDelay(sim),
+		Priority:     ActionPriorityGCD,
+		OnAction: func(sim *Simulation) {
+			lm.reacting = true
+			unit.Rotation.DoNextAction(sim)
+			lm.reacting = false
+		},
+	}
+	sim.AddPendingAction(lm.reactionAction)
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestLatencyDelaysActionAfterGCD(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.latency = newLatencyModel(&proto.LatencyProfile{
+		NetworkLatencyMs:   150,
+		InputDelayMeanMs:   300,
+		SpellQueueWindowMs: 400,
+	})
+	fa.Spell.DefaultCast.GCD = GCDDefault
+	fa.Spell.castFn = fa.Spell.makeCastFunc(CastConfig{})
+
+	fa.Spell.Cast(sim, sim.GetTargetUnit(0))
+	if readyAt := fa.GCD.ReadyAt(); readyAt != GCDDefault {
+		t.Fatalf("Expected GCD ready at %s, got %s", GCDDefault, readyAt)
+	}
+	// 150ms latency + 300ms input delay, minus the 400ms queue window.
+	if expected := GCDDefault + time.Millisecond*50; fa.NextGCDAt() != expected {
+		t.Fatalf("Expected next action at %s, got %s", expected, fa.NextGCDAt())
+	}
+}
+
+func TestLatencyReaction(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.latency = newLatencyModel(&proto.LatencyProfile{
+		NetworkLatencyMs: 100,
+		InputDelayMeanMs: 200,
+	})
+	fa.Rotation = fa.newAPLRotation(nil)
+
+	fa.ReactToEvent(sim)
+	reaction := fa.latency.reactionAction
+	if reaction == nil || reaction.NextActionAt != time.Millisecond*300 {
+		t.Fatalf("Expected a reaction at 300ms, got %v", reaction)
+	}
+
+	// Events before the player has reacted share the same reaction.
+	fa.ReactToEvent(sim)
+	if fa.latency.reactionAction != reaction {
+		t.Fatalf("Expected a single pending reaction")
+	}
+}
+
+func TestEmptyLatencyProfile(t *testing.T) {
+	if newLatencyModel(nil) != nil || newLatencyModel(&proto.LatencyProfile{SpellQueueWindowMs: 400}) != nil {
+		t.Fatalf("Expected no latency model without any delays")
+	}
+}
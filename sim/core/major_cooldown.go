@@ -122,6 +122,9 @@ func (mcd *MajorCooldown) tryActivateInternal(sim *Simulation, character *Charac
 	return mcd.tryActivateHelper(sim, character)
 }
 
+// How early survival cooldowns are used ahead of a dangerous boss event.
+const dangerousEventLeadTime = time.Second
+
 func (mcd *MajorCooldown) shouldActivateHelper(sim *Simulation, character *Character) bool {
 	if !mcd.Spell.CanCast(sim, character.CurrentTarget) {
 		return false
@@ -131,8 +134,18 @@ func (mcd *MajorCooldown) shouldActivateHelper(sim *Simulation, character *Chara
 		return sim.CurrentTime >= mcd.timings[mcd.numUsages]
 	}
 
-	if mcd.Type.Matches(CooldownTypeSurvival) && character.cooldownConfigs.HpPercentForDefensives != 0 {
-		if character.CurrentHealthPercent() > character.cooldownConfigs.HpPercentForDefensives {
+	if mcd.Type.Matches(CooldownTypeSurvival) {
+		if event, ok := character.Env.NextDangerousEvent(sim, &character.Unit); ok {
+			if event.TimeUntil(sim) <= dangerousEventLeadTime {
+				return mcd.ShouldActivate(sim, character)
+			}
+			// Save the cooldown for the event, unless it will be ready again by then anyway.
+			if sim.CurrentTime+mcd.Spell.CD.Duration > event.Time-dangerousEventLeadTime {
+				return false
+			}
+		}
+
+		if character.cooldownConfigs.HpPercentForDefensives != 0 && character.CurrentHealthPercent() > character.cooldownConfigs.HpPercentForDefensives {
 			return false
 		}
 	}
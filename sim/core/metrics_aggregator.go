@@ -78,13 +78,14 @@ func NewDistributionMetrics() DistributionMetrics {
 }
 
 type UnitMetrics struct {
-	dps    DistributionMetrics
-	dpasp  DistributionMetrics
-	threat DistributionMetrics
-	dtps   DistributionMetrics
-	tmi    DistributionMetrics
-	hps    DistributionMetrics
-	tto    DistributionMetrics
+	dps      DistributionMetrics
+	dpasp    DistributionMetrics
+	threat   DistributionMetrics
+	dtps     DistributionMetrics
+	tmi      DistributionMetrics
+	hps      DistributionMetrics
+	tto      DistributionMetrics
+	absorbed DistributionMetrics
 
 	tmiList   []tmiListItem
 	isTanking bool
@@ -97,6 +98,7 @@ type UnitMetrics struct {
 	oomTimeSum   float64
 	actions      map[ActionID]*ActionMetrics
 	resources    []*ResourceMetrics
+	absorbs      []*AbsorbMetrics
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -156,6 +158,7 @@ type SpellMetrics struct {
 	TotalThreat    float64 // Threat generated by all casts of this spell.
 	TotalHealing   float64 // Healing done by all casts of this spell.
 	TotalShielding float64 // Shielding done by all casts of this spell.
+	TotalAbsorbed  float64 // Damage absorbed by shields from this spell.
 	TotalCastTime  time.Duration
 }
 
@@ -175,6 +178,7 @@ type TargetedActionMetrics struct {
 	Threat    float64
 	Healing   float64
 	Shielding float64
+	Absorbed  float64
 	CastTime  time.Duration
 }
 
@@ -194,20 +198,22 @@ func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
 		Threat:     tam.Threat,
 		Healing:    tam.Healing,
 		Shielding:  tam.Shielding,
+		Absorbed:   tam.Absorbed,
 		CastTimeMs: float64(tam.CastTime.Milliseconds()),
 	}
 }
 
 func NewUnitMetrics() UnitMetrics {
 	return UnitMetrics{
-		dps:     NewDistributionMetrics(),
-		dpasp:   NewDistributionMetrics(),
-		threat:  NewDistributionMetrics(),
-		dtps:    NewDistributionMetrics(),
-		tmi:     NewDistributionMetrics(),
-		hps:     NewDistributionMetrics(),
-		tto:     NewDistributionMetrics(),
-		actions: make(map[ActionID]*ActionMetrics),
+		dps:      NewDistributionMetrics(),
+		dpasp:    NewDistributionMetrics(),
+		threat:   NewDistributionMetrics(),
+		dtps:     NewDistributionMetrics(),
+		tmi:      NewDistributionMetrics(),
+		hps:      NewDistributionMetrics(),
+		tto:      NewDistributionMetrics(),
+		absorbed: NewDistributionMetrics(),
+		actions:  make(map[ActionID]*ActionMetrics),
 	}
 }
 
@@ -260,6 +266,46 @@ func (unitMetrics *UnitMetrics) NewResourceMetrics(actionID ActionID, resourceTy
 	return newMetrics
 }
 
+// Damage absorbed on a unit by a single source, summed over all iterations.
+type AbsorbMetrics struct {
+	ActionID        ActionID
+	SourceUnitIndex int32
+
+	Events   int32
+	Absorbed float64
+}
+
+func (absorbMetrics *AbsorbMetrics) ToProto() *proto.AbsorbMetrics {
+	return &proto.AbsorbMetrics{
+		Id:              absorbMetrics.ActionID.ToProto(),
+		SourceUnitIndex: absorbMetrics.SourceUnitIndex,
+		Events:          absorbMetrics.Events,
+		Absorbed:        absorbMetrics.Absorbed,
+	}
+}
+
+func (unitMetrics *UnitMetrics) addAbsorb(spell *Spell, absorbed float64) {
+	unitMetrics.absorbed.Total += absorbed
+
+	var absorbMetrics *AbsorbMetrics
+	for _, am := range unitMetrics.absorbs {
+		if am.ActionID == spell.ActionID && am.SourceUnitIndex == spell.Unit.UnitIndex {
+			absorbMetrics = am
+			break
+		}
+	}
+	if absorbMetrics == nil {
+		absorbMetrics = &AbsorbMetrics{
+			ActionID:        spell.ActionID,
+			SourceUnitIndex: spell.Unit.UnitIndex,
+		}
+		unitMetrics.absorbs = append(unitMetrics.absorbs, absorbMetrics)
+	}
+
+	absorbMetrics.Events++
+	absorbMetrics.Absorbed += absorbed
+}
+
 // Convenience helpers for NewResourceMetrics.
 func (unit *Unit) NewHealthMetrics(actionID ActionID) *ResourceMetrics {
 	return unit.Metrics.NewResourceMetrics(actionID, proto.ResourceType_ResourceTypeHealth)
@@ -326,6 +372,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.Threat += spellTargetMetrics.TotalThreat
 		tam.Healing += spellTargetMetrics.TotalHealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
+		tam.Absorbed += spellTargetMetrics.TotalAbsorbed
 		tam.CastTime += spellTargetMetrics.TotalCastTime
 
 		target := spell.Unit.AttackTables[i].Defender
@@ -374,6 +421,7 @@ func (unitMetrics *UnitMetrics) reset() {
 	unitMetrics.tmiList = nil
 	unitMetrics.hps.reset()
 	unitMetrics.tto.reset()
+	unitMetrics.absorbed.reset()
 	unitMetrics.CharacterIterationMetrics = CharacterIterationMetrics{}
 
 	for _, resourceMetrics := range unitMetrics.resources {
@@ -418,6 +466,7 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	unitMetrics.tmi.doneIteration(sim)
 	unitMetrics.hps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
+	unitMetrics.absorbed.doneIteration(sim)
 
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
 	if unitMetrics.Died {
@@ -507,6 +556,7 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Tmi:           unitMetrics.tmi.ToProto(),
 		Hps:           unitMetrics.hps.ToProto(),
 		Tto:           unitMetrics.tto.ToProto(),
+		Absorbed:      unitMetrics.absorbed.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
 	}
@@ -523,6 +573,11 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		}
 	}
 
+	protoMetrics.Absorbs = make([]*proto.AbsorbMetrics, 0, len(unitMetrics.absorbs))
+	for _, absorb := range unitMetrics.absorbs {
+		protoMetrics.Absorbs = append(protoMetrics.Absorbs, absorb.ToProto())
+	}
+
 	return protoMetrics
 }
 
@@ -35,6 +35,10 @@ type PendingAction struct {
 
 	cancelled bool
 	consumed  bool
+
+	// Unit whose spell scheduled this action, recorded as the applier of any aura it activates.
+	// Captured when the action is created, or when it's first added if it wasn't created by a constructor.
+	applier *Unit
 }
 
 func (pa *PendingAction) Cancel(sim *Simulation) {
@@ -24,6 +24,7 @@ func NewDelayedAction(sim *Simulation, options DelayedActionOptions) *PendingAct
 		Priority:     options.Priority,
 		OnAction:     options.OnAction,
 		CleanUp:      options.CleanUp,
+		applier:      sim.applyingUnit,
 	}
 }
 
@@ -61,6 +62,7 @@ func NewPeriodicAction(sim *Simulation, options PeriodicActionOptions) *PendingA
 		NextActionAt: sim.CurrentTime + options.Period,
 		Priority:     options.Priority,
 		CleanUp:      options.CleanUp,
+		applier:      sim.applyingUnit,
 	}
 
 	tickIndex := 0
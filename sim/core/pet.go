@@ -36,6 +36,9 @@ type Pet struct {
 	OnPetEnable  OnPetEnable
 	OnPetDisable OnPetDisable
 
+	// Owner spell which summons this pet, if any. Used by the APL summon pet action.
+	SummonSpell *Spell
+
 	// Calculates inherited stats based on owner stats or stat changes.
 	statInheritance        PetStatInheritance
 	dynamicStatInheritance PetStatInheritance
@@ -119,6 +122,17 @@ func (pet *Pet) IsGuardian() bool {
 	return pet.isGuardian
 }
 
+// Time until this pet despawns on its own. NeverExpires for permanent pets, 0 if not summoned.
+func (pet *Pet) RemainingDuration(sim *Simulation) time.Duration {
+	if !pet.enabled {
+		return 0
+	}
+	if pet.timeoutAction == nil {
+		return NeverExpires
+	}
+	return pet.timeoutAction.NextActionAt - sim.CurrentTime
+}
+
 // petAgent should be the PetAgent which embeds this Pet.
 func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 	if pet.enabled {
@@ -124,8 +124,8 @@ func (rb *rageBar) AddRage(sim *Simulation, amount float64, metrics *ResourceMet
 	}
 
 	rb.currentRage = newRage
-	if !sim.Options.Interactive {
-		rb.unit.Rotation.DoNextAction(sim)
+	if !rb.unit.IsInteractive(sim) {
+		rb.unit.ReactToEvent(sim)
 	}
 }
 
@@ -0,0 +1,439 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Each placement is a separate sim, so requests which would create more than
+// this are rejected.
+const maxSetPlacements = 2000
+
+type setAnalyzer struct {
+	SingleRaidSimRunner raidSimRunner
+	Request             *proto.SetAnalysisRequest
+}
+
+func SetAnalysis(ctx context.Context, request *proto.SetAnalysisRequest, progress chan *proto.ProgressMetrics) *proto.SetAnalysisResult {
+	analyzer := &setAnalyzer{
+		SingleRaidSimRunner: runSim,
+		Request:             request,
+	}
+
+	result, err := analyzer.Run(ctx, progress)
+	if err != nil {
+		result = &proto.SetAnalysisResult{
+			ErrorResult: err.Error(),
+		}
+	}
+	return result
+}
+
+// An item set being analyzed, and the piece it can use in each slot.
+type analyzedSet struct {
+	set        *ItemSet
+	thresholds []int32
+	pieces     map[proto.ItemSlot]*proto.ItemSpec
+}
+
+func (as *analyzedSet) contains(item Item) bool {
+	return item.SetName != "" && (item.SetName == as.set.Name || item.SetName == as.set.AlternativeName)
+}
+
+// Returns the pieces needed for the highest bonus reached with numPieces, 0 for none.
+func (as *analyzedSet) activeBonus(numPieces int32) int32 {
+	var active int32
+	for _, threshold := range as.thresholds {
+		if threshold <= numPieces {
+			active = threshold
+		}
+	}
+	return active
+}
+
+func findItemSet(name string) *ItemSet {
+	for _, set := range sets {
+		if set.Name == name || (set.AlternativeName != "" && set.AlternativeName == name) {
+			return set
+		}
+	}
+	return nil
+}
+
+// A set of slot assignments to simulate, and the bonuses they reach.
+type setPlacement struct {
+	bonuses []int32
+	items   []*itemWithSlot
+	key     string
+}
+
+func (analyzer *setAnalyzer) Run(ctx context.Context, progress chan *proto.ProgressMetrics) (result *proto.SetAnalysisResult, resultErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.SetAnalysisResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	baseSettings := goproto.Clone(analyzer.Request.GetBaseSettings()).(*proto.RaidSimRequest)
+	if len(baseSettings.GetRaid().GetParties()) == 0 || len(baseSettings.Raid.Parties[0].Players) != 1 {
+		return nil, fmt.Errorf("set analysis: expected exactly 1 player")
+	}
+	baseSettings.Raid.Parties = baseSettings.Raid.Parties[:1]
+	player := baseSettings.Raid.Parties[0].Players[0]
+	if player.GetDatabase() != nil {
+		addPlayerDatabase(player.GetDatabase())
+		player.Database = nil
+	}
+	if player.Equipment == nil {
+		player.Equipment = &proto.EquipmentSpec{}
+	}
+	for len(player.Equipment.Items) < len(proto.ItemSlot_name) {
+		player.Equipment.Items = append(player.Equipment.Items, nil)
+	}
+	for i, item := range player.Equipment.Items {
+		if item == nil {
+			player.Equipment.Items[i] = &proto.ItemSpec{}
+		}
+	}
+	if baseSettings.SimOptions == nil {
+		baseSettings.SimOptions = &proto.SimOptions{}
+	}
+	equipped := player.Equipment.Items
+
+	settings := analyzer.Request.GetBulkSettings()
+	if settings == nil {
+		settings = &proto.BulkSettings{}
+	}
+	iterations := settings.GetIterationsPerCombo()
+	if iterations <= 0 {
+		iterations = defaultIterationsPerCombo
+	}
+
+	analyzedSets, err := analyzer.getSets(player, settings)
+	if err != nil {
+		return nil, err
+	}
+	inAnalyzedSet := func(itemID int32) bool {
+		item, ok := ItemsByID[itemID]
+		if !ok {
+			return false
+		}
+		for _, as := range analyzedSets {
+			if as.contains(item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if progress == nil {
+		drainCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		progress = discardProgress(drainCtx)
+	}
+	runner := &bulkSimRunner{SingleRaidSimRunner: analyzer.SingleRaidSimRunner}
+
+	// First sim each non-set candidate on its own, to find the best one for each slot.
+	combos := []singleBulkSim{{req: baseSettings, cl: &raidSimRequestChangeLog{}, eq: &equipmentSubstitution{}}}
+	for _, candidate := range analyzer.Request.GetCandidates() {
+		itemID := candidate.GetItem().GetId()
+		if itemID == 0 || inAnalyzedSet(itemID) || equipped[candidate.Slot].Id == itemID {
+			continue
+		}
+		spec := goproto.Clone(candidate.Item).(*proto.ItemSpec)
+		if settings.AutoGem {
+			autoGemItem(settings, spec)
+		}
+		sub := &equipmentSubstitution{Items: []*itemWithSlot{{Item: spec, Slot: candidate.Slot}}}
+		req, changeLog := createNewRequestWithSubstitution(baseSettings, sub, settings.AutoEnchant)
+		if isValidEquipment(req.Raid.Parties[0].Players[0].Equipment) {
+			combos = append(combos, singleBulkSim{req: req, cl: changeLog, eq: sub})
+		}
+	}
+	simResults, baseResult, err := runner.getRankedResults(ctx, combos, int64(iterations), progress)
+	if err != nil {
+		return nil, err
+	}
+	equippedDps := baseResult.Score()
+
+	// Slots holding a set piece have no non-set option unless a candidate fills them.
+	// An empty slot is a valid option.
+	nonSetItems := make(map[proto.ItemSlot]*proto.ItemSpec)
+	nonSetScores := make(map[proto.ItemSlot]float64)
+	for i, item := range equipped {
+		if !inAnalyzedSet(item.Id) {
+			nonSetItems[proto.ItemSlot(i)] = item
+			nonSetScores[proto.ItemSlot(i)] = equippedDps
+		}
+	}
+	for _, simResult := range simResults {
+		if !simResult.Substitution.HasItemReplacements() {
+			continue
+		}
+		added := simResult.ChangeLog.AddedItems[0]
+		if score, ok := nonSetScores[added.Slot]; !ok || simResult.Score() > score {
+			nonSetItems[added.Slot] = added.Item
+			nonSetScores[added.Slot] = simResult.Score()
+		}
+	}
+
+	placements, err := analyzer.getPlacements(analyzedSets, nonSetItems, equipped)
+	if err != nil {
+		return nil, err
+	}
+
+	// Then sim each distinct placement. One matching the equipped gear needs no sim.
+	dpsByKey := make(map[string]float64)
+	keysByRequest := make(map[*proto.RaidSimRequest]string)
+	combos = nil
+	for _, placement := range placements {
+		if _, ok := dpsByKey[placement.key]; ok {
+			continue
+		}
+		if len(placement.items) == 0 {
+			dpsByKey[placement.key] = equippedDps
+			continue
+		}
+		sub := &equipmentSubstitution{Items: placement.items}
+		req, changeLog := createNewRequestWithSubstitution(baseSettings, sub, settings.AutoEnchant)
+		if !isValidEquipment(req.Raid.Parties[0].Players[0].Equipment) {
+			continue
+		}
+		dpsByKey[placement.key] = 0
+		keysByRequest[req] = placement.key
+		combos = append(combos, singleBulkSim{req: req, cl: changeLog, eq: sub})
+	}
+	if len(combos) > 0 {
+		simResults, _, err = runner.getRankedResults(ctx, combos, int64(iterations), progress)
+		if err != nil {
+			return nil, err
+		}
+		for _, simResult := range simResults {
+			dpsByKey[keysByRequest[simResult.Request]] = simResult.Score()
+		}
+	}
+
+	result = &proto.SetAnalysisResult{
+		EquippedDps: equippedDps,
+	}
+	configurations := make(map[string]*proto.SetConfigurationResult)
+	for _, placement := range placements {
+		dps, ok := dpsByKey[placement.key]
+		if !ok {
+			continue
+		}
+
+		configKey := fmt.Sprint(placement.bonuses)
+		configuration, ok := configurations[configKey]
+		if !ok {
+			configuration = &proto.SetConfigurationResult{}
+			for i, as := range analyzedSets {
+				configuration.Bonuses = append(configuration.Bonuses, &proto.SetBonusCount{
+					SetName:   as.set.Name,
+					NumPieces: placement.bonuses[i],
+				})
+			}
+			configurations[configKey] = configuration
+			result.Configurations = append(result.Configurations, configuration)
+		}
+
+		setPlacement := &proto.SetPlacement{Dps: dps}
+		for _, item := range placement.items {
+			setPlacement.Items = append(setPlacement.Items, &proto.ItemSpecWithSlot{Item: item.Item, Slot: item.Slot})
+		}
+		configuration.Placements = append(configuration.Placements, setPlacement)
+		if len(configuration.Placements) == 1 || dps > configuration.Dps {
+			configuration.Dps = dps
+			configuration.DpsDelta = dps - equippedDps
+		}
+	}
+	for _, configuration := range result.Configurations {
+		sort.SliceStable(configuration.Placements, func(a, b int) bool {
+			return configuration.Placements[a].Dps > configuration.Placements[b].Dps
+		})
+	}
+	sort.SliceStable(result.Configurations, func(a, b int) bool {
+		return result.Configurations[a].Dps > result.Configurations[b].Dps
+	})
+	return result, nil
+}
+
+// Resolves the requested sets, and picks the piece each set uses in each slot.
+func (analyzer *setAnalyzer) getSets(player *proto.Player, settings *proto.BulkSettings) ([]*analyzedSet, error) {
+	if len(analyzer.Request.GetSetNames()) == 0 {
+		return nil, fmt.Errorf("set analysis: no sets given")
+	}
+
+	equipped := player.Equipment.Items
+	allowed := make(map[int32]bool)
+	for _, id := range analyzer.Request.GetSetItemIds() {
+		allowed[id] = true
+	}
+
+	var analyzedSets []*analyzedSet
+	for _, name := range analyzer.Request.GetSetNames() {
+		set := findItemSet(name)
+		if set == nil {
+			return nil, fmt.Errorf("set analysis: unknown item set %q", name)
+		}
+		if slices.ContainsFunc(analyzedSets, func(as *analyzedSet) bool { return as.set == set }) {
+			continue
+		}
+
+		as := &analyzedSet{
+			set:    set,
+			pieces: make(map[proto.ItemSlot]*proto.ItemSpec),
+		}
+		for numPieces := range set.Bonuses {
+			as.thresholds = append(as.thresholds, numPieces)
+		}
+		slices.Sort(as.thresholds)
+
+		chosen := make(map[proto.ItemSlot]Item)
+		for _, item := range set.Items() {
+			if !canEquipItem(item, player.Class) {
+				continue
+			}
+			for _, slot := range EligibleSlotsForItem(item) {
+				isEquipped := equipped[slot].Id == item.ID
+				if len(allowed) > 0 && !allowed[item.ID] && !isEquipped {
+					continue
+				}
+				current, ok := chosen[slot]
+				if !ok || isEquipped || (equipped[slot].Id != current.ID && item.Ilvl >= current.Ilvl) {
+					chosen[slot] = item
+				}
+			}
+		}
+		if len(chosen) == 0 {
+			return nil, fmt.Errorf("set analysis: no usable pieces of %s", set.Name)
+		}
+
+		for slot, item := range chosen {
+			if equipped[slot].Id == item.ID {
+				as.pieces[slot] = equipped[slot]
+				continue
+			}
+			spec := &proto.ItemSpec{Id: item.ID}
+			if settings.AutoGem {
+				autoGemItem(settings, spec)
+			}
+			as.pieces[slot] = spec
+		}
+		analyzedSets = append(analyzedSets, as)
+	}
+	return analyzedSets, nil
+}
+
+// Enumerates every way of filling the slots with set pieces or the best non-set
+// items, skipping those where a set piece adds nothing over a non-set item.
+func (analyzer *setAnalyzer) getPlacements(analyzedSets []*analyzedSet, nonSetItems map[proto.ItemSlot]*proto.ItemSpec, equipped []*proto.ItemSpec) ([]*setPlacement, error) {
+	const nonSet = -1
+
+	// The options of each slot which can hold a set piece.
+	var setSlots []proto.ItemSlot
+	options := make(map[proto.ItemSlot][]int)
+	for slot := range equipped {
+		itemSlot := proto.ItemSlot(slot)
+		var slotOptions []int
+		if _, ok := nonSetItems[itemSlot]; ok {
+			slotOptions = append(slotOptions, nonSet)
+		}
+		for i, as := range analyzedSets {
+			if _, ok := as.pieces[itemSlot]; ok {
+				slotOptions = append(slotOptions, i)
+			}
+		}
+		if len(slotOptions) > 1 || (len(slotOptions) == 1 && slotOptions[0] != nonSet) {
+			setSlots = append(setSlots, itemSlot)
+			options[itemSlot] = slotOptions
+		}
+	}
+
+	var placements []*setPlacement
+	choices := make(map[proto.ItemSlot]int)
+	counts := make([]int32, len(analyzedSets))
+	usedIDs := make(map[int32]bool)
+
+	addPlacement := func() error {
+		bonuses := make([]int32, len(analyzedSets))
+		for i, as := range analyzedSets {
+			bonuses[i] = as.activeBonus(counts[i])
+			if counts[i] == bonuses[i] {
+				continue
+			}
+			// A piece over the bonus only counts if it can't be swapped for a non-set item.
+			for slot, choice := range choices {
+				if _, ok := nonSetItems[slot]; ok && choice == i {
+					return nil
+				}
+			}
+		}
+
+		placement := &setPlacement{bonuses: bonuses}
+		var keyParts []string
+		for slot := range equipped {
+			itemSlot := proto.ItemSlot(slot)
+			spec := nonSetItems[itemSlot]
+			if choice, ok := choices[itemSlot]; ok && choice != nonSet {
+				spec = analyzedSets[choice].pieces[itemSlot]
+			}
+			if spec == nil || goproto.Equal(spec, equipped[slot]) {
+				continue
+			}
+			placement.items = append(placement.items, &itemWithSlot{Item: spec, Slot: itemSlot})
+			keyParts = append(keyParts, fmt.Sprintf("%d:%d", slot, spec.Id))
+		}
+		placement.key = strings.Join(keyParts, ",")
+
+		placements = append(placements, placement)
+		if len(placements) > maxSetPlacements {
+			return fmt.Errorf("set analysis: more than %d placements, use fewer sets or set pieces", maxSetPlacements)
+		}
+		return nil
+	}
+
+	var enumerate func(i int) error
+	enumerate = func(i int) error {
+		if i == len(setSlots) {
+			return addPlacement()
+		}
+		slot := setSlots[i]
+		for _, choice := range options[slot] {
+			var pieceID int32
+			if choice != nonSet {
+				pieceID = analyzedSets[choice].pieces[slot].Id
+				if usedIDs[pieceID] {
+					continue
+				}
+				usedIDs[pieceID] = true
+				counts[choice]++
+			}
+			choices[slot] = choice
+			err := enumerate(i + 1)
+			if choice != nonSet {
+				usedIDs[pieceID] = false
+				counts[choice]--
+			}
+			if err != nil {
+				return err
+			}
+		}
+		delete(choices, slot)
+		return nil
+	}
+	if err := enumerate(0); err != nil {
+		return nil, err
+	}
+	return placements, nil
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestSetAnalysis(t *testing.T) {
+	const setName = "Test Analysis Regalia"
+	slotTypes := map[proto.ItemSlot]proto.ItemType{
+		proto.ItemSlot_ItemSlotHead:     proto.ItemType_ItemTypeHead,
+		proto.ItemSlot_ItemSlotShoulder: proto.ItemType_ItemTypeShoulder,
+		proto.ItemSlot_ItemSlotChest:    proto.ItemType_ItemTypeChest,
+		proto.ItemSlot_ItemSlotHands:    proto.ItemType_ItemTypeHands,
+		proto.ItemSlot_ItemSlotLegs:     proto.ItemType_ItemTypeLegs,
+	}
+
+	// Equipped items are worth 20 DPS each, set pieces 10 and the hands candidate 40.
+	itemDps := map[int32]float64{}
+	equipment := &proto.EquipmentSpec{}
+	for slot, itemType := range slotTypes {
+		nonSetID := 9000300 + int32(slot)
+		setID := 9000400 + int32(slot)
+		ItemsByID[nonSetID] = Item{ID: nonSetID, Type: itemType}
+		ItemsByID[setID] = Item{ID: setID, Type: itemType, SetName: setName}
+		defer delete(ItemsByID, nonSetID)
+		defer delete(ItemsByID, setID)
+		itemDps[nonSetID] = 20
+		itemDps[setID] = 10
+
+		for len(equipment.Items) <= int(slot) {
+			equipment.Items = append(equipment.Items, &proto.ItemSpec{})
+		}
+		equipment.Items[slot] = &proto.ItemSpec{Id: nonSetID}
+	}
+	const handsCandidateID = 9000500
+	ItemsByID[handsCandidateID] = Item{ID: handsCandidateID, Type: proto.ItemType_ItemTypeHands}
+	defer delete(ItemsByID, handsCandidateID)
+	itemDps[handsCandidateID] = 40
+
+	sets = append(sets, &ItemSet{Name: setName, Bonuses: map[int32]ApplyEffect{2: nil, 4: nil}})
+	defer func() { sets = sets[:len(sets)-1] }()
+
+	fakeRunSim := func(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool) *proto.RaidSimResult {
+		dps := 1000.0
+		numPieces := 0
+		for _, item := range rsr.Raid.Parties[0].Players[0].Equipment.Items {
+			dps += itemDps[item.Id]
+			if ItemsByID[item.Id].SetName == setName {
+				numPieces++
+			}
+		}
+		if numPieces >= 2 {
+			dps += 30
+		}
+		if numPieces >= 4 {
+			dps += 100
+		}
+		return &proto.RaidSimResult{RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: dps}}}
+	}
+
+	analyzer := &setAnalyzer{
+		SingleRaidSimRunner: fakeRunSim,
+		Request: &proto.SetAnalysisRequest{
+			BaseSettings: &proto.RaidSimRequest{
+				Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{
+					Class:     proto.Class_ClassMage,
+					Equipment: equipment,
+				}}}}},
+			},
+			SetNames: []string{setName},
+			Candidates: []*proto.ItemSpecWithSlot{
+				{Item: &proto.ItemSpec{Id: handsCandidateID}, Slot: proto.ItemSlot_ItemSlotHands},
+			},
+			BulkSettings: &proto.BulkSettings{IterationsPerCombo: 1},
+		},
+	}
+
+	result, err := analyzer.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Set analysis failed: %v", err)
+	}
+	if result.ErrorResult != "" {
+		t.Fatal(result.ErrorResult)
+	}
+	if result.EquippedDps != 1100 {
+		t.Fatalf("Expected equipped DPS 1100, got %f", result.EquippedDps)
+	}
+
+	// 3 or 5 pieces always waste one over a non-set item, so only 0, 2 and 4 are reported.
+	expected := []struct {
+		numPieces     int32
+		dps           float64
+		numPlacements int
+	}{
+		{4, 1210, 5},
+		{2, 1130, 10},
+		{0, 1120, 1},
+	}
+	if len(result.Configurations) != len(expected) {
+		t.Fatalf("Expected %d configurations, got %v", len(expected), result.Configurations)
+	}
+	for i, exp := range expected {
+		configuration := result.Configurations[i]
+		if configuration.Bonuses[0].NumPieces != exp.numPieces || configuration.Dps != exp.dps || len(configuration.Placements) != exp.numPlacements {
+			t.Fatalf("Unexpected configuration %d: %v", i, configuration)
+		}
+	}
+
+	// The best 4 piece placement leaves the hands to the candidate.
+	best := result.Configurations[0].Placements[0]
+	if configuration := result.Configurations[0]; configuration.DpsDelta != 110 || len(best.Items) != 5 {
+		t.Fatalf("Unexpected best placement: %v", best)
+	}
+	for _, item := range best.Items {
+		if item.Slot == proto.ItemSlot_ItemSlotHands && item.Item.Id != handsCandidateID {
+			t.Fatalf("Expected the hands candidate in the best placement, got %v", item.Item)
+		}
+	}
+}
@@ -7,6 +7,15 @@ type ShieldConfig struct {
 
 	Spell *Spell
 
+	// Only damage from these schools is absorbed. Defaults to all schools.
+	School SpellSchool
+
+	// Fraction of each hit that can be absorbed. Defaults to the full hit.
+	AbsorbFraction func(spell *Spell) float64
+
+	// Invoked whenever this shield absorbs damage.
+	OnAbsorb func(sim *Simulation, shield *Shield, spell *Spell, result *SpellResult, absorbed float64)
+
 	Aura
 }
 
@@ -14,6 +23,13 @@ type ShieldConfig struct {
 type Shield struct {
 	Spell *Spell
 
+	School         SpellSchool
+	AbsorbFraction func(spell *Spell) float64
+	OnAbsorb       func(sim *Simulation, shield *Shield, spell *Spell, result *SpellResult, absorbed float64)
+
+	// Absorb remaining on the currently active shield.
+	remaining float64
+
 	// Embed Aura so we can use IsActive/Refresh/etc directly.
 	*Aura
 }
@@ -21,22 +37,107 @@ type Shield struct {
 func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 	caster := shield.Spell.Unit
 	target := shield.Aura.Unit
-	//attackTable := caster.AttackTables[target.UnitIndex]
 
 	// Shields are not affected by healing pseudostats the same way heals are.
 	// So we only apply the spell-specific multiplier.
 	shieldAmount *= shield.Spell.DamageMultiplier
 
+	// Reapplying a shield replaces the old one and moves it to the back of the absorb stack.
 	shield.Aura.Deactivate(sim)
 	shield.Aura.Activate(sim)
+	shield.remaining = shieldAmount
+	target.absorbShields = append(target.absorbShields, shield)
 
-	threat := 0.0 // TODO
-	shield.Spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
 	shield.Spell.SpellMetrics[target.UnitIndex].Hits++
 
 	if sim.Log != nil {
-		caster.Log(sim, "%s %s Hit for %0.3f shielding. (Threat: %0.3f)", target.LogLabel(), shield.Spell.ActionID, shieldAmount, threat)
+		caster.Log(sim, "%s %s Hit for %0.3f shielding.", target.LogLabel(), shield.Spell.ActionID, shieldAmount)
+	}
+}
+
+// Returns the absorb left on this shield, or 0 if it isn't active.
+func (shield *Shield) Remaining() float64 {
+	if !shield.Aura.IsActive() {
+		return 0
+	}
+	return shield.remaining
+}
+
+// Absorbs as much of result.Damage as this shield allows, and returns the amount absorbed.
+func (shield *Shield) absorb(sim *Simulation, spell *Spell, result *SpellResult) float64 {
+	if shield.School != SpellSchoolNone && !spell.SpellSchool.Matches(shield.School) {
+		return 0
+	}
+
+	absorbable := result.Damage
+	if shield.AbsorbFraction != nil {
+		absorbable *= shield.AbsorbFraction(spell)
+	}
+	absorbed := min(absorbable, shield.remaining)
+	if absorbed <= 0 {
+		return 0
+	}
+
+	result.Damage -= absorbed
+	shield.remaining -= absorbed
+
+	threat := shield.Spell.ThreatFromAbsorb(absorbed)
+	shield.Spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += threat
+	shield.Spell.RecordAbsorb(result.Target, absorbed)
+
+	if sim.Log != nil {
+		shield.Spell.Unit.Log(sim, "%s %s absorbed %0.3f damage from %s, %0.3f remaining. (Threat: %0.3f)", result.Target.LogLabel(), shield.Spell.ActionID, absorbed, spell.ActionID, shield.remaining, threat)
+	}
+
+	if shield.OnAbsorb != nil {
+		shield.OnAbsorb(sim, shield, spell, result, absorbed)
+	}
+
+	if shield.remaining <= 0 {
+		shield.Aura.Deactivate(sim)
+	}
+	return absorbed
+}
+
+// Absorbs generate threat for the caster the same way healing does, from the
+// spell and caster threat multipliers. It is counted as damage is absorbed rather
+// than when the shield is applied, so unused absorb generates none. There is no
+// hit outcome to check and flat threat bonuses belong to the cast, not the absorb.
+func (spell *Spell) ThreatFromAbsorb(absorbed float64) float64 {
+	return absorbed * spell.ThreatMultiplier * spell.Unit.PseudoStats.ThreatMultiplier
+}
+
+// Records damage prevented on target by this spell. Shields call this automatically;
+// it can also be used by effects which reduce damage without being a Shield, so they
+// show up as absorbs in metrics.
+func (spell *Spell) RecordAbsorb(target *Unit, absorbed float64) {
+	spell.SpellMetrics[target.UnitIndex].TotalAbsorbed += absorbed
+	target.Metrics.addAbsorb(spell, absorbed)
+}
+
+// Consumes active shields on this unit in the order they were applied.
+func (unit *Unit) applyAbsorbs(sim *Simulation, spell *Spell, result *SpellResult) {
+	if len(unit.absorbShields) == 0 || result.Damage <= 0 {
+		return
+	}
+
+	for i := 0; i < len(unit.absorbShields) && result.Damage > 0; {
+		shield := unit.absorbShields[i]
+		shield.absorb(sim, spell, result)
+		// Broken shields remove themselves from the stack.
+		if i < len(unit.absorbShields) && unit.absorbShields[i] == shield {
+			i++
+		}
+	}
+}
+
+func (unit *Unit) removeAbsorbShield(aura *Aura) {
+	for i, shield := range unit.absorbShields {
+		if shield.Aura == aura {
+			unit.absorbShields = append(unit.absorbShields[:i], unit.absorbShields[i+1:]...)
+			return
+		}
 	}
 }
 
@@ -62,13 +163,23 @@ func (spell *Spell) createShields(config ShieldConfig) {
 		config.Spell = spell
 	}
 	shield := Shield{
-		Spell: config.Spell,
+		Spell:          config.Spell,
+		School:         config.School,
+		AbsorbFraction: config.AbsorbFraction,
+		OnAbsorb:       config.OnAbsorb,
 	}
 
 	auraConfig := config.Aura
 	if auraConfig.ActionID.IsEmptyAction() {
 		auraConfig.ActionID = shield.Spell.ActionID
 	}
+	oldOnExpire := auraConfig.OnExpire
+	auraConfig.OnExpire = func(aura *Aura, sim *Simulation) {
+		aura.Unit.removeAbsorbShield(aura)
+		if oldOnExpire != nil {
+			oldOnExpire(aura, sim)
+		}
+	}
 
 	caster := shield.Spell.Unit
 	if config.SelfOnly {
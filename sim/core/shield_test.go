@@ -0,0 +1,197 @@
+package core
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_ProtectionPaladin{},
+		proto.Spec_SpecProtectionPaladin,
+		newFakeShieldUser,
+		func(player *proto.Player, spec interface{}) {
+			player.Spec = spec.(*proto.Player_ProtectionPaladin)
+		},
+	)
+}
+
+// A player with self shields absorbing magic, physical and any damage, and a shadow
+// spell to take damage from.
+type fakeShieldUser struct {
+	Character
+	damageSpell *Spell
+	shields     []*Shield
+}
+
+func (fsu *fakeShieldUser) GetCharacter() *Character {
+	return &fsu.Character
+}
+
+func (fsu *fakeShieldUser) Initialize() {
+	fsu.damageSpell = fsu.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 42},
+		SpellSchool: SpellSchoolShadow,
+		ProcMask:    ProcMaskSpellDamage,
+		Flags:       SpellFlagIgnoreResists,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	for i, school := range []SpellSchool{SpellSchoolMagic, SpellSchoolPhysical, SpellSchoolNone} {
+		shieldSpell := fsu.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 43, Tag: int32(i)},
+			SpellSchool: SpellSchoolHoly,
+			ProcMask:    ProcMaskSpellHealing,
+			Flags:       SpellFlagHelpful,
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 0.5,
+
+			Shield: ShieldConfig{
+				SelfOnly: true,
+				School:   school,
+				Aura: Aura{
+					Label:    "fakeshield-" + strconv.Itoa(i),
+					Duration: time.Second * 30,
+				},
+			},
+		})
+		fsu.shields = append(fsu.shields, shieldSpell.SelfShield())
+	}
+}
+
+func (fsu *fakeShieldUser) ApplyTalents()            {}
+func (fsu *fakeShieldUser) Reset(_ *Simulation)      {}
+func (fsu *fakeShieldUser) OnGCDReady(_ *Simulation) {}
+
+func newFakeShieldUser(char *Character, _ *proto.Player) Agent {
+	return &fakeShieldUser{
+		Character: *char,
+	}
+}
+
+func setupFakeShieldUser() (*Simulation, *fakeShieldUser) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{{
+						Name:      "Shielded",
+						Class:     proto.Class_ClassPaladin,
+						Race:      proto.Race_RaceHuman,
+						Consumes:  &proto.Consumes{},
+						Buffs:     &proto.IndividualBuffs{},
+						Spec:      &proto.Player_ProtectionPaladin{},
+						Equipment: &proto.EquipmentSpec{},
+					}},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets:  []*proto.Target{{Name: "target", Level: 83}},
+			Duration: 180,
+		},
+	})
+	sim.Reset()
+
+	return sim, sim.Raid.Parties[0].Players[0].(*fakeShieldUser)
+}
+
+func expectAbsorb(t *testing.T, sim *Simulation, fsu *fakeShieldUser, damage float64, expectedDamage float64) {
+	result := fsu.damageSpell.NewResult(&fsu.Unit)
+	result.Damage = damage
+	fsu.Unit.applyAbsorbs(sim, fsu.damageSpell, result)
+
+	if !WithinToleranceFloat64(expectedDamage, result.Damage, 0.01) {
+		t.Fatalf("Incorrect damage after absorbs: Expected: %0.3f, Actual: %0.3f", expectedDamage, result.Damage)
+	}
+}
+
+func TestShieldAbsorbOrder(t *testing.T) {
+	sim, fsu := setupFakeShieldUser()
+	magicShield, anyShield := fsu.shields[0], fsu.shields[2]
+
+	magicShield.Apply(sim, 100)
+	anyShield.Apply(sim, 50)
+
+	// The oldest shield is consumed first, and breaks once depleted.
+	expectAbsorb(t, sim, fsu, 120, 0)
+	if magicShield.IsActive() {
+		t.Fatalf("Depleted shield should have been removed")
+	}
+	if anyShield.Remaining() != 30 {
+		t.Fatalf("Incorrect remaining absorb: Expected: 30, Actual: %0.3f", anyShield.Remaining())
+	}
+
+	// Partial absorption once the stack runs out.
+	expectAbsorb(t, sim, fsu, 50, 20)
+	if anyShield.IsActive() || len(fsu.Unit.absorbShields) != 0 {
+		t.Fatalf("Absorb stack should be empty")
+	}
+
+	if fsu.Metrics.absorbed.Total != 150 {
+		t.Fatalf("Incorrect absorbed metrics: Expected: 150, Actual: %0.3f", fsu.Metrics.absorbed.Total)
+	}
+}
+
+func TestShieldSchool(t *testing.T) {
+	sim, fsu := setupFakeShieldUser()
+	physicalShield := fsu.shields[1]
+
+	physicalShield.Apply(sim, 100)
+
+	// Shadow damage passes through a physical-only shield.
+	expectAbsorb(t, sim, fsu, 80, 80)
+	if physicalShield.Remaining() != 100 {
+		t.Fatalf("Incorrect remaining absorb: Expected: 100, Actual: %0.3f", physicalShield.Remaining())
+	}
+}
+
+func TestShieldThreat(t *testing.T) {
+	sim, fsu := setupFakeShieldUser()
+	shield := fsu.shields[2]
+
+	// Applying a shield generates no threat by itself.
+	shield.Apply(sim, 100)
+	if threat := shield.Spell.SpellMetrics[fsu.UnitIndex].TotalThreat; threat != 0 {
+		t.Fatalf("Expected no threat from applying a shield, got %0.3f", threat)
+	}
+
+	// Absorbed damage generates threat like healing, scaled by the shield spell's threat multiplier.
+	expectAbsorb(t, sim, fsu, 60, 0)
+	if threat := shield.Spell.SpellMetrics[fsu.UnitIndex].TotalThreat; threat != 30 {
+		t.Fatalf("Incorrect absorb threat: Expected: 30, Actual: %0.3f", threat)
+	}
+}
+
+func TestShieldAfterDamageReduction(t *testing.T) {
+	sim, fsu := setupFakeShieldUser()
+	shield := fsu.shields[2]
+
+	// Added after finalization, so restore the modifiers for anything else using this unit.
+	modifiers := fsu.Unit.DynamicDamageTakenModifiers
+	defer func() { fsu.Unit.DynamicDamageTakenModifiers = modifiers }()
+	fsu.Unit.DynamicDamageTakenModifiers = append(modifiers[:len(modifiers):len(modifiers)], func(_ *Simulation, _ *Spell, result *SpellResult) {
+		result.Damage *= 0.8
+	})
+
+	shield.Apply(sim, 100)
+
+	// The shield only soaks the damage left after the 20% reduction.
+	result := fsu.damageSpell.NewResult(&fsu.Unit)
+	result.Damage = 100
+	fsu.damageSpell.ApplyPostOutcomeDamageModifiers(sim, result)
+	if result.Damage != 0 || shield.Remaining() != 20 {
+		t.Fatalf("Expected the shield to absorb 80 of the reduced hit, got damage %0.3f with %0.3f remaining", result.Damage, shield.Remaining())
+	}
+	if fsu.Metrics.absorbed.Total != 80 {
+		t.Fatalf("Incorrect absorbed metrics: Expected: 80, Actual: %0.3f", fsu.Metrics.absorbed.Total)
+	}
+}
@@ -59,6 +59,9 @@ type Simulation struct {
 
 	minTaskTime time.Duration
 	tasks       []Task
+
+	// Unit whose spell is currently applying its effects, used to track which unit applied each aura.
+	applyingUnit *Unit
 }
 
 func (sim *Simulation) rescheduleTracker(trackerTime time.Duration) {
@@ -136,6 +139,18 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}()
 	}
 
+	if err := validateRaidConsumes(rsr.Raid); err != nil {
+		result = &proto.RaidSimResult{
+			ErrorResult: err.Error(),
+		}
+		if progress != nil {
+			progress <- &proto.ProgressMetrics{
+				FinalRaidResult: result,
+			}
+		}
+		return result
+	}
+
 	sim := NewSim(rsr)
 
 	if !skipPresim {
@@ -500,7 +515,9 @@ func (sim *Simulation) Step() bool {
 	if pa.cancelled {
 		return false
 	}
+	sim.applyingUnit = pa.applier
 	pa.OnAction(sim)
+	sim.applyingUnit = nil
 	return false
 }
 
@@ -580,6 +597,10 @@ func (sim *Simulation) AddPendingAction(pa *PendingAction) {
 	//	panic(fmt.Sprintf("Cant add action in the past: %s", pa.NextActionAt))
 	//}
 	pa.consumed = false
+	if pa.applier == nil {
+		// Actions built without NewDelayedAction or NewPeriodicAction, e.g. GCD and hardcast actions.
+		pa.applier = sim.applyingUnit
+	}
 	for index, v := range sim.pendingActions[1:] {
 		if v.NextActionAt < pa.NextActionAt || (v.NextActionAt == pa.NextActionAt && v.Priority >= pa.Priority) {
 			//if sim.Log != nil {
@@ -530,7 +530,10 @@ func (spell *Spell) applyEffects(sim *Simulation, target *Unit) {
 	spell.SpellMetrics[target.UnitIndex].Casts++
 	spell.casts++
 
+	prevApplyingUnit := sim.applyingUnit
+	sim.applyingUnit = spell.Unit
 	spell.ApplyEffects(sim, target, spell)
+	sim.applyingUnit = prevApplyingUnit
 }
 
 func (spell *Spell) ApplyAOEThreatIgnoreMultipliers(threatAmount float64) {
@@ -160,6 +160,8 @@ func (spell *Spell) ApplyPostOutcomeDamageModifiers(sim *Simulation, result *Spe
 	for i := range result.Target.DynamicDamageTakenModifiers {
 		result.Target.DynamicDamageTakenModifiers[i](sim, spell, result)
 	}
+	// Shields absorb what is left after damage reductions.
+	result.Target.applyAbsorbs(sim, spell, result)
 	result.Damage = max(0, result.Damage)
 }
 
@@ -113,6 +113,12 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		swr.Player.BonusStats.PseudoStats = make([]float64, stats.PseudoStatsLen)
 	}
 
+	if swr.Player.Database != nil {
+		addPlayerDatabase(swr.Player.Database)
+	}
+	// The sims below run concurrently, so they mustn't each add the database again.
+	swr.Player.Database = nil
+
 	raidProto := SinglePlayerRaidProto(swr.Player, swr.PartyBuffs, swr.RaidBuffs, swr.Debuffs)
 	raidProto.Tanks = swr.Tanks
 
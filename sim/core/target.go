@@ -108,6 +108,8 @@ type Target struct {
 	Unit
 
 	AI TargetAI
+
+	dangerousEventPredictors []DangerousEventPredictor
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
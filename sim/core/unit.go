@@ -125,6 +125,12 @@ type Unit struct {
 
 	Rotation *APLRotation
 
+	// Set when an external agent chooses this unit's actions instead of its rotation.
+	interactive bool
+
+	// Delays applied to this unit's actions, or nil to act instantly.
+	latency *latencyModel
+
 	// Statistics describing the results of the sim.
 	Metrics UnitMetrics
 
@@ -133,6 +139,9 @@ type Unit struct {
 	AttackTables                []*AttackTable
 	DynamicDamageTakenModifiers []DynamicDamageTakenModifier
 
+	// Active absorb shields, in the order they are consumed.
+	absorbShields []*Shield
+
 	GCD *Timer
 
 	// Used for applying the effect of a hardcast spell when casting finishes.
@@ -433,6 +442,10 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	unit.resetCDs(sim)
 	unit.Hardcast.Expires = startingCDTime
 	unit.ChanneledDot = nil
+	if unit.latency != nil {
+		unit.latency.reset()
+	}
+	unit.absorbShields = unit.absorbShields[:0]
 	unit.Metrics.reset()
 	unit.ResetStatDeps()
 	unit.statsWithoutDeps = unit.initialStatsWithoutDeps
@@ -467,6 +480,11 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	}
 }
 
+// Returns whether this unit waits for external input rather than running its rotation.
+func (unit *Unit) IsInteractive(sim *Simulation) bool {
+	return unit.interactive || sim.Options.Interactive
+}
+
 func (unit *Unit) startPull(sim *Simulation) {
 	unit.AutoAttacks.startPull(sim)
 
@@ -485,6 +503,10 @@ func (unit *Unit) doneIteration(sim *Simulation) {
 	for _, spell := range unit.Spellbook {
 		spell.doneIteration()
 	}
+
+	if unit.Rotation != nil {
+		unit.Rotation.doneIteration(sim)
+	}
 }
 
 func (unit *Unit) GetSpellsMatchingSchool(school SpellSchool) []*Spell {
@@ -0,0 +1,328 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"sort"
+
+	goproto "google.golang.org/protobuf/proto"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Item data from the UI database which isn't needed to run the sim, but is used
+// to find upgrades by where they drop.
+type lootItem struct {
+	phase          int32
+	classAllowlist []proto.Class
+	drops          []*proto.DropSource
+}
+
+var lootItemsByID = map[int32]*lootItem{}
+
+// Items which can only be equipped once, checked by hasDuplicateUniqueItems.
+var uniqueItemIDs = map[int32]bool{}
+var lootNPCsByID = map[int32]*proto.UINPC{}
+var lootZonesByID = map[int32]*proto.UIZone{}
+
+func addToLootDatabase(db *proto.UIDatabase) {
+	for _, item := range db.Items {
+		if item.Unique {
+			uniqueItemIDs[item.Id] = true
+		}
+
+		var drops []*proto.DropSource
+		for _, source := range item.Sources {
+			if drop := source.GetDrop(); drop != nil {
+				drops = append(drops, drop)
+			}
+		}
+		if len(drops) == 0 {
+			continue
+		}
+		lootItemsByID[item.Id] = &lootItem{
+			phase:          item.Phase,
+			classAllowlist: item.ClassAllowlist,
+			drops:          drops,
+		}
+	}
+	for _, npc := range db.Npcs {
+		lootNPCsByID[npc.Id] = npc
+	}
+	for _, zone := range db.Zones {
+		lootZonesByID[zone.Id] = zone
+	}
+}
+
+// Returns true if equipment has a unique-equipped item, e.g. a weapon, in more than one slot.
+func hasDuplicateUniqueItems(equipment *proto.EquipmentSpec) bool {
+	seen := make(map[int32]bool)
+	for _, item := range equipment.Items {
+		if item == nil || !uniqueItemIDs[item.Id] {
+			continue
+		}
+		if seen[item.Id] {
+			return true
+		}
+		seen[item.Id] = true
+	}
+	return false
+}
+
+type upgradeFinderRunner struct {
+	Request *proto.UpgradeFinderRequest
+}
+
+func UpgradeFinder(ctx context.Context, request *proto.UpgradeFinderRequest, progress chan *proto.ProgressMetrics) *proto.UpgradeFinderResult {
+	finder := &upgradeFinderRunner{
+		Request: request,
+	}
+
+	result, err := finder.Run(ctx, progress)
+	if err != nil {
+		result = &proto.UpgradeFinderResult{
+			ErrorResult: err.Error(),
+		}
+	}
+	return result
+}
+
+// A single item swap, and the drops of that item which match the request.
+type upgradeCandidate struct {
+	item  *itemWithSlot
+	drops []*proto.DropSource
+}
+
+func (finder *upgradeFinderRunner) Run(ctx context.Context, progress chan *proto.ProgressMetrics) (result *proto.UpgradeFinderResult, resultErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.UpgradeFinderResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	baseSettings := goproto.Clone(finder.Request.GetBaseSettings()).(*proto.RaidSimRequest)
+	if len(baseSettings.GetRaid().GetParties()) == 0 || len(baseSettings.Raid.Parties[0].Players) != 1 {
+		return nil, fmt.Errorf("upgrade finder: expected exactly 1 player")
+	}
+	// Swaps only change the first player, so the rest of the raid doesn't need to be simmed.
+	baseSettings.Raid.Parties = baseSettings.Raid.Parties[:1]
+	player := baseSettings.Raid.Parties[0].Players[0]
+	if player.GetDatabase() != nil {
+		addPlayerDatabase(player.GetDatabase())
+		player.Database = nil
+	}
+	if player.Equipment == nil {
+		player.Equipment = &proto.EquipmentSpec{}
+	}
+	for len(player.Equipment.Items) < len(proto.ItemSlot_name) {
+		player.Equipment.Items = append(player.Equipment.Items, nil)
+	}
+	for i, item := range player.Equipment.Items {
+		if item == nil {
+			player.Equipment.Items[i] = &proto.ItemSpec{}
+		}
+	}
+
+	settings := finder.Request.GetBulkSettings()
+	if settings == nil {
+		settings = &proto.BulkSettings{}
+	}
+	iterations := settings.GetIterationsPerCombo()
+	if iterations <= 0 {
+		iterations = defaultIterationsPerCombo
+	}
+
+	candidates := finder.getCandidates(player)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("upgrade finder: no usable drops match the filters")
+	}
+
+	combos := []singleBulkSim{{req: baseSettings, cl: &raidSimRequestChangeLog{}, eq: &equipmentSubstitution{}}}
+	for _, candidate := range candidates {
+		sub := &equipmentSubstitution{Items: []*itemWithSlot{candidate.item}}
+		req, changeLog := createNewRequestWithSubstitution(baseSettings, sub, settings.AutoEnchant)
+		if equipment := req.Raid.Parties[0].Players[0].Equipment; isValidEquipment(equipment) && !hasDuplicateUniqueItems(equipment) {
+			combos = append(combos, singleBulkSim{req: req, cl: changeLog, eq: sub})
+		}
+	}
+
+	if progress == nil {
+		// Progress is always reported, so it needs somewhere to go.
+		drainCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		progress = discardProgress(drainCtx)
+	}
+	runner := &bulkSimRunner{SingleRaidSimRunner: runSim}
+	simResults, baseResult, err := runner.getRankedResults(ctx, combos, int64(iterations), progress)
+	if err != nil {
+		return nil, err
+	}
+	if baseResult == nil {
+		return nil, fmt.Errorf("no base result for equipped gear found in upgrade finder")
+	}
+	baseDps := baseResult.Score()
+
+	// Keep the better slot for items which fit in several.
+	bestByItem := make(map[int32]*itemSubstitutionSimResult)
+	for _, simResult := range simResults {
+		if !simResult.Substitution.HasItemReplacements() {
+			continue
+		}
+		itemID := simResult.Substitution.Items[0].Item.Id
+		if best, ok := bestByItem[itemID]; !ok || simResult.Score() > best.Score() {
+			bestByItem[itemID] = simResult
+		}
+	}
+
+	type bossKey struct {
+		zoneID int32
+		npcID  int32
+		name   string
+	}
+	bossesByKey := make(map[bossKey]*proto.BossUpgrades)
+	seen := make(map[int32]bool)
+	for _, candidate := range candidates {
+		itemID := candidate.item.Item.Id
+		simResult, ok := bestByItem[itemID]
+		if !ok || seen[itemID] || simResult.Score() <= baseDps {
+			continue
+		}
+		seen[itemID] = true
+
+		for _, drop := range candidate.drops {
+			key := bossKey{zoneID: drop.ZoneId, npcID: drop.NpcId, name: drop.OtherName}
+			boss, ok := bossesByKey[key]
+			if !ok {
+				boss = &proto.BossUpgrades{
+					ZoneId: drop.ZoneId,
+					NpcId:  drop.NpcId,
+					Name:   drop.OtherName,
+				}
+				if npc, ok := lootNPCsByID[drop.NpcId]; ok {
+					boss.Name = npc.Name
+					if boss.ZoneId == 0 {
+						boss.ZoneId = npc.ZoneId
+					}
+				}
+				if zone, ok := lootZonesByID[boss.ZoneId]; ok {
+					boss.ZoneName = zone.Name
+				}
+				bossesByKey[key] = boss
+			}
+			boss.Upgrades = append(boss.Upgrades, &proto.ItemUpgrade{
+				Item:       simResult.ChangeLog.AddedItems[0],
+				Difficulty: drop.Difficulty,
+				Category:   drop.Category,
+				Dps:        simResult.Score(),
+				DpsDelta:   simResult.Score() - baseDps,
+			})
+		}
+	}
+
+	bum := baseResult.Result.GetRaidMetrics().GetParties()[0].GetPlayers()[0]
+	bum.Actions = nil
+	bum.Auras = nil
+	bum.Resources = nil
+	bum.Absorbs = nil
+	bum.AplEntries = nil
+	bum.Pets = nil
+
+	result = &proto.UpgradeFinderResult{
+		EquippedGearResult: bum,
+	}
+	for _, boss := range bossesByKey {
+		sort.SliceStable(boss.Upgrades, func(i, j int) bool {
+			return boss.Upgrades[i].Dps > boss.Upgrades[j].Dps
+		})
+		result.Bosses = append(result.Bosses, boss)
+	}
+	sort.Slice(result.Bosses, func(i, j int) bool {
+		a, b := result.Bosses[i], result.Bosses[j]
+		if a.ZoneId != b.ZoneId {
+			return a.ZoneId < b.ZoneId
+		}
+		if a.NpcId != b.NpcId {
+			return a.NpcId < b.NpcId
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+// Returns every single-slot swap to a usable drop which matches the request filters,
+// in item ID order.
+func (finder *upgradeFinderRunner) getCandidates(player *proto.Player) []upgradeCandidate {
+	equipped := player.Equipment.Items
+	offHand := ItemsByID[equipped[proto.ItemSlot_ItemSlotOffHand].Id]
+	dualWielding := offHand.Type == proto.ItemType_ItemTypeWeapon && offHand.WeaponType != proto.WeaponType_WeaponTypeShield &&
+		offHand.WeaponType != proto.WeaponType_WeaponTypeOffHand
+
+	ids := make([]int32, 0, len(lootItemsByID))
+	for id := range lootItemsByID {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var candidates []upgradeCandidate
+	for _, id := range ids {
+		loot := lootItemsByID[id]
+		item, ok := ItemsByID[id]
+		if !ok || (finder.Request.MaxPhase != 0 && loot.phase > finder.Request.MaxPhase) {
+			continue
+		}
+		if len(loot.classAllowlist) > 0 && !slices.Contains(loot.classAllowlist, player.Class) {
+			continue
+		}
+		if !canEquipItem(item, player.Class) {
+			continue
+		}
+
+		var drops []*proto.DropSource
+		for _, drop := range loot.drops {
+			zoneID := drop.ZoneId
+			if npc, ok := lootNPCsByID[drop.NpcId]; ok && zoneID == 0 {
+				zoneID = npc.ZoneId
+			}
+			if finder.Request.ZoneId != 0 && zoneID != finder.Request.ZoneId {
+				continue
+			}
+			if finder.Request.Difficulty != proto.DungeonDifficulty_DifficultyUnknown && drop.Difficulty != finder.Request.Difficulty {
+				continue
+			}
+			drops = append(drops, drop)
+		}
+		if len(drops) == 0 {
+			continue
+		}
+
+		for _, slot := range EligibleSlotsForItem(item) {
+			if equipped[slot].Id == id {
+				continue
+			}
+			if slot == proto.ItemSlot_ItemSlotOffHand && item.HandType == proto.HandType_HandTypeOneHand && !dualWielding {
+				continue
+			}
+			// Same checks as the bulk sim, e.g. for a unique ring which is already in the other slot,
+			// as well as for other unique-equipped items.
+			swapped := &proto.EquipmentSpec{Items: slices.Clone(equipped)}
+			swapped.Items[slot] = &proto.ItemSpec{Id: id}
+			if !isValidEquipment(swapped) || hasDuplicateUniqueItems(swapped) {
+				continue
+			}
+
+			spec := &proto.ItemSpec{Id: id}
+			if settings := finder.Request.GetBulkSettings(); settings != nil && settings.AutoGem {
+				autoGemItem(settings, spec)
+			}
+			candidates = append(candidates, upgradeCandidate{
+				item:  &itemWithSlot{Item: spec, Slot: slot},
+				drops: drops,
+			})
+		}
+	}
+	return candidates
+}
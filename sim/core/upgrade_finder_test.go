@@ -0,0 +1,150 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Removes items added by a test from the databases, so they don't leak into other tests.
+func deleteTestItems(ids ...int32) {
+	for _, id := range ids {
+		delete(ItemsByID, id)
+		delete(lootItemsByID, id)
+		delete(uniqueItemIDs, id)
+	}
+}
+
+func TestUpgradeFinderCandidates(t *testing.T) {
+	defer deleteTestItems(900001, 900002, 900003, 900004, 900005)
+	defer delete(lootNPCsByID, 900100)
+	defer delete(lootNPCsByID, 900101)
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: 900001, Name: "Cloth Hood", Type: proto.ItemType_ItemTypeHead, ArmorType: proto.ArmorType_ArmorTypeCloth},
+			{Id: 900002, Name: "Plate Helm", Type: proto.ItemType_ItemTypeHead, ArmorType: proto.ArmorType_ArmorTypePlate},
+			{Id: 900003, Name: "Ring", Type: proto.ItemType_ItemTypeFinger},
+			{Id: 900004, Name: "Heroic Ring", Type: proto.ItemType_ItemTypeFinger},
+			{Id: 900005, Name: "Mage Token", Type: proto.ItemType_ItemTypeChest},
+		},
+	})
+	drop := func(npcID int32, difficulty proto.DungeonDifficulty) []*proto.UIItemSource {
+		return []*proto.UIItemSource{{Source: &proto.UIItemSource_Drop{Drop: &proto.DropSource{NpcId: npcID, Difficulty: difficulty}}}}
+	}
+	addToLootDatabase(&proto.UIDatabase{
+		Items: []*proto.UIItem{
+			{Id: 900001, Sources: drop(900100, proto.DungeonDifficulty_DifficultyRaid25)},
+			{Id: 900002, Sources: drop(900100, proto.DungeonDifficulty_DifficultyRaid25)},
+			{Id: 900003, Sources: drop(900101, proto.DungeonDifficulty_DifficultyRaid25)},
+			{Id: 900004, Sources: drop(900101, proto.DungeonDifficulty_DifficultyRaid25H)},
+			{Id: 900005, Sources: drop(900101, proto.DungeonDifficulty_DifficultyRaid25), ClassAllowlist: []proto.Class{proto.Class_ClassMage}},
+		},
+		Npcs: []*proto.UINPC{
+			{Id: 900100, Name: "First Boss", ZoneId: 900200},
+			{Id: 900101, Name: "Second Boss", ZoneId: 900200},
+		},
+	})
+
+	player := &proto.Player{
+		Class:     proto.Class_ClassShaman,
+		Equipment: &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, len(proto.ItemSlot_name))},
+	}
+	for i := range player.Equipment.Items {
+		player.Equipment.Items[i] = &proto.ItemSpec{}
+	}
+	player.Equipment.Items[proto.ItemSlot_ItemSlotFinger2].Id = 900003
+
+	finder := &upgradeFinderRunner{Request: &proto.UpgradeFinderRequest{
+		ZoneId:     900200,
+		Difficulty: proto.DungeonDifficulty_DifficultyRaid25,
+	}}
+	candidates := finder.getCandidates(player)
+
+	// The plate helm, heroic ring and mage token are excluded, and the equipped ring can't be worn twice.
+	expected := []struct {
+		id   int32
+		slot proto.ItemSlot
+	}{
+		{900001, proto.ItemSlot_ItemSlotHead},
+	}
+	if len(candidates) != len(expected) {
+		t.Fatalf("Expected %d candidates, got %d", len(expected), len(candidates))
+	}
+	for i, candidate := range candidates {
+		if candidate.item.Item.Id != expected[i].id || candidate.item.Slot != expected[i].slot {
+			t.Fatalf("Expected candidate %d in %s, got %d in %s", expected[i].id, expected[i].slot, candidate.item.Item.Id, candidate.item.Slot)
+		}
+	}
+}
+
+func TestUpgradeFinderRun(t *testing.T) {
+	defer deleteTestItems(900011)
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: 900011, Name: "Boots", Type: proto.ItemType_ItemTypeFeet, ArmorType: proto.ArmorType_ArmorTypeMail},
+		},
+	})
+	addToLootDatabase(&proto.UIDatabase{
+		Items: []*proto.UIItem{
+			{Id: 900011, Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Drop{Drop: &proto.DropSource{NpcId: 900110, ZoneId: 900210}}}}},
+		},
+	})
+
+	result := UpgradeFinder(context.Background(), &proto.UpgradeFinderRequest{
+		BaseSettings: &proto.RaidSimRequest{
+			Raid: &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{
+				Name:      "Caster",
+				Class:     proto.Class_ClassShaman,
+				Consumes:  &proto.Consumes{},
+				Buffs:     &proto.IndividualBuffs{},
+				Spec:      &proto.Player_ElementalShaman{},
+				Equipment: &proto.EquipmentSpec{},
+			}}}}},
+			Encounter: &proto.Encounter{
+				Targets:  []*proto.Target{{Name: "target", Level: 83}},
+				Duration: 60,
+			},
+			SimOptions: &proto.SimOptions{RandomSeed: 100},
+		},
+		ZoneId:       900210,
+		BulkSettings: &proto.BulkSettings{IterationsPerCombo: 5},
+	}, nil)
+
+	if result.ErrorResult != "" {
+		t.Fatalf("Upgrade finder failed: %s", result.ErrorResult)
+	}
+	// The fake agent doesn't do any damage, so nothing is an upgrade.
+	if result.EquippedGearResult == nil || len(result.Bosses) != 0 {
+		t.Fatalf("Expected an equipped gear result and no upgrades, got %v", result)
+	}
+}
+
+func TestUpgradeFinderUniqueItems(t *testing.T) {
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: 900021, Name: "Unique Axe", Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeAxe, HandType: proto.HandType_HandTypeOneHand},
+		},
+	})
+	addToLootDatabase(&proto.UIDatabase{
+		Items: []*proto.UIItem{{Id: 900021, Unique: true}},
+	})
+	defer deleteTestItems(900021)
+
+	equipment := &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, len(proto.ItemSlot_name))}
+	for i := range equipment.Items {
+		equipment.Items[i] = &proto.ItemSpec{}
+	}
+	equipment.Items[proto.ItemSlot_ItemSlotMainHand].Id = 900021
+	if hasDuplicateUniqueItems(equipment) {
+		t.Fatalf("Expected a single unique item to be allowed")
+	}
+	equipment.Items[proto.ItemSlot_ItemSlotOffHand].Id = 900021
+	if !hasDuplicateUniqueItems(equipment) {
+		t.Fatalf("Expected a unique item in both hands to be rejected")
+	}
+	// The bulk sim doesn't check unique-equipped items, so its results don't change.
+	if !isValidEquipment(equipment) {
+		t.Fatalf("Expected the bulk sim to allow a unique item in both hands")
+	}
+}
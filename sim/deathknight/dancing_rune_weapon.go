@@ -70,6 +70,7 @@ func (dk *Deathknight) registerDancingRuneWeaponCD() {
 			dancingRuneWeaponAura.Activate(sim)
 		},
 	})
+	dk.RuneWeapon.SummonSpell = dk.DancingRuneWeapon
 }
 
 func (runeWeapon *RuneWeaponPet) getImpurityBonus(spell *core.Spell) float64 {
@@ -95,7 +95,7 @@ func (dk *Deathknight) registerBloodPresenceAura(timer *core.Timer) {
 		},
 	}
 
-	if !dk.Inputs.IsDps {
+	if !dk.Inputs.IsDps || dk.HealthIsModelled() {
 		aura.OnSpellHitDealt = func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
 			if result.Damage > 0 {
 				healthGain := 0.04 * result.Damage
@@ -156,7 +156,7 @@ func (dk *Deathknight) registerFrostPresenceAura(timer *core.Timer) {
 	})
 	dk.FrostPresenceAura.NewExclusiveEffect(presenceEffectCategory, true, core.ExclusiveEffect{})
 
-	if !dk.Inputs.IsDps && dk.Talents.ImprovedBloodPresence > 0 {
+	if (!dk.Inputs.IsDps || dk.HealthIsModelled()) && dk.Talents.ImprovedBloodPresence > 0 {
 		healFactor := 0.02 * float64(dk.Talents.ImprovedBloodPresence)
 		healthMetrics := dk.NewHealthMetrics(core.ActionID{SpellID: 50689})
 		dk.FrostPresenceAura.OnSpellHitDealt = func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
@@ -248,7 +248,7 @@ func (dk *Deathknight) registerUnholyPresenceAura(timer *core.Timer) {
 	})
 	dk.UnholyPresenceAura.NewExclusiveEffect(presenceEffectCategory, true, core.ExclusiveEffect{})
 
-	if !dk.Inputs.IsDps && dk.Talents.ImprovedBloodPresence > 0 {
+	if (!dk.Inputs.IsDps || dk.HealthIsModelled()) && dk.Talents.ImprovedBloodPresence > 0 {
 		healFactor := 0.02 * float64(dk.Talents.ImprovedBloodPresence)
 		healthMetrics := dk.NewHealthMetrics(core.ActionID{SpellID: 50689})
 		dk.UnholyPresenceAura.OnSpellHitDealt = func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
@@ -17,7 +17,7 @@ func (dk *Deathknight) registerRaiseDeadCD() {
 		ActionID: core.ActionID{SpellID: 46584},
 		Duration: time.Minute * 1,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			dk.Ghoul.Pet.Enable(sim, dk.Ghoul)
+			dk.Ghoul.Pet.EnableWithTimeout(sim, dk.Ghoul, aura.Duration)
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
 			dk.Ghoul.Pet.Disable(sim)
@@ -42,16 +42,13 @@ func (dk *Deathknight) registerRaiseDeadCD() {
 			raiseDeadAura.Activate(sim)
 		},
 	})
+	dk.Ghoul.SummonSpell = dk.RaiseDead
 
-	// TODO: Raise Dead should be used from the rotation in a smart way
-	// adding it as a survival MCD with GCDs messes with rotation more then it helps
-	// if !dk.Inputs.IsDps {
-	// 	dk.AddMajorCooldown(core.MajorCooldown{
-	// 		Spell: dk.RaiseDead,
-	// 		Type:  core.CooldownTypeSurvival,
-	// 		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
-	// 			return dk.CurrentHealthPercent() < 0.5 && sim.GetRemainingDuration() > 5*time.Second
-	// 		},
-	// 	})
-	// }
+	// Death Pact sacrifices the ghoul early, so drop the timeline aura with it.
+	dk.Ghoul.Pet.OnPetDisable = func(sim *core.Simulation) {
+		raiseDeadAura.Deactivate(sim)
+	}
+
+	// Raise Dead isn't added as a survival MCD, as spending GCDs on it messes with the
+	// rotation more than it helps. Use the APL pet actions and values to time it instead.
 }
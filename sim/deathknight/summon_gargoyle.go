@@ -55,6 +55,8 @@ func (dk *Deathknight) registerSummonGargoyleCD() {
 		},
 	})
 
+	dk.Gargoyle.SummonSpell = dk.SummonGargoyle
+
 	dk.AddMajorCooldown(core.MajorCooldown{
 		Spell: dk.SummonGargoyle,
 		Type:  core.CooldownTypeDPS,
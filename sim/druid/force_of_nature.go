@@ -51,6 +51,9 @@ func (druid *Druid) registerForceOfNatureCD() {
 			sim.AddPendingAction(&pa)
 		},
 	})
+	druid.Treant1.SummonSpell = druid.ForceOfNature.Spell
+	druid.Treant2.SummonSpell = druid.ForceOfNature.Spell
+	druid.Treant3.SummonSpell = druid.ForceOfNature.Spell
 }
 
 type TreantPet struct {
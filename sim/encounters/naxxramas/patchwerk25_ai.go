@@ -42,11 +42,17 @@ func addPatchwerk25(bossPrefix string) {
 	})
 }
 
+// Patchwerk frenzies at 5% health.
+const patchwerkFrenzyThreshold = 0.05
+
 type Patchwerk25AI struct {
 	Target *core.Target
 
 	HatefulStrike *core.Spell
 	Frenzy        *core.Spell
+	FrenzyAura    *core.Aura
+
+	MinBaseDamage float64
 }
 
 func NewPatchwerk25AI() core.AIFactory {
@@ -57,9 +63,10 @@ func NewPatchwerk25AI() core.AIFactory {
 
 func (ai *Patchwerk25AI) Initialize(target *core.Target, config *proto.Target) {
 	ai.Target = target
+	ai.MinBaseDamage = config.MinBaseDamage
 
 	//ai.registerHatefulStrikeSpell(target)
-	//ai.registerFrenzySpell(target)
+	ai.registerFrenzySpell(target)
 }
 
 func (ai *Patchwerk25AI) Reset(*core.Simulation) {
@@ -93,7 +100,7 @@ func (ai *Patchwerk25AI) registerHatefulStrikeSpell(target *core.Target) {
 
 func (ai *Patchwerk25AI) registerFrenzySpell(target *core.Target) {
 	actionID := core.ActionID{SpellID: 28131}
-	frenzyAura := target.GetOrRegisterAura(core.Aura{
+	ai.FrenzyAura = target.GetOrRegisterAura(core.Aura{
 		ActionID: actionID,
 		Label:    "Frenzy",
 		Duration: 5 * time.Minute,
@@ -119,9 +126,38 @@ func (ai *Patchwerk25AI) registerFrenzySpell(target *core.Target) {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			frenzyAura.Activate(sim)
+			ai.FrenzyAura.Activate(sim)
 		},
 	})
+
+	// Frenzy usually lasts for the rest of the fight, so tanks should save a cooldown for it.
+	target.RegisterDangerousEvent(func(sim *core.Simulation) (core.DangerousEvent, bool) {
+		event := core.DangerousEvent{
+			ActionID: actionID,
+			Duration: ai.FrenzyAura.Duration,
+			Damage:   ai.MinBaseDamage * 1.25,
+			School:   core.SpellSchoolPhysical,
+			Target:   ai.Target.CurrentTarget,
+		}
+		if ai.FrenzyAura.IsActive() {
+			event.Time = ai.FrenzyAura.StartedAt()
+			return event, true
+		}
+		if !ai.Frenzy.IsReady(sim) {
+			return event, false
+		}
+		event.Time = sim.CurrentTime + ai.timeUntilFrenzy(sim)
+		return event, true
+	})
+}
+
+// Estimates the time until the boss reaches the Frenzy threshold.
+func (ai *Patchwerk25AI) timeUntilFrenzy(sim *core.Simulation) time.Duration {
+	remainingPercent := sim.GetRemainingDurationPercent()
+	if remainingPercent <= patchwerkFrenzyThreshold {
+		return 0
+	}
+	return time.Duration(float64(sim.GetRemainingDuration()) * (remainingPercent - patchwerkFrenzyThreshold) / remainingPercent)
 }
 
 func (ai *Patchwerk25AI) ExecuteCustomRotation(sim *core.Simulation) {
@@ -129,11 +165,9 @@ func (ai *Patchwerk25AI) ExecuteCustomRotation(sim *core.Simulation) {
 		return
 	}
 
-	// TODO: Re-enable Frenzy when we have a feature to flag for tank cooldown timing
-	//       Otherwise users get confused why the default settings say they die a lot...
-	//if ai.Frenzy.IsReady(sim) && sim.GetRemainingDurationPercent() < 0.05 {
-	//	ai.Frenzy.Cast(sim, ai.Target.CurrentTarget)
-	//}
+	if ai.Frenzy.IsReady(sim) && sim.GetRemainingDurationPercent() < patchwerkFrenzyThreshold {
+		ai.Frenzy.Cast(sim, ai.Target.CurrentTarget)
+	}
 
 	// TODO: Only enable Hateful Strike in solo sim if you are assigned OT instead of MT
 	// TODO: Actual targeting logic for Hateful Strike in raidsim
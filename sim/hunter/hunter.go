@@ -131,6 +131,7 @@ func (hunter *Hunter) Initialize() {
 	hunter.registerSteadyShotSpell()
 	hunter.registerVolleySpell()
 
+	hunter.registerCallPetSpell()
 	hunter.registerKillCommandCD()
 	hunter.registerRapidFireCD()
 
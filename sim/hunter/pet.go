@@ -72,6 +72,29 @@ func (hunter *Hunter) NewHunterPet() *HunterPet {
 	return hp
 }
 
+func (hunter *Hunter) registerCallPetSpell() {
+	if hunter.pet == nil {
+		return
+	}
+
+	hunter.pet.SummonSpell = hunter.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 883},
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !hunter.pet.IsEnabled()
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			hunter.pet.Enable(sim, hunter.pet)
+		},
+	})
+}
+
 func (hp *HunterPet) GetPet() *core.Pet {
 	return &hp.Pet
 }
@@ -0,0 +1,78 @@
+package hunter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func currentTimeCompare(op proto.APLValueCompare_ComparisonOperator, val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+		Op:  op,
+		Lhs: &proto.APLValue{Value: &proto.APLValue_CurrentTime{CurrentTime: &proto.APLValueCurrentTime{}}},
+		Rhs: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}},
+	}}}
+}
+
+func TestDismissAndCallPet(t *testing.T) {
+	petRef := &proto.UnitReference{Type: proto.UnitReference_Pet, Owner: &proto.UnitReference{Type: proto.UnitReference_Self}}
+
+	raid := core.SinglePlayerRaidProto(core.WithSpec(&proto.Player{
+		Class:     proto.Class_ClassHunter,
+		Race:      proto.Race_RaceOrc,
+		Equipment: &proto.EquipmentSpec{},
+		Rotation: &proto.APLRotation{
+			Type: proto.APLRotation_TypeAPL,
+			PriorityList: []*proto.APLListItem{
+				{Action: &proto.APLAction{
+					Condition: currentTimeCompare(proto.APLValueCompare_OpLt, "2s"),
+					Action:    &proto.APLAction_DismissPet{DismissPet: &proto.APLActionDismissPet{Pet: petRef}},
+				}},
+				{Action: &proto.APLAction{
+					Condition: currentTimeCompare(proto.APLValueCompare_OpGe, "3s"),
+					Action:    &proto.APLAction_SummonPet{SummonPet: &proto.APLActionSummonPet{Pet: petRef}},
+				}},
+			},
+		},
+	}, &proto.Player_Hunter{Hunter: &proto.Hunter{Options: &proto.Hunter_Options{
+		PetType:   proto.Hunter_Options_Wolf,
+		PetUptime: 1,
+	}}}), nil, nil, nil)
+
+	sim := core.NewSim(&proto.RaidSimRequest{
+		Raid:       raid,
+		Encounter:  core.MakeSingleTargetEncounter(0),
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+	})
+	hunter := sim.Raid.Parties[0].Players[0].(HunterAgent).GetHunter()
+	callPet := hunter.pet.SummonSpell
+	if callPet == nil || callPet.ActionID.SpellID != 883 {
+		t.Fatalf("Expected Call Pet to be the pet's summon spell")
+	}
+
+	// Without a ranged weapon equipped there is nothing to auto shoot with.
+	hunter.AutoAttacks.AutoSwingRanged = false
+
+	sim.Reset()
+	sim.PrePull()
+	stepUntil := func(until time.Duration) {
+		for sim.CurrentTime < until {
+			sim.Step()
+		}
+	}
+
+	// The pet is dismissed at the pull, and Call Pet brings it back instantly once allowed.
+	stepUntil(time.Second)
+	if hunter.pet.IsEnabled() {
+		t.Fatalf("Expected the pet to be dismissed")
+	}
+	stepUntil(time.Second * 5)
+	if !hunter.pet.IsEnabled() {
+		t.Fatalf("Expected the pet to be called again")
+	}
+	if casts := callPet.SpellMetrics[0].Casts; casts != 1 {
+		t.Fatalf("Expected 1 cast of Call Pet, got %d", casts)
+	}
+}
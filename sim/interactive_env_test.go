@@ -0,0 +1,105 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func interactiveEnvTestRequest(raidIndex int32) *proto.EnvResetRequest {
+	rogue := core.WithSpec(&proto.Player{
+		Name:      "Rogue",
+		Class:     proto.Class_ClassRogue,
+		Race:      proto.Race_RaceHuman,
+		Equipment: &proto.EquipmentSpec{},
+		Rotation:  &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+	}, &proto.Player_Rogue{Rogue: &proto.Rogue{Options: &proto.Rogue_Options{}}})
+	warrior := core.WithSpec(&proto.Player{
+		Name:      "Warrior",
+		Class:     proto.Class_ClassWarrior,
+		Race:      proto.Race_RaceHuman,
+		Equipment: &proto.EquipmentSpec{},
+		Rotation:  &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+	}, &proto.Player_Warrior{Warrior: &proto.Warrior{Options: &proto.Warrior_Options{}}})
+
+	return &proto.EnvResetRequest{
+		Settings: &proto.RaidSimRequest{
+			Raid: &proto.Raid{Parties: []*proto.Party{{
+				Players: []*proto.Player{rogue, warrior},
+			}}},
+			Encounter: &proto.Encounter{
+				Duration: 30,
+				Targets:  []*proto.Target{StandardTarget},
+			},
+		},
+		RaidIndex: raidIndex,
+		Seed:      3,
+	}
+}
+
+// Runs an episode which always casts the first castable action, returning the total
+// reward and number of steps.
+func runInteractiveEnvEpisode(t *testing.T, env *core.InteractiveEnv, seed int64) (float64, int) {
+	env.Reset(seed)
+	totalReward := 0.0
+	steps := 0
+	for !env.Done() {
+		obs := env.Observe()
+		action := int32(-1)
+		for i, canCast := range obs.ActionMask {
+			if canCast {
+				action = int32(i)
+				break
+			}
+		}
+		result, err := env.Step(action, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		totalReward += result.Reward
+		steps++
+	}
+	return totalReward, steps
+}
+
+func TestInteractiveEnv(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		raidIndex int32
+	}{
+		{name: "Rogue", raidIndex: 0},
+		{name: "Warrior", raidIndex: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			request := interactiveEnvTestRequest(tc.raidIndex)
+			env, err := core.NewInteractiveEnv(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(env.Spec().Actions) == 0 {
+				t.Fatalf("Expected actions for %s", tc.name)
+			}
+
+			obs := env.Reset(request.Seed)
+			if (obs.Energy > 0) != (tc.name == "Rogue") {
+				t.Errorf("Expected energy only for the rogue: %v", obs)
+			}
+			if len(obs.ActionMask) != len(env.Actions) || len(obs.Cooldowns) != len(env.Actions) {
+				t.Errorf("Observation doesn't match the action space")
+			}
+
+			reward1, steps1 := runInteractiveEnvEpisode(t, env, 7)
+			reward2, steps2 := runInteractiveEnvEpisode(t, env, 7)
+			if reward1 <= 0 {
+				t.Errorf("Expected positive reward, got %f", reward1)
+			}
+			if reward1 != reward2 || steps1 != steps2 {
+				t.Errorf("Episodes with the same seed differ: %f in %d steps vs %f in %d steps", reward1, steps1, reward2, steps2)
+			}
+			if _, err := env.Step(-1, 0); err == nil {
+				t.Errorf("Expected an error stepping a finished episode")
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ var _active_sim = core.NewSim(&_default_rsr)
 var _active_seed int64 = 1
 var _aura_labels = []string{}
 var _target_aura_labels = []string{}
+var _active_env *core.InteractiveEnv
 
 //export runSim
 func runSim(json *C.char) *C.char {
@@ -119,22 +120,25 @@ func getDatabase(itemIds *int32, numItems int32, enchantIds *int32, numEnchants
 			WeaponDamageMax:  item.WeaponDamageMax,
 			WeaponSpeed:      item.SwingSpeed,
 			SetName:          item.SetName,
+			HasSpecialEffect: item.HasSpecialEffect,
 		}
 	}
 	for i, enchantId := range eids {
 		enchant := core.EnchantsByEffectID[enchantId]
 		simDB.Enchants[i] = &proto.SimEnchant{
-			EffectId: enchant.EffectID,
-			Stats:    enchant.Stats[:],
+			EffectId:         enchant.EffectID,
+			Stats:            enchant.Stats[:],
+			HasSpecialEffect: enchant.HasSpecialEffect,
 		}
 	}
 	for i, gemId := range gids {
 		gem := core.GemsByID[gemId]
 		simDB.Gems[i] = &proto.SimGem{
-			Id:    gem.ID,
-			Name:  gem.Name,
-			Color: gem.Color,
-			Stats: gem.Stats[:],
+			Id:               gem.ID,
+			Name:             gem.Name,
+			Color:            gem.Color,
+			Stats:            gem.Stats[:],
+			HasSpecialEffect: gem.HasSpecialEffect,
 		}
 	}
 	out, err := protojson.Marshal(simDB)
@@ -352,6 +356,78 @@ func cleanup() {
 	_active_sim.Cleanup()
 }
 
+// Starts a new episode of the step-based environment. Takes an EnvResetRequest and returns
+// an EnvResetResult, both in protojson format. The environment is rebuilt whenever settings
+// are given, otherwise the previous one is reused.
+//
+//export envReset
+func envReset(json *C.char) *C.char {
+	input := &proto.EnvResetRequest{}
+	jsonString := C.GoString(json)
+	err := protojson.Unmarshal([]byte(jsonString), input)
+	if err != nil {
+		log.Fatalf("failed to load input json file: %s", err)
+	}
+
+	var result *proto.EnvResetResult
+	if _active_env == nil || input.Settings != nil {
+		sim.RegisterAll()
+		_active_env, err = core.NewInteractiveEnv(input)
+	}
+	if err != nil {
+		result = &proto.EnvResetResult{ErrorResult: err.Error()}
+	} else {
+		result = &proto.EnvResetResult{
+			Spec:        _active_env.Spec(),
+			Observation: _active_env.Reset(input.Seed),
+		}
+	}
+	out, err := protojson.Marshal(result)
+	if err != nil {
+		panic(err)
+	}
+	return C.CString(string(out))
+}
+
+// Takes an action in the environment, storing its reward. Returns true once the episode is over,
+// or if there is no environment because envReset hasn't succeeded.
+//
+//export envStep
+func envStep(action int32, waitSeconds float64, reward *float64) bool {
+	if _active_env == nil {
+		*reward = 0
+		return true
+	}
+	result, err := _active_env.Step(action, core.DurationFromSeconds(waitSeconds))
+	if err != nil {
+		*reward = 0
+		return true
+	}
+	*reward = result.Reward
+	return result.Done
+}
+
+// Returns 0 if there is no environment.
+//
+//export envObservationSize
+func envObservationSize() int {
+	if _active_env == nil {
+		return 0
+	}
+	return len(core.EnvObservationVector(_active_env.Observe()))
+}
+
+// Writes the current observation as a flat vector, see core.EnvObservationVector.
+// Writes nothing if there is no environment.
+//
+//export envObservation
+func envObservation(storage *float64, n int32) {
+	if _active_env == nil {
+		return
+	}
+	copy(unsafe.Slice(storage, n), core.EnvObservationVector(_active_env.Observe()))
+}
+
 //export FreeCString
 func FreeCString(s *C.char) {
 	C.free(unsafe.Pointer(s))
@@ -42,6 +42,7 @@ type Paladin struct {
 	SealOfCommand         *core.Spell
 	AvengingWrath         *core.Spell
 	DivineProtection      *core.Spell
+	SacredShield          *core.Spell
 	SovDotSpell           *core.Spell
 	// SealOfWisdom        *core.Spell
 	// SealOfLight         *core.Spell
@@ -56,6 +57,7 @@ type Paladin struct {
 	SealOfRighteousnessAura *core.Aura
 	AvengingWrathAura       *core.Aura
 	DivineProtectionAura    *core.Aura
+	SacredShieldAura        *core.Aura
 	ForbearanceAura         *core.Aura
 	VengeanceAura           *core.Aura
 
@@ -149,6 +151,7 @@ func (paladin *Paladin) Initialize() {
 	paladin.registerSpiritualAttunement()
 	paladin.registerDivinePleaSpell()
 	paladin.registerDivineProtectionSpell()
+	paladin.registerSacredShieldSpell()
 	paladin.registerForbearanceDebuff()
 
 	for i := int32(0); i < paladin.Env.GetNumTargets(); i++ {
@@ -0,0 +1,69 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core"
+)
+
+func (paladin *Paladin) registerSacredShieldSpell() {
+	actionID := core.ActionID{SpellID: 53601}
+
+	absorbSpell := paladin.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 58597},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			SelfOnly: true,
+			Aura: core.Aura{
+				Label:    "Sacred Shield Absorb",
+				Duration: time.Second * 6,
+			},
+		},
+	})
+
+	icd := core.Cooldown{
+		Timer:    paladin.NewTimer(),
+		Duration: time.Second * 6,
+	}
+
+	// Taking damage grants an absorb shield, at most once every 6s.
+	paladin.SacredShieldAura = paladin.RegisterAura(core.Aura{
+		Label:    "Sacred Shield",
+		ActionID: actionID,
+		Duration: time.Minute,
+		Icd:      &icd,
+		OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if result.Damage <= 0 || !icd.IsReady(sim) {
+				return
+			}
+			icd.Use(sim)
+			absorbSpell.SelfShield().Apply(sim, 500+0.75*absorbSpell.HealingPower(&paladin.Unit))
+		},
+	})
+
+	paladin.SacredShield = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagAPL | core.SpellFlagHelpful,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.12,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			paladin.SacredShieldAura.Activate(sim)
+		},
+	})
+}
@@ -271,6 +271,14 @@ func (paladin *Paladin) applyArdentDefender() {
 		},
 	})
 
+	// Only used to report the damage reduction as absorption in metrics
+	reductionSpell := paladin.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 31852},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful,
+	})
+
 	// >= 0.35, no effect
 	// < 0.35, pro-rated DR
 	// =< 0, proc death save
@@ -279,6 +287,7 @@ func (paladin *Paladin) applyArdentDefender() {
 		if (paladin.CurrentHealth()-incomingDamage)/paladin.MaxHealth() <= 0.35 {
 			//rangeAura.Activate(sim)
 			result.Damage -= (paladin.MaxHealth()*0.35 - (paladin.CurrentHealth() - incomingDamage)) * ardentDamageReduction
+			reductionSpell.RecordAbsorb(&paladin.Unit, incomingDamage-result.Damage)
 			if sim.Log != nil {
 				paladin.Log(sim, "Ardent Defender reduced damage by %d", int32(incomingDamage-result.Damage))
 			}
@@ -306,7 +315,6 @@ func (paladin *Paladin) applyArdentDefender() {
 				procAura.Activate(sim)
 			}
 		}
-		// TODO: Metrics, attribute reduced damage as absorption
 	})
 }
 
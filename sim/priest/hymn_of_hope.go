@@ -7,13 +7,15 @@ import (
 	"github.com/wowsims/wotlk/sim/core/proto"
 )
 
-// TODO: This currently only affects the caster, not other raid members.
+// Each tick restores mana to the raid members with the lowest mana, which may include the priest.
 func (priest *Priest) RegisterHymnOfHopeCD() {
-	actionID := core.ActionID{SpellID: 64901}
-	manaMetrics := priest.NewManaMetrics(actionID)
+	actionID := core.HymnOfHopeActionID
 
 	numTicks := 4 + core.TernaryInt32(priest.HasMajorGlyph(proto.PriestMajorGlyph_GlyphOfHymnOfHope), 1, 0)
 
+	// Mana gained by each recipient is attributed to this priest.
+	manaSource := priest.NewRaidManaSource(actionID)
+
 	hymnOfHopeSpell := priest.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
 		Flags:    core.SpellFlagHelpful,
@@ -24,7 +26,7 @@ func (priest *Priest) RegisterHymnOfHopeCD() {
 			},
 			CD: core.Cooldown{
 				Timer:    priest.NewTimer(),
-				Duration: time.Minute * 6,
+				Duration: core.HymnOfHopeCD,
 			},
 		},
 
@@ -33,9 +35,9 @@ func (priest *Priest) RegisterHymnOfHopeCD() {
 				Period:   spell.Unit.ApplyCastSpeedForSpell(time.Second*2, spell),
 				NumTicks: int(numTicks),
 				OnAction: func(sim *core.Simulation) {
-					// This is 3%, but it increases the target's max mana by 20% for the duration
-					// so just simplify to 3 * 1.2 = 3.6%.
-					priest.AddMana(sim, priest.MaxMana()*0.036, manaMetrics)
+					for _, unit := range manaSource.LowestManaUnits(core.HymnOfHopeNumTargets) {
+						manaSource.AddMana(sim, unit, core.HymnOfHopeManaPerTick(unit))
+					}
 				},
 			})
 		},
@@ -45,7 +47,7 @@ func (priest *Priest) RegisterHymnOfHopeCD() {
 		Spell: hymnOfHopeSpell,
 		Type:  core.CooldownTypeMana,
 		ShouldActivate: func(sim *core.Simulation, character *core.Character) bool {
-			return character.CurrentManaPercent() < 0.1
+			return manaSource.LowestManaUnits(1)[0].CurrentManaPercent() < 0.1
 		},
 	})
 }
@@ -41,4 +41,5 @@ func (priest *Priest) registerShadowfiendSpell() {
 			priest.ShadowfiendAura.Activate(sim)
 		},
 	})
+	priest.ShadowfiendPet.SummonSpell = priest.Shadowfiend
 }
@@ -0,0 +1,59 @@
+package rogue
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func (rogue *Rogue) NewAPLValue(rot *core.APLRotation, config *proto.APLValue) core.APLValue {
+	switch config.Value.(type) {
+	case *proto.APLValue_RogueIsStealthed:
+		return rogue.newValueRogueIsStealthed(rot, config.GetRogueIsStealthed())
+	case *proto.APLValue_RogueTimeSinceStealthBroke:
+		return rogue.newValueRogueTimeSinceStealthBroke(rot, config.GetRogueTimeSinceStealthBroke())
+	default:
+		return nil
+	}
+}
+
+type APLValueRogueIsStealthed struct {
+	core.DefaultAPLValueImpl
+	rogue *Rogue
+}
+
+func (rogue *Rogue) newValueRogueIsStealthed(_ *core.APLRotation, _ *proto.APLValueRogueIsStealthed) core.APLValue {
+	return &APLValueRogueIsStealthed{
+		rogue: rogue,
+	}
+}
+func (value *APLValueRogueIsStealthed) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueRogueIsStealthed) GetBool(_ *core.Simulation) bool {
+	return value.rogue.IsStealthed()
+}
+func (value *APLValueRogueIsStealthed) String() string {
+	return "Rogue Is Stealthed()"
+}
+
+type APLValueRogueTimeSinceStealthBroke struct {
+	core.DefaultAPLValueImpl
+	rogue *Rogue
+}
+
+func (rogue *Rogue) newValueRogueTimeSinceStealthBroke(_ *core.APLRotation, _ *proto.APLValueRogueTimeSinceStealthBroke) core.APLValue {
+	return &APLValueRogueTimeSinceStealthBroke{
+		rogue: rogue,
+	}
+}
+func (value *APLValueRogueTimeSinceStealthBroke) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueRogueTimeSinceStealthBroke) GetDuration(sim *core.Simulation) time.Duration {
+	return value.rogue.TimeSinceStealthBroke(sim)
+}
+func (value *APLValueRogueTimeSinceStealthBroke) String() string {
+	return "Rogue Time Since Stealth Broke()"
+}
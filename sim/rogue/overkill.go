@@ -1,8 +1,6 @@
 package rogue
 
 import (
-	"time"
-
 	"github.com/wowsims/wotlk/sim/core"
 )
 
@@ -13,15 +11,10 @@ func (rogue *Rogue) registerOverkill() {
 		return
 	}
 
-	effectDuration := time.Second * 20
-	if rogue.StealthAura.IsActive() {
-		effectDuration = core.NeverExpires
-	}
-
 	rogue.OverkillAura = rogue.RegisterAura(core.Aura{
 		Label:    "Overkill",
 		ActionID: OverkillActionID,
-		Duration: effectDuration,
+		Duration: overkillDuration,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
 			rogue.ApplyEnergyTickMultiplier(0.3)
 		},
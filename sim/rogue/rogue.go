@@ -70,6 +70,7 @@ type Rogue struct {
 	ShadowDance      *core.Spell
 	ColdBlood        *core.Spell
 	Vanish           *core.Spell
+	Stealth          *core.Spell
 
 	Envenom      *core.Spell
 	Eviscerate   *core.Spell
@@ -104,6 +105,8 @@ type Rogue struct {
 
 	QuickRecoveryMetrics *core.ResourceMetrics
 
+	stealthBrokeAt time.Duration // When Stealth last faded in this iteration, or NeverExpires.
+
 	costModifier               func(float64) float64
 	finishingMoveEffectApplier func(sim *core.Simulation, numPoints int32)
 }
@@ -153,7 +156,7 @@ func (rogue *Rogue) Initialize() {
 
 	rogue.costModifier = rogue.makeCostModifier()
 
-	rogue.registerStealthAura()
+	rogue.registerStealth()
 	rogue.registerBackstabSpell()
 	rogue.registerDeadlyPoisonSpell()
 	rogue.registerPoisonAuras()
@@ -184,6 +187,8 @@ func (rogue *Rogue) ApplyEnergyTickMultiplier(multiplier float64) {
 }
 
 func (rogue *Rogue) Reset(sim *core.Simulation) {
+	rogue.stealthBrokeAt = core.NeverExpires
+
 	for _, mcd := range rogue.GetMajorCooldowns() {
 		mcd.Disable()
 	}
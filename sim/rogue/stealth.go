@@ -1,35 +1,86 @@
 package rogue
 
 import (
+	"time"
+
 	"github.com/wowsims/wotlk/sim/core"
 )
 
-func (rogue *Rogue) registerStealthAura() {
-	// TODO: Add Stealth spell for use with prepull in APL
+const (
+	overkillDuration         = time.Second * 20
+	masterOfSubtletyDuration = time.Second * 6
+)
+
+func (rogue *Rogue) registerStealth() {
 	rogue.StealthAura = rogue.RegisterAura(core.Aura{
 		Label:    "Stealth",
 		ActionID: core.ActionID{SpellID: 1787},
 		Duration: core.NeverExpires,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
-			// Stealth triggered auras
+			// Stealth triggered auras last for as long as Stealth does.
 			if rogue.Talents.Overkill {
+				rogue.OverkillAura.Duration = core.NeverExpires
 				rogue.OverkillAura.Activate(sim)
 			}
 			if rogue.Talents.MasterOfSubtlety > 0 {
+				rogue.MasterOfSubtletyAura.Duration = core.NeverExpires
 				rogue.MasterOfSubtletyAura.Activate(sim)
 			}
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-			if rogue.Talents.Overkill {
-				rogue.OverkillAura.Deactivate(sim)
-				rogue.OverkillAura.Activate(sim)
+			rogue.stealthBrokeAt = sim.CurrentTime
+
+			// ... and then linger for a short while after Stealth breaks.
+			if rogue.Talents.Overkill && rogue.OverkillAura.IsActive() {
+				lingerAfterStealth(sim, rogue.OverkillAura, overkillDuration)
 			}
-			if rogue.Talents.MasterOfSubtlety > 0 {
-				rogue.MasterOfSubtletyAura.Deactivate(sim)
-				rogue.MasterOfSubtletyAura.Activate(sim)
+			if rogue.Talents.MasterOfSubtlety > 0 && rogue.MasterOfSubtletyAura.IsActive() {
+				lingerAfterStealth(sim, rogue.MasterOfSubtletyAura, masterOfSubtletyDuration)
 			}
 		},
 		// Stealth breaks on damage taken (if not absorbed)
 		// This may be desirable later, but not applicable currently
 	})
+
+	rogue.Stealth = rogue.RegisterSpell(core.SpellConfig{
+		ActionID: core.ActionID{SpellID: 1787},
+		Flags:    core.SpellFlagAPL,
+
+		Cast: core.CastConfig{
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    rogue.NewTimer(),
+				Duration: time.Second * 10,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			// Stealth can't be entered in combat, only Vanish can do that.
+			return sim.CurrentTime < 0 && !rogue.StealthAura.IsActive()
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// Keep auto attacks from starting at the pull; the opener will turn them on.
+			rogue.AutoAttacks.HoldAutoSwingAtPull(sim)
+			rogue.StealthAura.Activate(sim)
+		},
+	})
+}
+
+// Auras activated without an expiration aren't tracked for expiring, so they have
+// to be reapplied for the new duration to take effect.
+func lingerAfterStealth(sim *core.Simulation, aura *core.Aura, duration time.Duration) {
+	aura.Deactivate(sim)
+	aura.Duration = duration
+	aura.Activate(sim)
+}
+
+// Time since Stealth last faded, or NeverExpires if the rogue hasn't been stealthed yet.
+func (rogue *Rogue) TimeSinceStealthBroke(sim *core.Simulation) time.Duration {
+	if rogue.StealthAura.IsActive() {
+		return 0
+	}
+	if rogue.stealthBrokeAt == core.NeverExpires {
+		return core.NeverExpires
+	}
+	return sim.CurrentTime - rogue.stealthBrokeAt
 }
@@ -0,0 +1,128 @@
+package rogue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Overkill and 3/3 Master of Subtlety, with nothing else.
+const stealthTestTalents = "0000000000000000001--00000000000000003"
+
+func castSpellAction(actionID core.ActionID) *proto.APLAction {
+	return &proto.APLAction{
+		Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: actionID.ToProto()}},
+	}
+}
+
+// Sets up a rogue who enters Stealth before the pull and opens with Garrote at openAt.
+func setupStealthOpener(t *testing.T, openAt string) (*core.Simulation, *Rogue) {
+	opener := castSpellAction(core.ActionID{SpellID: 48676})
+	opener.Condition = &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+		Op:  proto.APLValueCompare_OpGe,
+		Lhs: &proto.APLValue{Value: &proto.APLValue_CurrentTime{CurrentTime: &proto.APLValueCurrentTime{}}},
+		Rhs: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: openAt}}},
+	}}}
+
+	raid := core.SinglePlayerRaidProto(core.WithSpec(&proto.Player{
+		Class:         proto.Class_ClassRogue,
+		Race:          proto.Race_RaceHuman,
+		Equipment:     &proto.EquipmentSpec{},
+		TalentsString: stealthTestTalents,
+		Rotation: &proto.APLRotation{
+			Type: proto.APLRotation_TypeAPL,
+			PrepullActions: []*proto.APLPrepullAction{{
+				Action:    castSpellAction(core.ActionID{SpellID: 1787}),
+				DoAtValue: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "-1s"}}},
+			}},
+			PriorityList: []*proto.APLListItem{{Action: opener}},
+		},
+	}, &proto.Player_Rogue{Rogue: &proto.Rogue{Options: &proto.Rogue_Options{}}}), nil, nil, nil)
+
+	sim := core.NewSim(&proto.RaidSimRequest{
+		Raid:       raid,
+		Encounter:  core.MakeSingleTargetEncounter(0),
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+	})
+	rogue := sim.Raid.Parties[0].Players[0].(RogueAgent).GetRogue()
+	if !rogue.Talents.Overkill || rogue.Talents.MasterOfSubtlety != 3 {
+		t.Fatalf("Expected Overkill and 3/3 Master of Subtlety")
+	}
+
+	sim.Reset()
+	sim.PrePull()
+	stepUntil(sim, 0)
+	return sim, rogue
+}
+
+func stepUntil(sim *core.Simulation, until time.Duration) {
+	for sim.CurrentTime < until {
+		sim.Step()
+	}
+}
+
+func TestStealthOpener(t *testing.T) {
+	sim, rogue := setupStealthOpener(t, "2s")
+	mhAuto := rogue.AutoAttacks.MHAuto()
+
+	if !rogue.StealthAura.IsActive() {
+		t.Fatalf("Expected Stealth to be active from the prepull action")
+	}
+
+	// Auto attacks are held at the pull while the rogue waits in Stealth.
+	stepUntil(sim, time.Second)
+	if !rogue.StealthAura.IsActive() {
+		t.Fatalf("Expected Stealth to stay active until the opener")
+	}
+	if casts := mhAuto.SpellMetrics[rogue.CurrentTarget.UnitIndex].Casts; casts != 0 {
+		t.Fatalf("Expected no auto attacks before the opener, got %d", casts)
+	}
+
+	// The opener breaks Stealth and starts auto attacks.
+	stepUntil(sim, time.Second*3)
+	if rogue.StealthAura.IsActive() {
+		t.Fatalf("Expected the opener to break Stealth")
+	}
+	if casts := rogue.Garrote.SpellMetrics[rogue.CurrentTarget.UnitIndex].Casts; casts != 1 {
+		t.Fatalf("Expected the opener to be cast once, got %d", casts)
+	}
+	if casts := mhAuto.SpellMetrics[rogue.CurrentTarget.UnitIndex].Casts; casts == 0 {
+		t.Fatalf("Expected auto attacks to start after the opener")
+	}
+	if broke := rogue.TimeSinceStealthBroke(sim); broke != sim.CurrentTime-time.Second*2 {
+		t.Fatalf("Expected Stealth to have broken at 2s, %s ago, got %s", sim.CurrentTime-time.Second*2, broke)
+	}
+}
+
+func TestStealthAuraWindows(t *testing.T) {
+	sim, rogue := setupStealthOpener(t, "2s")
+
+	// Both auras last for as long as Stealth does.
+	if !rogue.OverkillAura.IsActive() || !rogue.MasterOfSubtletyAura.IsActive() {
+		t.Fatalf("Expected Overkill and Master of Subtlety while stealthed")
+	}
+	stepUntil(sim, time.Second)
+	if rogue.OverkillAura.ExpiresAt() != core.NeverExpires || rogue.MasterOfSubtletyAura.ExpiresAt() != core.NeverExpires {
+		t.Fatalf("Expected Overkill and Master of Subtlety not to expire while stealthed")
+	}
+
+	// Once Stealth breaks, Master of Subtlety lingers for 6s and Overkill for 20s.
+	stepUntil(sim, time.Second*2+1)
+	if expiresAt := rogue.MasterOfSubtletyAura.ExpiresAt(); expiresAt != time.Second*2+masterOfSubtletyDuration {
+		t.Fatalf("Expected Master of Subtlety to expire 6s after the opener, got %s", expiresAt)
+	}
+	if expiresAt := rogue.OverkillAura.ExpiresAt(); expiresAt != time.Second*2+overkillDuration {
+		t.Fatalf("Expected Overkill to expire 20s after the opener, got %s", expiresAt)
+	}
+
+	stepUntil(sim, time.Second*9)
+	if rogue.MasterOfSubtletyAura.IsActive() || !rogue.OverkillAura.IsActive() {
+		t.Fatalf("Expected only Overkill to remain 7s after the opener")
+	}
+	stepUntil(sim, time.Second*23)
+	if rogue.OverkillAura.IsActive() {
+		t.Fatalf("Expected Overkill to have expired 21s after the opener")
+	}
+}
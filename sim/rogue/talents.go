@@ -162,8 +162,9 @@ func (rogue *Rogue) registerHungerForBlood() {
 }
 
 func (rogue *Rogue) preyOnTheWeakMultiplier(_ *core.Unit) float64 {
-	// TODO: Use the following predicate if/when health values are modeled,
+	// TODO: Use the following predicate if/when target health values are modeled,
 	//  but note that this would have to be applied dynamically in that case.
+	//  Raid damage intake only models player health, and targets have no health bar.
 	//if rogue.CurrentTarget != nil &&
 	//rogue.CurrentTarget.HasHealthBar() &&
 	//rogue.CurrentTarget.CurrentHealthPercent() < rogue.CurrentHealthPercent()
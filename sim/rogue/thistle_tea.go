@@ -4,11 +4,10 @@ import (
 	"time"
 
 	"github.com/wowsims/wotlk/sim/core"
-	"github.com/wowsims/wotlk/sim/core/proto"
 )
 
 func (rogue *Rogue) registerThistleTeaCD() {
-	if rogue.Consumes.DefaultConjured != proto.Conjured_ConjuredRogueThistleTea {
+	if core.GetConsumableIDs(rogue.Consumes).DefaultConjured != 7676 {
 		return
 	}
 
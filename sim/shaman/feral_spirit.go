@@ -46,6 +46,10 @@ func (shaman *Shaman) registerFeralSpirit() {
 			shaman.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime, false)
 		},
 	})
+	if shaman.SpiritWolves != nil {
+		shaman.SpiritWolves.SpiritWolf1.SummonSpell = shaman.FeralSpirit
+		shaman.SpiritWolves.SpiritWolf2.SummonSpell = shaman.FeralSpirit
+	}
 
 	shaman.AddMajorCooldown(core.MajorCooldown{
 		Spell:    shaman.FeralSpirit,
@@ -212,10 +212,8 @@ func (shaman *Shaman) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 	}
 }
 func (shaman *Shaman) AddPartyBuffs(partyBuffs *proto.PartyBuffs) {
-	if shaman.Talents.ManaTideTotem {
-		partyBuffs.ManaTideTotems++
-	}
-
+	// Mana Tide Totem is not added to the party buffs, because the shaman casts it
+	// for their party directly (see registerManaTideTotemCD).
 	shaman.hasHeroicPresence = partyBuffs.HeroicPresence
 }
 
@@ -56,6 +56,7 @@ func (warlock *Warlock) registerInfernoSpell() {
 			summonInfernalAura.Activate(sim)
 		},
 	})
+	warlock.Infernal.SummonSpell = warlock.Inferno
 }
 
 type InfernalPet struct {
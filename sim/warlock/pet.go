@@ -22,6 +22,43 @@ type WarlockPet struct {
 
 const PetExpertiseScale = 1.53
 
+var summonDemonSpellIDs = map[proto.Warlock_Options_Summon]int32{
+	proto.Warlock_Options_Imp:       688,
+	proto.Warlock_Options_Succubus:  712,
+	proto.Warlock_Options_Felhunter: 691,
+	proto.Warlock_Options_Felguard:  30146,
+}
+
+func (warlock *Warlock) registerSummonDemonSpell() {
+	if warlock.Pet == nil {
+		return
+	}
+
+	warlock.Pet.SummonSpell = warlock.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: summonDemonSpellIDs[warlock.Options.Summon]},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskEmpty,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost:   core.TernaryFloat64(warlock.Options.Summon == proto.Warlock_Options_Imp, 0.64, 0.8),
+			Multiplier: 1 - 0.2*float64(warlock.Talents.MasterSummoner),
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				CastTime: time.Second*10 - time.Second*2*time.Duration(warlock.Talents.MasterSummoner),
+				GCD:      core.GCDDefault,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return !warlock.Pet.IsEnabled()
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			warlock.Pet.Enable(sim, warlock.Pet)
+		},
+	})
+}
+
 func (warlock *Warlock) NewWarlockPet() *WarlockPet {
 	var cfg struct {
 		Name          string
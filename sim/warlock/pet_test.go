@@ -0,0 +1,78 @@
+package warlock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func currentTimeCompare(op proto.APLValueCompare_ComparisonOperator, val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+		Op:  op,
+		Lhs: &proto.APLValue{Value: &proto.APLValue_CurrentTime{CurrentTime: &proto.APLValueCurrentTime{}}},
+		Rhs: &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}},
+	}}}
+}
+
+func TestDismissAndResummonDemon(t *testing.T) {
+	petRef := &proto.UnitReference{Type: proto.UnitReference_Pet, Owner: &proto.UnitReference{Type: proto.UnitReference_Self}}
+
+	raid := core.SinglePlayerRaidProto(core.WithSpec(&proto.Player{
+		Class:     proto.Class_ClassWarlock,
+		Race:      proto.Race_RaceOrc,
+		Equipment: &proto.EquipmentSpec{},
+		Rotation: &proto.APLRotation{
+			Type: proto.APLRotation_TypeAPL,
+			PriorityList: []*proto.APLListItem{
+				{Action: &proto.APLAction{
+					Condition: currentTimeCompare(proto.APLValueCompare_OpLt, "2s"),
+					Action:    &proto.APLAction_DismissPet{DismissPet: &proto.APLActionDismissPet{Pet: petRef}},
+				}},
+				{Action: &proto.APLAction{
+					Condition: currentTimeCompare(proto.APLValueCompare_OpGe, "3s"),
+					Action:    &proto.APLAction_SummonPet{SummonPet: &proto.APLActionSummonPet{Pet: petRef}},
+				}},
+			},
+		},
+	}, &proto.Player_Warlock{Warlock: &proto.Warlock{Options: &proto.Warlock_Options{Summon: proto.Warlock_Options_Felhunter}}}), nil, nil, nil)
+
+	sim := core.NewSim(&proto.RaidSimRequest{
+		Raid:       raid,
+		Encounter:  core.MakeSingleTargetEncounter(0),
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+	})
+	warlock := sim.Raid.Parties[0].Players[0].(WarlockAgent).GetWarlock()
+	summonSpell := warlock.Pet.SummonSpell
+	if summonSpell == nil || summonSpell.ActionID.SpellID != 691 {
+		t.Fatalf("Expected Summon Felhunter to be the pet's summon spell")
+	}
+
+	sim.Reset()
+	sim.PrePull()
+	stepUntil := func(until time.Duration) {
+		for sim.CurrentTime < until {
+			sim.Step()
+		}
+	}
+
+	// The permanent demon starts out summoned, and is dismissed at the pull.
+	stepUntil(time.Second)
+	if warlock.Pet.IsEnabled() {
+		t.Fatalf("Expected the demon to be dismissed")
+	}
+
+	// Re-summoning it takes the full 10s cast.
+	stepUntil(time.Second * 4)
+	if warlock.Hardcast.Expires <= sim.CurrentTime || warlock.Pet.IsEnabled() {
+		t.Fatalf("Expected the warlock to be casting Summon Felhunter")
+	}
+	stepUntil(time.Second * 15)
+	if !warlock.Pet.IsEnabled() {
+		t.Fatalf("Expected the demon to be summoned again")
+	}
+	if casts := summonSpell.SpellMetrics[0].Casts; casts != 1 {
+		t.Fatalf("Expected 1 cast of Summon Felhunter, got %d", casts)
+	}
+}
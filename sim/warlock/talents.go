@@ -59,7 +59,7 @@ func (warlock *Warlock) ApplyTalents() {
 	warlock.setupDecimation()
 	warlock.setupPyroclasm()
 	warlock.setupBackdraft()
-	warlock.setupImprovedSoulLeech()
+	warlock.setupSoulLeech()
 	warlock.setupEmpoweredImp()
 	warlock.setupGlyphOfLifeTapAura()
 }
@@ -470,23 +470,36 @@ func (warlock *Warlock) everlastingAfflictionRefresh(sim *core.Simulation, targe
 	}
 }
 
-func (warlock *Warlock) setupImprovedSoulLeech() {
-	if warlock.Talents.ImprovedSoulLeech <= 0 {
+func (warlock *Warlock) setupSoulLeech() {
+	if warlock.Talents.SoulLeech == 0 {
+		return
+	}
+
+	// The health return only matters when incoming damage is modelled, so skip the
+	// proc entirely unless it is needed for either effect.
+	restoresHealth := warlock.HealthIsModelled()
+	if warlock.Talents.ImprovedSoulLeech <= 0 && !restoresHealth {
 		return
 	}
 
 	soulLeechProcChance := 0.1 * float64(warlock.Talents.SoulLeech)
+	healthMetrics := warlock.NewHealthMetrics(core.ActionID{SpellID: 30295})
+
 	impSoulLeechProcChance := float64(warlock.Talents.ImprovedSoulLeech) / 2.
 	actionID := core.ActionID{SpellID: 54118}
-	impSoulLeechManaMetric := warlock.NewManaMetrics(actionID)
+	var impSoulLeechManaMetric *core.ResourceMetrics
 	var impSoulLeechPetManaMetric *core.ResourceMetrics
-	if warlock.Pet != nil {
-		impSoulLeechPetManaMetric = warlock.Pet.NewManaMetrics(actionID)
+	var replSrc core.ReplenishmentSource
+	if warlock.Talents.ImprovedSoulLeech > 0 {
+		impSoulLeechManaMetric = warlock.NewManaMetrics(actionID)
+		if warlock.Pet != nil {
+			impSoulLeechPetManaMetric = warlock.Pet.NewManaMetrics(actionID)
+		}
+		replSrc = warlock.Env.Raid.NewReplenishmentSource(core.ActionID{SpellID: 54118})
 	}
-	replSrc := warlock.Env.Raid.NewReplenishmentSource(core.ActionID{SpellID: 54118})
 
 	warlock.RegisterAura(core.Aura{
-		Label:    "Improved Soul Leech Hidden Aura",
+		Label:    "Soul Leech Hidden Aura",
 		Duration: core.NeverExpires,
 		OnReset: func(aura *core.Aura, sim *core.Simulation) {
 			aura.Activate(sim)
@@ -498,6 +511,14 @@ func (warlock *Warlock) setupImprovedSoulLeech() {
 					return
 				}
 
+				if restoresHealth {
+					warlock.GainHealth(sim, 0.2*result.Damage*warlock.PseudoStats.HealingTakenMultiplier, healthMetrics)
+				}
+
+				if warlock.Talents.ImprovedSoulLeech <= 0 {
+					return
+				}
+
 				restorePct := float64(warlock.Talents.ImprovedSoulLeech) / 100
 				warlock.AddMana(sim, warlock.MaxMana()*restorePct, impSoulLeechManaMetric)
 				pet := warlock.Pet
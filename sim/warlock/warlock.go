@@ -113,6 +113,7 @@ func (warlock *Warlock) Initialize() {
 	warlock.registerShadowBurnSpell()
 	warlock.registerSearingPainSpell()
 	warlock.registerInfernoSpell()
+	warlock.registerSummonDemonSpell()
 	warlock.registerBlackBook()
 
 	// Do this post-finalize so cast speed is updated with new stats
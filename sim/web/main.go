@@ -48,9 +48,16 @@ func main() {
 	var host = flag.String("host", "localhost:3333", "URL to host the interface on.")
 	var launch = flag.Bool("launch", true, "auto launch browser")
 	var skipVersionCheck = flag.Bool("nvc", false, "set true to skip version check")
+	var itemEffects = flag.String("item_effects", "", "JSON file of extra item effects to load")
 
 	flag.Parse()
 
+	if *itemEffects != "" {
+		if err := core.LoadItemEffectConfigsFile(*itemEffects); err != nil {
+			log.Fatalf("Failed to load item effects: %s", err)
+		}
+	}
+
 	fmt.Printf("Version: %s\n", Version)
 	if !*skipVersionCheck && Version != "development" {
 		go func() {
@@ -100,6 +107,30 @@ var handlers = map[string]apiHandler{
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
+	"/envReset": {msg: func() googleProto.Message { return &proto.EnvResetRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.EnvReset(msg.(*proto.EnvResetRequest))
+	}},
+	"/envStep": {msg: func() googleProto.Message { return &proto.EnvStepRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.EnvStep(msg.(*proto.EnvStepRequest))
+	}},
+	"/envClose": {msg: func() googleProto.Message { return &proto.EnvCloseRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.EnvClose(msg.(*proto.EnvCloseRequest))
+	}},
+	"/aplTune": {msg: func() googleProto.Message { return &proto.APLTuneRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunAPLTune(msg.(*proto.APLTuneRequest))
+	}},
+	"/aplCheck": {msg: func() googleProto.Message { return &proto.APLCheckRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunAPLCheck(msg.(*proto.APLCheckRequest))
+	}},
+	"/upgradeFinder": {msg: func() googleProto.Message { return &proto.UpgradeFinderRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunUpgradeFinder(msg.(*proto.UpgradeFinderRequest))
+	}},
+	"/consumableOptimizer": {msg: func() googleProto.Message { return &proto.ConsumableOptimizerRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunConsumableOptimizer(msg.(*proto.ConsumableOptimizerRequest))
+	}},
+	"/setAnalysis": {msg: func() googleProto.Message { return &proto.SetAnalysisRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RunSetAnalysis(msg.(*proto.SetAnalysisRequest))
+	}},
 }
 
 var asyncAPIHandlers = map[string]asyncAPIHandler{
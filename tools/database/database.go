@@ -39,8 +39,9 @@ type WowDatabase struct {
 	ItemIcons  map[int32]*proto.IconData
 	SpellIcons map[int32]*proto.IconData
 
-	Encounters []*proto.PresetEncounter
-	GlyphIDs   []*proto.GlyphID
+	Encounters  []*proto.PresetEncounter
+	GlyphIDs    []*proto.GlyphID
+	Consumables []*proto.SimConsumable
 }
 
 func NewWowDatabase() *WowDatabase {
@@ -203,15 +204,16 @@ func (db *WowDatabase) ToUIProto() *proto.UIDatabase {
 	})
 
 	return &proto.UIDatabase{
-		Items:      mapToSlice(db.Items),
-		Enchants:   enchants,
-		Gems:       mapToSlice(db.Gems),
-		Encounters: db.Encounters,
-		Zones:      mapToSlice(db.Zones),
-		Npcs:       mapToSlice(db.Npcs),
-		ItemIcons:  mapToSlice(db.ItemIcons),
-		SpellIcons: mapToSlice(db.SpellIcons),
-		GlyphIds:   db.GlyphIDs,
+		Items:       mapToSlice(db.Items),
+		Enchants:    enchants,
+		Gems:        mapToSlice(db.Gems),
+		Encounters:  db.Encounters,
+		Zones:       mapToSlice(db.Zones),
+		Npcs:        mapToSlice(db.Npcs),
+		ItemIcons:   mapToSlice(db.ItemIcons),
+		SpellIcons:  mapToSlice(db.SpellIcons),
+		GlyphIds:    db.GlyphIDs,
+		Consumables: db.Consumables,
 	}
 }
 
@@ -287,6 +289,8 @@ func (db *WowDatabase) WriteJson(jsonFilePath string) {
 	tools.WriteProtoArrayToBuffer(uidb.Encounters, buffer, "encounters")
 	buffer.WriteString(",\n")
 	tools.WriteProtoArrayToBuffer(uidb.GlyphIds, buffer, "glyphIds")
+	buffer.WriteString(",\n")
+	tools.WriteProtoArrayToBuffer(uidb.Consumables, buffer, "consumables")
 	buffer.WriteString("\n")
 
 	buffer.WriteString("}")
@@ -0,0 +1,283 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// A field that differs between two versions of an item, gem or enchant.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+
+	// Whether the change can alter sim results, rather than only what the UI shows.
+	AffectsSim bool `json:"affectsSim"`
+}
+
+type EntryDiff struct {
+	Kind    string        `json:"kind"`
+	ID      int32         `json:"id"`
+	Name    string        `json:"name"`
+	Changes []FieldChange `json:"changes,omitempty"`
+
+	// Gear sets of the spec test suites which use this entry, if it was removed
+	// or changed in a way that can alter sim results.
+	TestGearSets []string `json:"testGearSets,omitempty"`
+}
+
+func (entry *EntryDiff) AffectsSim() bool {
+	return slices.ContainsFunc(entry.Changes, func(change FieldChange) bool { return change.AffectsSim })
+}
+
+type DatabaseDiff struct {
+	Added   []EntryDiff `json:"added"`
+	Removed []EntryDiff `json:"removed"`
+	Changed []EntryDiff `json:"changed"`
+}
+
+// Reads a UIDatabase written by WriteBinary or WriteJson, based on the file extension.
+func ReadUIDatabaseFile(path string) (*proto.UIDatabase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	db := &proto.UIDatabase{}
+	if filepath.Ext(path) == ".json" {
+		err = protojson.Unmarshal(data, db)
+	} else {
+		err = googleProto.Unmarshal(data, db)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Compares the items, gems and enchants of two databases.
+func DiffDatabases(oldDB, newDB *proto.UIDatabase) *DatabaseDiff {
+	diff := &DatabaseDiff{}
+	diffEntries(diff, "item", oldDB.Items, newDB.Items,
+		func(item *proto.UIItem) int32 { return item.Id },
+		func(item *proto.UIItem) (int32, string) { return item.Id, item.Name },
+		diffItems)
+	diffEntries(diff, "gem", oldDB.Gems, newDB.Gems,
+		func(gem *proto.UIGem) int32 { return gem.Id },
+		func(gem *proto.UIGem) (int32, string) { return gem.Id, gem.Name },
+		diffGems)
+	diffEntries(diff, "enchant", oldDB.Enchants, newDB.Enchants,
+		EnchantToDBKey,
+		func(enchant *proto.UIEnchant) (int32, string) { return enchant.EffectId, enchant.Name },
+		diffEnchants)
+	return diff
+}
+
+func diffEntries[T any, K comparable](diff *DatabaseDiff, kind string, oldEntries, newEntries []T, getKey func(T) K, describe func(T) (int32, string), diffFields func(T, T) []FieldChange) {
+	newEntry := func(entry T, changes []FieldChange) EntryDiff {
+		id, name := describe(entry)
+		return EntryDiff{Kind: kind, ID: id, Name: name, Changes: changes}
+	}
+
+	oldByKey := make(map[K]T, len(oldEntries))
+	for _, entry := range oldEntries {
+		oldByKey[getKey(entry)] = entry
+	}
+	newKeys := make(map[K]bool, len(newEntries))
+
+	for _, entry := range newEntries {
+		key := getKey(entry)
+		newKeys[key] = true
+		oldEntry, ok := oldByKey[key]
+		if !ok {
+			diff.Added = append(diff.Added, newEntry(entry, nil))
+		} else if changes := diffFields(oldEntry, entry); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, newEntry(entry, changes))
+		}
+	}
+
+	for _, entry := range oldEntries {
+		if !newKeys[getKey(entry)] {
+			diff.Removed = append(diff.Removed, newEntry(entry, nil))
+		}
+	}
+}
+
+type fieldChanges []FieldChange
+
+func (changes *fieldChanges) add(field string, oldValue, newValue interface{}, affectsSim bool) {
+	oldStr, newStr := fmt.Sprint(oldValue), fmt.Sprint(newValue)
+	if oldStr != newStr {
+		*changes = append(*changes, FieldChange{Field: field, Old: oldStr, New: newStr, AffectsSim: affectsSim})
+	}
+}
+
+// Adds a change for each stat with a different value.
+func (changes *fieldChanges) addStats(prefix string, oldStats, newStats []float64) {
+	oldValues, newValues := stats.FromFloatArray(oldStats), stats.FromFloatArray(newStats)
+	for i := range oldValues {
+		changes.add(prefix+stats.Stat(i).StatName(), oldValues[i], newValues[i], true)
+	}
+}
+
+func diffItems(oldItem, newItem *proto.UIItem) []FieldChange {
+	var changes fieldChanges
+	changes.add("name", oldItem.Name, newItem.Name, false)
+	changes.add("type", oldItem.Type, newItem.Type, true)
+	changes.add("armorType", oldItem.ArmorType, newItem.ArmorType, true)
+	changes.add("weaponType", oldItem.WeaponType, newItem.WeaponType, true)
+	changes.add("handType", oldItem.HandType, newItem.HandType, true)
+	changes.add("rangedWeaponType", oldItem.RangedWeaponType, newItem.RangedWeaponType, true)
+	changes.addStats("", oldItem.Stats, newItem.Stats)
+	changes.add("sockets", oldItem.GemSockets, newItem.GemSockets, true)
+	changes.addStats("socketBonus.", oldItem.SocketBonus, newItem.SocketBonus)
+	changes.add("weaponDamageMin", oldItem.WeaponDamageMin, newItem.WeaponDamageMin, true)
+	changes.add("weaponDamageMax", oldItem.WeaponDamageMax, newItem.WeaponDamageMax, true)
+	changes.add("weaponSpeed", oldItem.WeaponSpeed, newItem.WeaponSpeed, true)
+	changes.add("setName", oldItem.SetName, newItem.SetName, true)
+	changes.add("ilvl", oldItem.Ilvl, newItem.Ilvl, false)
+	changes.add("phase", oldItem.Phase, newItem.Phase, false)
+	changes.add("quality", oldItem.Quality, newItem.Quality, false)
+	changes.add("hasSpecialEffect", oldItem.HasSpecialEffect, newItem.HasSpecialEffect, false)
+	changes.add("sources", formatSources(oldItem.Sources), formatSources(newItem.Sources), false)
+	return changes
+}
+
+func diffGems(oldGem, newGem *proto.UIGem) []FieldChange {
+	var changes fieldChanges
+	changes.add("name", oldGem.Name, newGem.Name, false)
+	changes.add("color", oldGem.Color, newGem.Color, true)
+	changes.addStats("", oldGem.Stats, newGem.Stats)
+	changes.add("phase", oldGem.Phase, newGem.Phase, false)
+	changes.add("hasSpecialEffect", oldGem.HasSpecialEffect, newGem.HasSpecialEffect, false)
+	return changes
+}
+
+func diffEnchants(oldEnchant, newEnchant *proto.UIEnchant) []FieldChange {
+	var changes fieldChanges
+	changes.add("name", oldEnchant.Name, newEnchant.Name, false)
+	changes.add("type", oldEnchant.Type, newEnchant.Type, false)
+	changes.addStats("", oldEnchant.Stats, newEnchant.Stats)
+	changes.add("phase", oldEnchant.Phase, newEnchant.Phase, false)
+	changes.add("hasSpecialEffect", oldEnchant.HasSpecialEffect, newEnchant.HasSpecialEffect, false)
+	return changes
+}
+
+func formatSources(sources []*proto.UIItemSource) string {
+	formatted := make([]string, len(sources))
+	for i, source := range sources {
+		switch s := source.Source.(type) {
+		case *proto.UIItemSource_Drop:
+			formatted[i] = fmt.Sprintf("drop(npc=%d zone=%d %s)", s.Drop.NpcId, s.Drop.ZoneId, s.Drop.Difficulty)
+		case *proto.UIItemSource_Crafted:
+			formatted[i] = fmt.Sprintf("crafted(%s spell=%d)", s.Crafted.Profession, s.Crafted.SpellId)
+		case *proto.UIItemSource_Quest:
+			formatted[i] = fmt.Sprintf("quest(%d)", s.Quest.Id)
+		case *proto.UIItemSource_SoldBy:
+			formatted[i] = fmt.Sprintf("soldBy(npc=%d)", s.SoldBy.NpcId)
+		}
+	}
+	slices.Sort(formatted)
+	return "[" + strings.Join(formatted, " ") + "]"
+}
+
+var getGearSetRegex = regexp.MustCompile(`GetGearSet\("([^"]+)",\s*"([^"]+)"\)`)
+
+// Gear sets of the spec test suites, by entry kind and then by the IDs they contain.
+type TestGearSetUsage map[string]map[int32][]string
+
+// Finds the gear sets used by the spec test suites under simDir.
+func FindTestGearSets(simDir string) (TestGearSetUsage, error) {
+	usage := TestGearSetUsage{"item": {}, "gem": {}, "enchant": {}}
+	err := filepath.WalkDir(simDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, match := range getGearSetRegex.FindAllStringSubmatch(string(src), -1) {
+			gearPath := filepath.Join(filepath.Dir(path), match[1], match[2]+".gear.json")
+			data, err := os.ReadFile(gearPath)
+			if err != nil {
+				return fmt.Errorf("gear set used by %s: %w", path, err)
+			}
+			gearSet := &proto.EquipmentSpec{}
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, gearSet); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", gearPath, err)
+			}
+
+			label := filepath.Base(filepath.Dir(filepath.Join(filepath.Dir(path), match[1]))) + "/" + match[2]
+			addUsage := func(kind string, id int32) {
+				if id != 0 && !slices.Contains(usage[kind][id], label) {
+					usage[kind][id] = append(usage[kind][id], label)
+				}
+			}
+			for _, item := range gearSet.Items {
+				addUsage("item", item.Id)
+				addUsage("enchant", item.Enchant)
+				for _, gem := range item.Gems {
+					addUsage("gem", gem)
+				}
+			}
+		}
+		return nil
+	})
+	return usage, err
+}
+
+// Fills in TestGearSets for the removed entries, and for the changed entries
+// whose changes can alter sim results.
+func (diff *DatabaseDiff) FlagTestGearSets(usage TestGearSetUsage) {
+	for i := range diff.Removed {
+		entry := &diff.Removed[i]
+		entry.TestGearSets = usage[entry.Kind][entry.ID]
+	}
+	for i := range diff.Changed {
+		entry := &diff.Changed[i]
+		if entry.AffectsSim() {
+			entry.TestGearSets = usage[entry.Kind][entry.ID]
+		}
+	}
+}
+
+func (diff *DatabaseDiff) ToJson() ([]byte, error) {
+	return json.MarshalIndent(diff, "", "  ")
+}
+
+func (diff *DatabaseDiff) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+
+	for _, entry := range diff.Added {
+		fmt.Fprintf(&sb, "+ %s %d %s\n", entry.Kind, entry.ID, entry.Name)
+	}
+	for _, entry := range diff.Removed {
+		fmt.Fprintf(&sb, "- %s %d %s\n", entry.Kind, entry.ID, entry.Name)
+		if len(entry.TestGearSets) > 0 {
+			fmt.Fprintf(&sb, "    ! breaks spec tests using %s\n", strings.Join(entry.TestGearSets, ", "))
+		}
+	}
+	for _, entry := range diff.Changed {
+		fmt.Fprintf(&sb, "~ %s %d %s\n", entry.Kind, entry.ID, entry.Name)
+		for _, change := range entry.Changes {
+			fmt.Fprintf(&sb, "    %s: %s -> %s\n", change.Field, change.Old, change.New)
+		}
+		if len(entry.TestGearSets) > 0 {
+			fmt.Fprintf(&sb, "    ! changes results of spec tests using %s\n", strings.Join(entry.TestGearSets, ", "))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+func TestDiffDatabases(t *testing.T) {
+	oldDB := &proto.UIDatabase{
+		Items: []*proto.UIItem{
+			{Id: 1, Name: "Kept", Stats: stats.Stats{stats.Strength: 10}.ToFloatArray()},
+			{Id: 2, Name: "Buffed", Stats: stats.Stats{stats.Strength: 10}.ToFloatArray()},
+			{Id: 3, Name: "Renamed"},
+			{Id: 4, Name: "Removed"},
+		},
+		Gems:     []*proto.UIGem{{Id: 10, Name: "Gem", Color: proto.GemColor_GemColorRed}},
+		Enchants: []*proto.UIEnchant{{EffectId: 20, Name: "Enchant", Type: proto.ItemType_ItemTypeWeapon}},
+	}
+	newDB := &proto.UIDatabase{
+		Items: []*proto.UIItem{
+			{Id: 1, Name: "Kept", Stats: stats.Stats{stats.Strength: 10}.ToFloatArray()},
+			{Id: 2, Name: "Buffed", Stats: stats.Stats{stats.Strength: 12}.ToFloatArray()},
+			{Id: 3, Name: "Renamed Item"},
+			{Id: 5, Name: "Added"},
+		},
+		Gems:     []*proto.UIGem{{Id: 10, Name: "Gem", Color: proto.GemColor_GemColorOrange}},
+		Enchants: []*proto.UIEnchant{{EffectId: 20, Name: "Enchant", Type: proto.ItemType_ItemTypeWeapon}},
+	}
+
+	diff := DiffDatabases(oldDB, newDB)
+	if len(diff.Added) != 1 || diff.Added[0].ID != 5 {
+		t.Fatalf("Expected item 5 to be added, got %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != 4 {
+		t.Fatalf("Expected item 4 to be removed, got %v", diff.Removed)
+	}
+	if len(diff.Changed) != 3 {
+		t.Fatalf("Expected 3 changed entries, got %v", diff.Changed)
+	}
+
+	expected := []struct {
+		kind       string
+		id         int32
+		field      string
+		affectsSim bool
+	}{
+		{"item", 2, "Strength", true},
+		{"item", 3, "name", false},
+		{"gem", 10, "color", true},
+	}
+	for i, exp := range expected {
+		entry := diff.Changed[i]
+		if entry.Kind != exp.kind || entry.ID != exp.id || len(entry.Changes) != 1 || entry.Changes[0].Field != exp.field {
+			t.Fatalf("Expected a %s change to %s %d, got %v", exp.field, exp.kind, exp.id, entry)
+		}
+		if entry.AffectsSim() != exp.affectsSim {
+			t.Fatalf("Expected %s %d AffectsSim to be %t", exp.kind, exp.id, exp.affectsSim)
+		}
+	}
+}
+
+func TestFindTestGearSets(t *testing.T) {
+	usage, err := FindTestGearSets("testdata/spec_tests/sim")
+	if err != nil {
+		t.Fatalf("Failed to find test gear sets: %v", err)
+	}
+
+	expected := []string{"warrior/p1_fury"}
+	for kind, id := range map[string]int32{"item": 40528, "gem": 42153, "enchant": 3789} {
+		if !slices.Equal(usage[kind][id], expected) {
+			t.Fatalf("Expected %s %d to be used by %v, got %v", kind, id, expected, usage[kind][id])
+		}
+	}
+	if len(usage["item"][12345]) != 0 {
+		t.Fatalf("Expected item 12345 to be unused, got %v", usage["item"][12345])
+	}
+}
+
+func TestFlagTestGearSets(t *testing.T) {
+	usage := TestGearSetUsage{"item": {
+		1: {"warrior/p1_fury"},
+		2: {"warrior/p1_fury"},
+		3: {"warrior/p1_fury"},
+	}}
+	diff := &DatabaseDiff{
+		Removed: []EntryDiff{{Kind: "item", ID: 1}},
+		Changed: []EntryDiff{
+			{Kind: "item", ID: 2, Changes: []FieldChange{{Field: "Strength", AffectsSim: true}}},
+			{Kind: "item", ID: 3, Changes: []FieldChange{{Field: "name"}}},
+		},
+	}
+	diff.FlagTestGearSets(usage)
+
+	if len(diff.Removed[0].TestGearSets) != 1 {
+		t.Fatalf("Expected the removed item to be flagged, got %v", diff.Removed[0].TestGearSets)
+	}
+	if len(diff.Changed[0].TestGearSets) != 1 {
+		t.Fatalf("Expected the item with changed stats to be flagged, got %v", diff.Changed[0].TestGearSets)
+	}
+	if len(diff.Changed[1].TestGearSets) != 0 {
+		t.Fatalf("Expected the renamed item not to be flagged, got %v", diff.Changed[1].TestGearSets)
+	}
+}
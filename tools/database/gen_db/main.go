@@ -26,11 +26,15 @@ import (
 // go run ./tools/database/gen_db -outDir=assets -gen=wotlk-items
 // go run ./tools/database/gen_db -outDir=assets -gen=wago-db2-items
 // go run ./tools/database/gen_db -outDir=assets -gen=db
+// go run ./tools/database/gen_db -outDir=assets -gen=diff -oldDB=old_db.json [-newDB=assets/database/db.json] [-diffJson=diff.json]
 
 var minId = flag.Int("minid", 1, "Minimum ID to scan for")
 var maxId = flag.Int("maxid", 57000, "Maximum ID to scan for")
 var outDir = flag.String("outDir", "assets", "Path to output directory for writing generated .go files.")
-var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'wotlk-items', and 'wago-db2-items'")
+var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'diff', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'wotlk-items', and 'wago-db2-items'")
+var oldDB = flag.String("oldDB", "", "For -gen=diff, path to the previous db.json or db.bin.")
+var newDB = flag.String("newDB", "", "For -gen=diff, path to the new db.json or db.bin. Defaults to the db.json in outDir.")
+var diffJson = flag.String("diffJson", "", "For -gen=diff, optional path to also write the diff as JSON.")
 
 func main() {
 	flag.Parse()
@@ -60,6 +64,12 @@ func main() {
 	} else if *genAsset == "wago-db2-items" {
 		tools.WriteFile(fmt.Sprintf("%s/wago_db2_items.csv", inputsDir), tools.ReadWebRequired("https://wago.tools/db2/ItemSparse/csv?build=3.4.2.49311"))
 		return
+	} else if *genAsset == "diff" {
+		if *newDB == "" {
+			*newDB = fmt.Sprintf("%s/db.json", dbDir)
+		}
+		diffDatabases(*oldDB, *newDB, *diffJson)
+		return
 	} else if *genAsset != "db" {
 		panic("Invalid gen value")
 	}
@@ -72,6 +82,7 @@ func main() {
 
 	db := database.NewWowDatabase()
 	db.Encounters = core.PresetEncounters
+	db.Consumables = core.DefaultConsumables
 	db.GlyphIDs = getGlyphIDsFromJson(fmt.Sprintf("%s/glyph_id_map.json", inputsDir))
 
 	for _, response := range itemTooltips {
@@ -580,3 +591,33 @@ func GetAllRotationSpellIds() map[string][]int32 {
 	}
 	return ret_db
 }
+
+func diffDatabases(oldPath, newPath, jsonPath string) {
+	if oldPath == "" {
+		panic("oldDB flag is required for -gen=diff")
+	}
+	oldDB, err := database.ReadUIDatabaseFile(oldPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	newDB, err := database.ReadUIDatabaseFile(newPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	diff := database.DiffDatabases(oldDB, newDB)
+	usage, err := database.FindTestGearSets("sim")
+	if err != nil {
+		log.Fatal(err)
+	}
+	diff.FlagTestGearSets(usage)
+
+	fmt.Print(diff.String())
+	if jsonPath != "" {
+		data, err := diff.ToJson()
+		if err != nil {
+			log.Fatal(err)
+		}
+		tools.WriteFile(jsonPath, string(data))
+	}
+}
@@ -0,0 +1,13 @@
+package dps
+
+import (
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core"
+)
+
+func TestFury(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
+		GearSet: core.GetGearSet("../../../ui/warrior/gear_sets", "p1_fury"),
+	}))
+}
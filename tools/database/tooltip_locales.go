@@ -0,0 +1,288 @@
+package database
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Tooltips mark the stats of an item with comments carrying the game's item
+// stat type, e.g. <!--stat3-->+64 Agility or <!--rtg38-->86, which read the
+// same in every locale. Only the stats which some tooltips write without a
+// marker, and the socket texts, need per-locale patterns.
+var tooltipStatMarkerRegex = regexp.MustCompile(`<!--(?:stat|rtg)([0-9]+)-->[^0-9<]*([0-9]+)`)
+var tooltipArmorMarkerRegex = regexp.MustCompile(`<!--amr-->[^0-9<]*([0-9]+)`)
+var tooltipItemLevelMarkerRegex = regexp.MustCompile(`<!--ilvl-->\s*([0-9]+)`)
+var tooltipSocketRegex = regexp.MustCompile(`<span class="socket-([a-z]+)[^"]*"[^>]*>(.*?)</span>`)
+var tooltipGemMarkerRegex = regexp.MustCompile(`<!--gem([0-9]+):`)
+
+// Sim stats for each of the game's item stat types.
+var itemStatTypes = map[int][]proto.Stat{
+	3:  {proto.Stat_StatAgility},
+	4:  {proto.Stat_StatStrength},
+	5:  {proto.Stat_StatIntellect},
+	6:  {proto.Stat_StatSpirit},
+	7:  {proto.Stat_StatStamina},
+	12: {proto.Stat_StatDefense},
+	13: {proto.Stat_StatDodge},
+	14: {proto.Stat_StatParry},
+	15: {proto.Stat_StatBlock},
+	16: {proto.Stat_StatMeleeHit},
+	18: {proto.Stat_StatSpellHit},
+	19: {proto.Stat_StatMeleeCrit},
+	21: {proto.Stat_StatSpellCrit},
+	28: {proto.Stat_StatMeleeHaste},
+	30: {proto.Stat_StatSpellHaste},
+	31: {proto.Stat_StatMeleeHit, proto.Stat_StatSpellHit},
+	32: {proto.Stat_StatMeleeCrit, proto.Stat_StatSpellCrit},
+	35: {proto.Stat_StatResilience},
+	36: {proto.Stat_StatMeleeHaste, proto.Stat_StatSpellHaste},
+	37: {proto.Stat_StatExpertise},
+	38: {proto.Stat_StatAttackPower, proto.Stat_StatRangedAttackPower},
+	39: {proto.Stat_StatRangedAttackPower},
+	43: {proto.Stat_StatMP5},
+	44: {proto.Stat_StatArmorPenetration},
+	45: {proto.Stat_StatSpellPower},
+	47: {proto.Stat_StatSpellPenetration},
+	48: {proto.Stat_StatBlockValue},
+}
+
+// The game's socket color flags, which gem markers carry for filled sockets.
+var socketColorCodes = map[int]proto.GemColor{
+	1:  proto.GemColor_GemColorMeta,
+	2:  proto.GemColor_GemColorRed,
+	4:  proto.GemColor_GemColorYellow,
+	8:  proto.GemColor_GemColorBlue,
+	14: proto.GemColor_GemColorPrismatic,
+}
+
+var socketClassColors = map[string]proto.GemColor{
+	"meta":      proto.GemColor_GemColorMeta,
+	"red":       proto.GemColor_GemColorRed,
+	"yellow":    proto.GemColor_GemColorYellow,
+	"blue":      proto.GemColor_GemColorBlue,
+	"prismatic": proto.GemColor_GemColorPrismatic,
+}
+
+type statPattern struct {
+	stats   []proto.Stat
+	pattern *regexp.Regexp
+}
+
+func newStatPattern(pattern string, stats ...proto.Stat) statPattern {
+	return statPattern{stats: stats, pattern: regexp.MustCompile(pattern)}
+}
+
+type tooltipLocale struct {
+	// Where the set bonuses start, to leave them out of the item's own stats.
+	setBonusRegex *regexp.Regexp
+
+	socketBonusRegex *regexp.Regexp
+	socketColors     map[proto.GemColor]*regexp.Regexp
+	heroicRegex      *regexp.Regexp
+
+	// Stats written without a marker, added to the marked ones.
+	unmarkedStats []statPattern
+
+	// Stats as written in socket bonuses.
+	bonusStats []statPattern
+}
+
+var tooltipLocales = []tooltipLocale{
+	// English
+	{
+		setBonusRegex:    regexp.MustCompile(`Set : `),
+		socketBonusRegex: regexp.MustCompile(`<span class="q[0-9]">Socket Bonus: (.*?)</span>`),
+		socketColors: map[proto.GemColor]*regexp.Regexp{
+			proto.GemColor_GemColorMeta:      regexp.MustCompile(`Meta Socket`),
+			proto.GemColor_GemColorRed:       regexp.MustCompile(`Red Socket`),
+			proto.GemColor_GemColorYellow:    regexp.MustCompile(`Yellow Socket`),
+			proto.GemColor_GemColorBlue:      regexp.MustCompile(`Blue Socket`),
+			proto.GemColor_GemColorPrismatic: regexp.MustCompile(`Prismatic Socket`),
+		},
+		heroicRegex: regexp.MustCompile(`<span class="q2">Heroic</span>`),
+		unmarkedStats: []statPattern{
+			newStatPattern(`Equip: Increases spell power by ([0-9]+)`, proto.Stat_StatSpellPower),
+			newStatPattern(`Restores ([0-9]+) mana per 5 sec`, proto.Stat_StatMP5),
+			newStatPattern(`Increases attack power by ([0-9]+)\.`, proto.Stat_StatAttackPower, proto.Stat_StatRangedAttackPower),
+			newStatPattern(`Increases ranged attack power by ([0-9]+)`, proto.Stat_StatRangedAttackPower),
+			newStatPattern(`Increases your spell penetration by ([0-9]+)`, proto.Stat_StatSpellPenetration),
+			newStatPattern(`Equip: Increases defense rating by ([0-9]+)`, proto.Stat_StatDefense),
+			newStatPattern(`Equip: Increases your shield block rating by ([0-9]+)`, proto.Stat_StatBlock),
+			newStatPattern(`Equip: Increases the block value of your shield by ([0-9]+)\.`, proto.Stat_StatBlockValue),
+			newStatPattern(`<span>([0-9]+) Block</span>`, proto.Stat_StatBlockValue),
+			newStatPattern(`Increases your dodge rating by ([0-9]+)`, proto.Stat_StatDodge),
+			newStatPattern(`Increases your parry rating by ([0-9]+)`, proto.Stat_StatParry),
+			newStatPattern(`\+([0-9]+) Arcane Resistance`, proto.Stat_StatArcaneResistance),
+			newStatPattern(`\+([0-9]+) Fire Resistance`, proto.Stat_StatFireResistance),
+			newStatPattern(`\+([0-9]+) Frost Resistance`, proto.Stat_StatFrostResistance),
+			newStatPattern(`\+([0-9]+) Nature Resistance`, proto.Stat_StatNatureResistance),
+			newStatPattern(`\+([0-9]+) Shadow Resistance`, proto.Stat_StatShadowResistance),
+		},
+		bonusStats: []statPattern{
+			newStatPattern(`\+([0-9]+) Strength`, proto.Stat_StatStrength),
+			newStatPattern(`\+([0-9]+) Agility`, proto.Stat_StatAgility),
+			newStatPattern(`\+([0-9]+) Stamina`, proto.Stat_StatStamina),
+			newStatPattern(`\+([0-9]+) Intellect`, proto.Stat_StatIntellect),
+			newStatPattern(`\+([0-9]+) Spirit`, proto.Stat_StatSpirit),
+			newStatPattern(`\+([0-9]+) Spell Power`, proto.Stat_StatSpellPower),
+			newStatPattern(`\+([0-9]+) Hit Rating`, proto.Stat_StatMeleeHit, proto.Stat_StatSpellHit),
+			newStatPattern(`\+([0-9]+) Critical Strike Rating`, proto.Stat_StatMeleeCrit, proto.Stat_StatSpellCrit),
+			newStatPattern(`\+([0-9]+) Haste Rating`, proto.Stat_StatMeleeHaste, proto.Stat_StatSpellHaste),
+			newStatPattern(`([0-9]+) [Mm]ana per 5 sec`, proto.Stat_StatMP5),
+			newStatPattern(`\+([0-9]+) Attack Power`, proto.Stat_StatAttackPower, proto.Stat_StatRangedAttackPower),
+			newStatPattern(`\+([0-9]+) Armor Penetration Rating`, proto.Stat_StatArmorPenetration),
+			newStatPattern(`\+([0-9]+) Expertise Rating`, proto.Stat_StatExpertise),
+			newStatPattern(`\+([0-9]+) Defense Rating`, proto.Stat_StatDefense),
+			newStatPattern(`\+([0-9]+) Block Rating`, proto.Stat_StatBlock),
+			newStatPattern(`\+([0-9]+) Dodge Rating`, proto.Stat_StatDodge),
+			newStatPattern(`\+([0-9]+) Parry Rating`, proto.Stat_StatParry),
+			newStatPattern(`\+([0-9]+) Resilience Rating`, proto.Stat_StatResilience),
+		},
+	},
+	// Russian
+	{
+		setBonusRegex:    regexp.MustCompile(`Комплект ?: `),
+		socketBonusRegex: regexp.MustCompile(`<span class="q[0-9]">При соответствии цвета: (.*?)</span>`),
+		socketColors: map[proto.GemColor]*regexp.Regexp{
+			proto.GemColor_GemColorMeta:      regexp.MustCompile(`Особое гнездо`),
+			proto.GemColor_GemColorRed:       regexp.MustCompile(`Красное гнездо`),
+			proto.GemColor_GemColorYellow:    regexp.MustCompile(`Желтое гнездо`),
+			proto.GemColor_GemColorBlue:      regexp.MustCompile(`Синее гнездо`),
+			proto.GemColor_GemColorPrismatic: regexp.MustCompile(`Радужное гнездо`),
+		},
+		heroicRegex: regexp.MustCompile(`<span class="q2">Героический</span>`),
+		unmarkedStats: []statPattern{
+			newStatPattern(`Если на персонаже: Увеличивает силу заклинаний на ([0-9]+)`, proto.Stat_StatSpellPower),
+			newStatPattern(`Восполнение ([0-9]+) ед\. маны раз в 5 сек`, proto.Stat_StatMP5),
+			newStatPattern(`Увеличивает силу атаки на ([0-9]+)\.`, proto.Stat_StatAttackPower, proto.Stat_StatRangedAttackPower),
+			newStatPattern(`Увеличивает силу атаки дальнего боя на ([0-9]+)`, proto.Stat_StatRangedAttackPower),
+			newStatPattern(`Увеличивает показатель проникающей способности заклинаний на ([0-9]+)`, proto.Stat_StatSpellPenetration),
+			newStatPattern(`<span>([0-9]+) Блок</span>`, proto.Stat_StatBlockValue),
+			newStatPattern(`\+([0-9]+) к сопротивлению тайной магии`, proto.Stat_StatArcaneResistance),
+			newStatPattern(`\+([0-9]+) к сопротивлению огню`, proto.Stat_StatFireResistance),
+			newStatPattern(`\+([0-9]+) к сопротивлению магии льда`, proto.Stat_StatFrostResistance),
+			newStatPattern(`\+([0-9]+) к сопротивлению силам природы`, proto.Stat_StatNatureResistance),
+			newStatPattern(`\+([0-9]+) к сопротивлению темной магии`, proto.Stat_StatShadowResistance),
+		},
+		bonusStats: []statPattern{
+			newStatPattern(`\+([0-9]+) к силе(?:$|[^ а-яё]| и)`, proto.Stat_StatStrength),
+			newStatPattern(`\+([0-9]+) к ловкости`, proto.Stat_StatAgility),
+			newStatPattern(`\+([0-9]+) к выносливости`, proto.Stat_StatStamina),
+			newStatPattern(`\+([0-9]+) к интеллекту`, proto.Stat_StatIntellect),
+			newStatPattern(`\+([0-9]+) к духу`, proto.Stat_StatSpirit),
+			newStatPattern(`\+([0-9]+) к силе заклинаний`, proto.Stat_StatSpellPower),
+			newStatPattern(`\+([0-9]+) к рейтингу меткости`, proto.Stat_StatMeleeHit, proto.Stat_StatSpellHit),
+			newStatPattern(`\+([0-9]+) к рейтингу критического удара`, proto.Stat_StatMeleeCrit, proto.Stat_StatSpellCrit),
+			newStatPattern(`\+([0-9]+) к рейтингу скорости`, proto.Stat_StatMeleeHaste, proto.Stat_StatSpellHaste),
+			newStatPattern(`([0-9]+) ед\. маны раз в 5 сек`, proto.Stat_StatMP5),
+			newStatPattern(`\+([0-9]+) к силе атаки`, proto.Stat_StatAttackPower, proto.Stat_StatRangedAttackPower),
+			newStatPattern(`\+([0-9]+) к рейтингу пробивания брони`, proto.Stat_StatArmorPenetration),
+			newStatPattern(`\+([0-9]+) к рейтингу мастерства`, proto.Stat_StatExpertise),
+			newStatPattern(`\+([0-9]+) к рейтингу защиты`, proto.Stat_StatDefense),
+			newStatPattern(`\+([0-9]+) к рейтингу блокирования`, proto.Stat_StatBlock),
+			newStatPattern(`\+([0-9]+) к рейтингу уклонения`, proto.Stat_StatDodge),
+			newStatPattern(`\+([0-9]+) к рейтингу парирования`, proto.Stat_StatParry),
+			newStatPattern(`\+([0-9]+) к рейтингу устойчивости`, proto.Stat_StatResilience),
+		},
+	},
+}
+
+// Returns the tooltip up to the first set bonus in any locale.
+func tooltipWithoutSetBonus(tooltip string) string {
+	for _, locale := range tooltipLocales {
+		if loc := locale.setBonusRegex.FindStringIndex(tooltip); loc != nil {
+			tooltip = tooltip[:loc[0]]
+		}
+	}
+	return tooltip
+}
+
+// Adds up the marked stats of a tooltip, and the unmarked stats of any locale.
+func parseTooltipStats(tooltip string) Stats {
+	var stats Stats
+	for _, match := range tooltipStatMarkerRegex.FindAllStringSubmatch(tooltip, -1) {
+		statType, _ := strconv.Atoi(match[1])
+		value, _ := strconv.Atoi(match[2])
+		for _, stat := range itemStatTypes[statType] {
+			stats[stat] += float64(value)
+		}
+	}
+	stats[proto.Stat_StatArmor] = float64(GetRegexIntValue(tooltip, tooltipArmorMarkerRegex, 1))
+
+	for _, locale := range tooltipLocales {
+		for _, unmarked := range locale.unmarkedStats {
+			value := float64(GetRegexIntValue(tooltip, unmarked.pattern, 1))
+			for _, stat := range unmarked.stats {
+				stats[stat] += value
+			}
+		}
+	}
+	return stats
+}
+
+func parseTooltipSocketBonus(tooltip string) Stats {
+	var stats Stats
+	for _, locale := range tooltipLocales {
+		match := locale.socketBonusRegex.FindStringSubmatch(tooltip)
+		if match == nil {
+			continue
+		}
+		for _, bonus := range locale.bonusStats {
+			value := float64(GetRegexIntValue(match[1], bonus.pattern, 1))
+			for _, stat := range bonus.stats {
+				stats[stat] = max(stats[stat], value)
+			}
+		}
+	}
+	return stats
+}
+
+// Socket colors come from the socket's CSS class. When the class names the
+// color of a gem already in the socket, they come from the gem marker or the
+// socket text instead. Sockets whose color can't be read are kept as unknown,
+// so the item still has the right number of sockets.
+func parseTooltipSockets(tooltip string) []proto.GemColor {
+	sockets := []proto.GemColor{}
+	for _, match := range tooltipSocketRegex.FindAllStringSubmatch(tooltip, -1) {
+		if color, ok := socketClassColors[match[1]]; ok {
+			sockets = append(sockets, color)
+		} else if color, ok := parseGemMarkerColor(match[2]); ok {
+			sockets = append(sockets, color)
+		} else {
+			sockets = append(sockets, parseSocketColorName(match[2]))
+		}
+	}
+	return sockets
+}
+
+func parseGemMarkerColor(text string) (proto.GemColor, bool) {
+	match := tooltipGemMarkerRegex.FindStringSubmatch(text)
+	if match == nil {
+		return proto.GemColor_GemColorUnknown, false
+	}
+	code, _ := strconv.Atoi(match[1])
+	color, ok := socketColorCodes[code]
+	return color, ok
+}
+
+func parseSocketColorName(text string) proto.GemColor {
+	for _, locale := range tooltipLocales {
+		for color, pattern := range locale.socketColors {
+			if pattern.MatchString(text) {
+				return color
+			}
+		}
+	}
+	return proto.GemColor_GemColorUnknown
+}
+
+func parseTooltipIsHeroic(tooltip string) bool {
+	for _, locale := range tooltipLocales {
+		if locale.heroicRegex.MatchString(tooltip) {
+			return true
+		}
+	}
+	return false
+}
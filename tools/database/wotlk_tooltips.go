@@ -25,10 +25,10 @@ func (wtm *WotlkTooltipManager) Read() map[int32]WotlkItemResponse {
 		tooltip = strings.TrimSuffix(tooltip, ")")
 		tooltip = strings.ReplaceAll(tooltip, "\n", "")
 		tooltip = strings.ReplaceAll(tooltip, "\t", "")
-		tooltip = strings.Replace(tooltip, "name_enus: '", "\"name\": \"", 1)
+		tooltip = wotlkNameKeyRegex.ReplaceAllString(tooltip, "\"name\": \"")
 		tooltip = strings.Replace(tooltip, "quality:", "\"quality\":", 1)
 		tooltip = strings.Replace(tooltip, "icon: '", "\"icon\": \"", 1)
-		tooltip = strings.Replace(tooltip, "tooltip_enus: '", "\"tooltip\": \"", 1)
+		tooltip = wotlkTooltipKeyRegex.ReplaceAllString(tooltip, "\"tooltip\": \"")
 		tooltip = strings.ReplaceAll(tooltip, "',", "\",")
 		tooltip = strings.ReplaceAll(tooltip, "\\'", "'")
 		// replace the '} with "}
@@ -40,6 +40,10 @@ func (wtm *WotlkTooltipManager) Read() map[int32]WotlkItemResponse {
 	})
 }
 
+// Localized tooltips use the locale in their keys, e.g. name_ruru.
+var wotlkNameKeyRegex = regexp.MustCompile(`name_[a-z]{4}: '`)
+var wotlkTooltipKeyRegex = regexp.MustCompile(`tooltip_[a-z]{4}: '`)
+
 func NewWotlkItemTooltipManager(filePath string) *WotlkTooltipManager {
 	return &WotlkTooltipManager{
 		TooltipManager{
@@ -79,12 +83,7 @@ func (item WotlkItemResponse) GetIcon() string {
 }
 
 func (item WotlkItemResponse) TooltipWithoutSetBonus() string {
-	setIdx := strings.Index(item.Tooltip, "Set : ")
-	if setIdx == -1 {
-		return item.Tooltip
-	} else {
-		return item.Tooltip[:setIdx]
-	}
+	return tooltipWithoutSetBonus(item.Tooltip)
 }
 
 func (item WotlkItemResponse) GetTooltipRegexString(pattern *regexp.Regexp, matchIdx int) string {
@@ -99,79 +98,8 @@ func (item WotlkItemResponse) GetIntValue(pattern *regexp.Regexp) int {
 	return item.GetTooltipRegexValue(pattern, 1)
 }
 
-var wotlkdbArmorRegex = regexp.MustCompile("<!--amr-->([0-9]+) Armor")
-var wotlkdbAgilityRegex = regexp.MustCompile(`<!--stat3-->\+([0-9]+) Agility`)
-var wotlkdbStrengthRegex = regexp.MustCompile(`<!--stat4-->\+([0-9]+) Strength`)
-var wotlkdbIntellectRegex = regexp.MustCompile(`<!--stat5-->\+([0-9]+) Intellect`)
-var wotlkdbSpiritRegex = regexp.MustCompile(`<!--stat6-->\+([0-9]+) Spirit`)
-var wotlkdbStaminaRegex = regexp.MustCompile(`<!--stat7-->\+([0-9]+) Stamina`)
-var wotlkdbSpellPowerRegex = regexp.MustCompile("Equip: Increases spell power by ([0-9]+)")
-var wotlkdbSpellPowerRegex2 = regexp.MustCompile("Equip: Increases spell power by <!--rtg45-->([0-9]+)")
-
-var wotlkdbHitRegex = regexp.MustCompile("Improves hit rating by <!--rtg31-->([0-9]+)")
-var wotlkdbCritRegex = regexp.MustCompile("Improves critical strike rating by <!--rtg32-->([0-9]+)")
-var wotlkdbHasteRegex = regexp.MustCompile("Increases your haste rating by <!--rtg36-->([0-9]+)")
-
-var wotlkdbSpellPenetrationRegex = regexp.MustCompile("Increases your spell penetration by ([0-9]+)")
-var wotlkdbMp5Regex = regexp.MustCompile("Restores ([0-9]+) mana per 5 sec")
-var wotlkdbAttackPowerRegex = regexp.MustCompile(`Increases attack power by ([0-9]+)\.`)
-var wotlkdbAttackPowerRegex2 = regexp.MustCompile(`Increases attack power by <!--rtg38-->([0-9]+)\.`)
-var wotlkdbRangedAttackPowerRegex = regexp.MustCompile("Increases ranged attack power by ([0-9]+)")
-var wotlkdbArmorPenetrationRegex = regexp.MustCompile("Increases your armor penetration rating by <!--rtg44-->([0-9]+)")
-var wotlkdbArmorPenetrationRegex2 = regexp.MustCompile("Increases armor penetration rating by <!--rtg44-->([0-9]+)")
-var wotlkdbExpertiseRegex = regexp.MustCompile("Increases expertise rating by <!--rtg37-->([0-9]+)")
-
-var wotlkdbDefenseRegex = regexp.MustCompile("Equip: Increases defense rating by <!--rtg12-->([0-9]+)")
-var wotlkdbDefenseRegex2 = regexp.MustCompile("Equip: Increases defense rating by ([0-9]+)")
-var wotlkdbBlockRegex = regexp.MustCompile(`Equip: Increases your shield block rating by <!--rtg15-->([0-9]+)`)
-var wotlkdbBlockRegex2 = regexp.MustCompile("Equip: Increases your shield block rating by ([0-9]+)")
-var wotlkdbBlockValueRegex = regexp.MustCompile(`Equip: Increases the block value of your shield by ([0-9]+)\.`)
-var wotlkdbBlockValueRegex2 = regexp.MustCompile("<span>([0-9]+) Block</span>")
-var wotlkdbDodgeRegex = regexp.MustCompile("Increases your dodge rating by <!--rtg13-->([0-9]+)")
-var wotlkdbDodgeRegex2 = regexp.MustCompile("Increases your dodge rating by ([0-9]+)")
-var wotlkdbParryRegex = regexp.MustCompile("Increases your parry rating by <!--rtg14-->([0-9]+)")
-var wotlkdbParryRegex2 = regexp.MustCompile("Increases your parry rating by ([0-9]+)")
-var wotlkdbResilienceRegex = regexp.MustCompile("Increases your resilience rating by <!--rtg35-->([0-9]+)")
-var wotlkdbArcaneResistanceRegex = regexp.MustCompile(`\+([0-9]+) Arcane Resistance`)
-var wotlkdbFireResistanceRegex = regexp.MustCompile(`\+([0-9]+) Fire Resistance`)
-var wotlkdbFrostResistanceRegex = regexp.MustCompile(`\+([0-9]+) Frost Resistance`)
-var wotlkdbNatureResistanceRegex = regexp.MustCompile(`\+([0-9]+) Nature Resistance`)
-var wotlkdbShadowResistanceRegex = regexp.MustCompile(`\+([0-9]+) Shadow Resistance`)
-
 func (item WotlkItemResponse) GetStats() Stats {
-	sp := float64(item.GetIntValue(wotlkdbSpellPowerRegex)) + float64(item.GetIntValue(wotlkdbSpellPowerRegex2))
-	return Stats{
-		proto.Stat_StatArmor:             float64(item.GetIntValue(wotlkdbArmorRegex)),
-		proto.Stat_StatStrength:          float64(item.GetIntValue(wotlkdbStrengthRegex)),
-		proto.Stat_StatAgility:           float64(item.GetIntValue(wotlkdbAgilityRegex)),
-		proto.Stat_StatStamina:           float64(item.GetIntValue(wotlkdbStaminaRegex)),
-		proto.Stat_StatIntellect:         float64(item.GetIntValue(wotlkdbIntellectRegex)),
-		proto.Stat_StatSpirit:            float64(item.GetIntValue(wotlkdbSpiritRegex)),
-		proto.Stat_StatSpellPower:        sp,
-		proto.Stat_StatSpellHit:          float64(item.GetIntValue(wotlkdbHitRegex)),
-		proto.Stat_StatMeleeHit:          float64(item.GetIntValue(wotlkdbHitRegex)),
-		proto.Stat_StatSpellCrit:         float64(item.GetIntValue(wotlkdbCritRegex)),
-		proto.Stat_StatMeleeCrit:         float64(item.GetIntValue(wotlkdbCritRegex)),
-		proto.Stat_StatSpellHaste:        float64(item.GetIntValue(wotlkdbHasteRegex)),
-		proto.Stat_StatMeleeHaste:        float64(item.GetIntValue(wotlkdbHasteRegex)),
-		proto.Stat_StatSpellPenetration:  float64(item.GetIntValue(wotlkdbSpellPenetrationRegex)),
-		proto.Stat_StatMP5:               float64(item.GetIntValue(wotlkdbMp5Regex)),
-		proto.Stat_StatAttackPower:       float64(item.GetIntValue(wotlkdbAttackPowerRegex) + item.GetIntValue(wotlkdbAttackPowerRegex2)),
-		proto.Stat_StatRangedAttackPower: float64(item.GetIntValue(wotlkdbAttackPowerRegex) + item.GetIntValue(wotlkdbAttackPowerRegex2) + item.GetIntValue(wotlkdbRangedAttackPowerRegex)),
-		proto.Stat_StatArmorPenetration:  float64(item.GetIntValue(wotlkdbArmorPenetrationRegex) + item.GetIntValue(wotlkdbArmorPenetrationRegex2)),
-		proto.Stat_StatExpertise:         float64(item.GetIntValue(wotlkdbExpertiseRegex)),
-		proto.Stat_StatDefense:           float64(item.GetIntValue(wotlkdbDefenseRegex) + item.GetIntValue(wotlkdbDefenseRegex2)),
-		proto.Stat_StatBlock:             float64(item.GetIntValue(wotlkdbBlockRegex) + item.GetIntValue(wotlkdbBlockRegex2)),
-		proto.Stat_StatBlockValue:        float64(item.GetIntValue(wotlkdbBlockValueRegex) + item.GetIntValue(wotlkdbBlockValueRegex2)),
-		proto.Stat_StatDodge:             float64(item.GetIntValue(wotlkdbDodgeRegex) + item.GetIntValue(wotlkdbDodgeRegex2)),
-		proto.Stat_StatParry:             float64(item.GetIntValue(wotlkdbParryRegex) + item.GetIntValue(wotlkdbParryRegex2)),
-		proto.Stat_StatResilience:        float64(item.GetIntValue(wotlkdbResilienceRegex)),
-		proto.Stat_StatArcaneResistance:  float64(item.GetIntValue(wotlkdbArcaneResistanceRegex)),
-		proto.Stat_StatFireResistance:    float64(item.GetIntValue(wotlkdbFireResistanceRegex)),
-		proto.Stat_StatFrostResistance:   float64(item.GetIntValue(wotlkdbFrostResistanceRegex)),
-		proto.Stat_StatNatureResistance:  float64(item.GetIntValue(wotlkdbNatureResistanceRegex)),
-		proto.Stat_StatShadowResistance:  float64(item.GetIntValue(wotlkdbShadowResistanceRegex)),
-	}
+	return parseTooltipStats(item.TooltipWithoutSetBonus())
 }
 
 var classPatternsWotlkdb = []classPattern{
@@ -234,6 +162,9 @@ func (item WotlkItemResponse) IsEquippable() bool {
 var wotlkItemLevelRegex = regexp.MustCompile("Item Level ([0-9]+)<")
 
 func (item WotlkItemResponse) GetItemLevel() int {
+	if ilvl := item.GetIntValue(tooltipItemLevelMarkerRegex); ilvl != 0 {
+		return ilvl
+	}
 	return item.GetIntValue(wotlkItemLevelRegex)
 }
 
@@ -374,13 +305,12 @@ func (item WotlkItemResponse) GetWeaponSpeed() float64 {
 }
 
 func (item WotlkItemResponse) GetGemSockets() []proto.GemColor {
-	matches := gemColorsRegex.FindAllStringSubmatch(item.Tooltip, -1)
-	if matches == nil {
-		return []proto.GemColor{}
+	if sockets := parseTooltipSockets(item.Tooltip); len(sockets) > 0 {
+		return sockets
 	}
 
-	numSockets := len(matches)
-	gemColors := make([]proto.GemColor, numSockets)
+	matches := gemColorsRegex.FindAllStringSubmatch(item.Tooltip, -1)
+	gemColors := make([]proto.GemColor, len(matches))
 	for socketIdx, match := range matches {
 		gemColorName := "GemColor" + match[1]
 		gemColors[socketIdx] = proto.GemColor(proto.GemColor_value[gemColorName])
@@ -389,40 +319,7 @@ func (item WotlkItemResponse) GetGemSockets() []proto.GemColor {
 }
 
 func (item WotlkItemResponse) GetSocketBonus() Stats {
-	match := socketBonusRegex.FindStringSubmatch(item.Tooltip)
-	if match == nil {
-		return Stats{}
-	}
-
-	bonusStr := match[1]
-	//fmt.Printf("\n%s\n", bonusStr)
-
-	stats := Stats{
-		proto.Stat_StatStrength:          float64(GetBestRegexIntValue(bonusStr, strengthSocketBonusRegexes, 1)),
-		proto.Stat_StatAgility:           float64(GetBestRegexIntValue(bonusStr, agilitySocketBonusRegexes, 1)),
-		proto.Stat_StatStamina:           float64(GetBestRegexIntValue(bonusStr, staminaSocketBonusRegexes, 1)),
-		proto.Stat_StatIntellect:         float64(GetBestRegexIntValue(bonusStr, intellectSocketBonusRegexes, 1)),
-		proto.Stat_StatSpirit:            float64(GetBestRegexIntValue(bonusStr, spiritSocketBonusRegexes, 1)),
-		proto.Stat_StatSpellHaste:        float64(GetBestRegexIntValue(bonusStr, hasteSocketBonusRegexes, 1)),
-		proto.Stat_StatSpellPower:        float64(GetBestRegexIntValue(bonusStr, spellPowerSocketBonusRegexes, 1)),
-		proto.Stat_StatSpellHit:          float64(GetBestRegexIntValue(bonusStr, spellHitSocketBonusRegexes, 1)),
-		proto.Stat_StatMeleeHit:          float64(GetBestRegexIntValue(bonusStr, spellHitSocketBonusRegexes, 1)),
-		proto.Stat_StatSpellCrit:         float64(GetBestRegexIntValue(bonusStr, spellCritSocketBonusRegexes, 1)),
-		proto.Stat_StatMeleeCrit:         float64(GetBestRegexIntValue(bonusStr, spellCritSocketBonusRegexes, 1)),
-		proto.Stat_StatMeleeHaste:        float64(GetBestRegexIntValue(bonusStr, hasteSocketBonusRegexes, 1)),
-		proto.Stat_StatMP5:               float64(GetBestRegexIntValue(bonusStr, mp5SocketBonusRegexes, 1)),
-		proto.Stat_StatAttackPower:       float64(GetBestRegexIntValue(bonusStr, attackPowerSocketBonusRegexes, 1)),
-		proto.Stat_StatRangedAttackPower: float64(GetBestRegexIntValue(bonusStr, attackPowerSocketBonusRegexes, 1)),
-		proto.Stat_StatExpertise:         float64(GetBestRegexIntValue(bonusStr, expertiseSocketBonusRegexes, 1)),
-		proto.Stat_StatArmorPenetration:  float64(GetBestRegexIntValue(bonusStr, armorPenSocketBonusRegexes, 1)),
-		proto.Stat_StatDefense:           float64(GetBestRegexIntValue(bonusStr, defenseSocketBonusRegexes, 1)),
-		proto.Stat_StatBlock:             float64(GetBestRegexIntValue(bonusStr, blockSocketBonusRegexes, 1)),
-		proto.Stat_StatDodge:             float64(GetBestRegexIntValue(bonusStr, dodgeSocketBonusRegexes, 1)),
-		proto.Stat_StatParry:             float64(GetBestRegexIntValue(bonusStr, parrySocketBonusRegexes, 1)),
-		proto.Stat_StatResilience:        float64(GetBestRegexIntValue(bonusStr, resilienceSocketBonusRegexes, 1)),
-	}
-
-	return stats
+	return parseTooltipSocketBonus(item.Tooltip)
 }
 
 func (item WotlkItemResponse) GetSocketColor() proto.GemColor {
@@ -478,7 +375,7 @@ func (item WotlkItemResponse) GetItemSetName() string {
 }
 
 func (item WotlkItemResponse) IsHeroic() bool {
-	return strings.Contains(item.Tooltip, "<span class=\"q2\">Heroic</span>")
+	return parseTooltipIsHeroic(item.Tooltip)
 }
 
 func (item WotlkItemResponse) GetRequiredProfession() proto.Profession {
@@ -488,3 +385,10 @@ func (item WotlkItemResponse) GetRequiredProfession() proto.Profession {
 
 	return proto.Profession_ProfessionUnknown
 }
+
+func (item WotlkItemResponse) HasSpecialEffect() bool {
+	if item.GetSocketColor() == proto.GemColor_GemColorMeta {
+		return true
+	}
+	return tooltipHasSpecialEffect(item.TooltipWithoutSetBonus())
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+	"golang.org/x/exp/maps"
+)
+
+type parsedTooltip struct {
+	ID          int32              `json:"id"`
+	Name        string             `json:"name"`
+	ItemLevel   int                `json:"ilvl"`
+	Heroic      bool               `json:"heroic,omitempty"`
+	SetName     string             `json:"setName,omitempty"`
+	Stats       map[string]float64 `json:"stats"`
+	Sockets     []string           `json:"sockets"`
+	SocketBonus map[string]float64 `json:"socketBonus,omitempty"`
+}
+
+func statsByName(values Stats) map[string]float64 {
+	byName := make(map[string]float64)
+	for i, value := range values {
+		if value != 0 {
+			byName[stats.Stat(i).StatName()] = value
+		}
+	}
+	return byName
+}
+
+func socketNames(sockets []proto.GemColor) []string {
+	names := make([]string, len(sockets))
+	for i, color := range sockets {
+		names[i] = color.String()
+	}
+	return names
+}
+
+// Parses the cached tooltips in testdata, in several locales, and compares
+// the result to the stored one. On a difference the new result is written to
+// a .results.tmp file next to it.
+func TestWotlkTooltipsGolden(t *testing.T) {
+	const resultsFile = "testdata/wotlk_items_tooltips.results"
+
+	tooltips := NewWotlkItemTooltipManager("testdata/wotlk_items_tooltips.csv").Read()
+	ids := maps.Keys(tooltips)
+	slices.Sort(ids)
+
+	var parsed []parsedTooltip
+	for _, id := range ids {
+		item := tooltips[id]
+		parsed = append(parsed, parsedTooltip{
+			ID:          id,
+			Name:        item.GetName(),
+			ItemLevel:   item.GetItemLevel(),
+			Heroic:      item.IsHeroic(),
+			SetName:     item.GetItemSetName(),
+			Stats:       statsByName(item.GetStats()),
+			Sockets:     socketNames(item.GetGemSockets()),
+			SocketBonus: statsByName(item.GetSocketBonus()),
+		})
+	}
+
+	data, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, '\n')
+
+	expected, err := os.ReadFile(resultsFile)
+	if err != nil || string(expected) != string(data) {
+		os.WriteFile(resultsFile+".tmp", data, 0644)
+		t.Fatalf("Parsed tooltips differ from %s, see %s.tmp", resultsFile, resultsFile)
+	}
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"log"
 	"regexp"
 	"strconv"
@@ -71,6 +72,7 @@ type ItemResponse interface {
 	GetItemSetName() string
 	IsHeroic() bool
 	GetRequiredProfession() proto.Profession
+	HasSpecialEffect() bool
 }
 
 type WowheadItemResponse struct {
@@ -620,6 +622,38 @@ func (item WowheadItemResponse) IsGem() bool {
 	return item.GetSocketColor() != proto.GemColor_GemColorUnknown &&
 		!strings.Contains(item.GetName(), "Design:")
 }
+
+// Matches the text of each Equip, Use and Chance on hit line.
+var specialEffectLineRegex = regexp.MustCompile(`(Equip|Use|Chance on hit): (.*?)</span>`)
+var smallTextRegex = regexp.MustCompile(`<small>.*?</small>`)
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Equip lines which only give stats, which are already parsed by GetStats.
+var statEquipLineRegex = regexp.MustCompile(`^((Increases|Improves) (your )?(spell power|attack power|ranged attack power|armor penetration rating|armor penetration|spell penetration|hit rating|critical strike rating|haste rating|expertise rating|defense rating|shield block rating|dodge rating|parry rating|resilience rating|the block value of your shield) by [0-9]+|Restores [0-9]+ mana per 5 sec) *\.?$`)
+
+// Whether the tooltip has an Equip, Use or Chance on hit line which isn't a plain stat bonus.
+func tooltipHasSpecialEffect(tooltip string) bool {
+	for _, match := range specialEffectLineRegex.FindAllStringSubmatch(tooltip, -1) {
+		if match[1] != "Equip" {
+			return true
+		}
+		text := htmlTagRegex.ReplaceAllString(smallTextRegex.ReplaceAllString(match[2], ""), "")
+		text = strings.TrimSpace(strings.ReplaceAll(html.UnescapeString(text), "\u00a0", " "))
+		if !statEquipLineRegex.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (item WowheadItemResponse) HasSpecialEffect() bool {
+	if item.IsGem() && item.GetSocketColor() == proto.GemColor_GemColorMeta {
+		// Meta gems always have a bonus besides their stats.
+		return true
+	}
+	return tooltipHasSpecialEffect(item.TooltipWithoutSetBonus())
+}
+
 func (item WowheadItemResponse) ToItemProto() *proto.UIItem {
 	weaponDamageMin, weaponDamageMax := item.GetWeaponDamage()
 	return &proto.UIItem{
@@ -650,6 +684,7 @@ func (item WowheadItemResponse) ToItemProto() *proto.UIItem {
 		ClassAllowlist:     item.GetClassAllowlist(),
 		RequiredProfession: item.GetRequiredProfession(),
 		SetName:            item.GetItemSetName(),
+		HasSpecialEffect:   item.HasSpecialEffect(),
 	}
 }
 func (item WowheadItemResponse) ToGemProto() *proto.UIGem {
@@ -665,6 +700,7 @@ func (item WowheadItemResponse) ToGemProto() *proto.UIGem {
 		Quality:            proto.ItemQuality(item.GetQuality()),
 		Unique:             item.GetUnique(),
 		RequiredProfession: item.GetRequiredProfession(),
+		HasSpecialEffect:   item.HasSpecialEffect(),
 	}
 }
 
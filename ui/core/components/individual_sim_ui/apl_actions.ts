@@ -15,17 +15,24 @@ import {
 	APLActionWait,
 	APLActionWaitUntil,
 	APLActionSchedule,
+	APLActionMistake,
 
 	APLActionSequence,
 	APLActionResetSequence,
 	APLActionStrictSequence,
 
+	APLActionSetVariable,
+	APLActionCallList,
+	APLActionRunList,
+
 	APLActionChangeTarget,
 	APLActionActivateAura,
 	APLActionCancelAura,
 	APLActionTriggerICD,
 	APLActionItemSwap,
 	APLActionItemSwap_SwapSet as ItemSwapSet,
+	APLActionSummonPet,
+	APLActionDismissPet,
 
 	APLActionCustomRotation,
 	APLActionCatOptimalRotationAction,
@@ -481,6 +488,43 @@ const actionKindFactories: {[f in NonNullable<APLActionKind>]: ActionKindConfig<
 			actionFieldConfig('innerAction'),
 		],
 	}),
+	['mistake']: inputBuilder({
+		label: 'Mistake',
+		submenu: ['Timing'],
+		shortDescription: 'Randomly ignores the inner action, to model imperfect play.',
+		fullDescription: `
+			<p>Each time the inner action becomes ready, it is ignored with the given chance. If a delay is set, it is ignored for that long. Otherwise the press is skipped: it is ignored until another action is performed, or for at most the max skip (1.5s by default).</p>
+		`,
+		includeIf: (player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: () => APLActionMistake.create({
+			chance: {
+				value: {
+					oneofKind: 'const',
+					const: {
+						val: '10%',
+					},
+				},
+			},
+			action: {
+				action: {oneofKind: 'castSpell', castSpell: {}},
+			},
+		}),
+		fields: [
+			AplValues.valueFieldConfig('chance', {
+				label: 'Chance',
+				labelTooltip: 'Probability of a mistake each time the action becomes ready.',
+			}),
+			AplValues.valueFieldConfig('delay', {
+				label: 'Delay',
+				labelTooltip: 'How long the action is ignored after a mistake. Leave empty to skip the press instead.',
+			}),
+			AplValues.valueFieldConfig('maxSkip', {
+				label: 'Max Skip',
+				labelTooltip: 'Longest a skipped press is ignored for, if no other action is performed. Defaults to 1.5s.',
+			}),
+			actionFieldConfig('action'),
+		],
+	}),
 	['sequence']: inputBuilder({
 		label: 'Sequence',
 		submenu: ['Sequences'],
@@ -522,6 +566,37 @@ const actionKindFactories: {[f in NonNullable<APLActionKind>]: ActionKindConfig<
 			actionListFieldConfig('actions'),
 		],
 	}),
+	['setVariable']: inputBuilder({
+		label: 'Set Variable',
+		submenu: ['Variables'],
+		shortDescription: 'Stores a value under a name, which can be read with the <b>Variable</b> value.',
+		fullDescription: `
+			<p>The variable is assigned whenever this action is reached in the list, and evaluation continues with the next action. Variables are reset to 0 at the start of each iteration.</p>
+		`,
+		newValue: APLActionSetVariable.create,
+		fields: [
+			AplHelpers.stringFieldConfig('name'),
+			AplValues.valueFieldConfig('value'),
+		],
+	}),
+	['callList']: inputBuilder({
+		label: 'Call Action List',
+		submenu: ['Variables'],
+		shortDescription: 'Performs the first ready action from a named action list. If none are ready, continues with the next action in this list.',
+		newValue: APLActionCallList.create,
+		fields: [
+			AplHelpers.stringFieldConfig('listName'),
+		],
+	}),
+	['runList']: inputBuilder({
+		label: 'Run Action List',
+		submenu: ['Variables'],
+		shortDescription: 'Performs the first ready action from a named action list. If none are ready, nothing else in this list is performed.',
+		newValue: APLActionRunList.create,
+		fields: [
+			AplHelpers.stringFieldConfig('listName'),
+		],
+	}),
 	['changeTarget']: inputBuilder({
 		label: 'Change Target',
 		submenu: ['Misc'],
@@ -571,6 +646,27 @@ const actionKindFactories: {[f in NonNullable<APLActionKind>]: ActionKindConfig<
 		],
 	}),
 
+	['summonPet']: inputBuilder({
+		label: 'Summon Pet',
+		submenu: ['Pet'],
+		shortDescription: 'Summons the pet, using the spell that summons it if there is one. Permanent pets without such a spell are summoned instantly.',
+		includeIf: (player: Player<any>, isPrepull: boolean) => player.getPetMetadatas().asList().length > 0,
+		newValue: () => APLActionSummonPet.create(),
+		fields: [
+			AplHelpers.petFieldConfig('pet'),
+		],
+	}),
+	['dismissPet']: inputBuilder({
+		label: 'Dismiss Pet',
+		submenu: ['Pet'],
+		shortDescription: 'Dismisses the pet if it is currently active, e.g. to sacrifice it or to re-summon it later.',
+		includeIf: (player: Player<any>, isPrepull: boolean) => player.getPetMetadatas().asList().length > 0,
+		newValue: () => APLActionDismissPet.create(),
+		fields: [
+			AplHelpers.petFieldConfig('pet'),
+		],
+	}),
+
 	['customRotation']: inputBuilder({
 		label: 'Custom Rotation',
 		//submenu: ['Misc'],
@@ -226,7 +226,7 @@ export class APLActionIDPicker extends DropdownPicker<Player<any>, ActionID, Act
 	}
 }
 
-export type UNIT_SET = 'aura_sources' | 'aura_sources_targets_first' | 'targets';
+export type UNIT_SET = 'aura_sources' | 'aura_sources_targets_first' | 'targets' | 'pets';
 
 const unitSets: Record<UNIT_SET, {
 	// Uses target icon by default instead of person icon. This should be set to true for inputs that default to CurrentTarget.
@@ -263,6 +263,11 @@ const unitSets: Record<UNIT_SET, {
 			].flat();
 		},
 	},
+	'pets': {
+		getUnits: (player) => {
+			return player.getPetMetadatas().asList().map((petMetadata, i) => UnitReference.create({type: UnitType.Pet, index: i, owner: UnitReference.create({type: UnitType.Self})}));
+		},
+	},
 };
 
 export interface APLUnitPickerConfig extends Omit<UnitPickerConfig<Player<any>>, 'values'> {
@@ -477,6 +482,13 @@ export function unitFieldConfig(field: string, unitSet: UNIT_SET, options?: Part
 	};
 }
 
+export function petFieldConfig(field: string, options?: Partial<APLPickerBuilderFieldConfig<any, any>>): APLPickerBuilderFieldConfig<any, any> {
+	return unitFieldConfig(field, 'pets', {
+		newValue: () => UnitReference.create({type: UnitType.Pet, index: 0, owner: UnitReference.create({type: UnitType.Self})}),
+		...(options || {}),
+	});
+}
+
 export function booleanFieldConfig(field: string, label?:string, options?: Partial<APLPickerBuilderFieldConfig<any, any>>): APLPickerBuilderFieldConfig<any, any> {
 	return {
 		field: field,
@@ -18,6 +18,9 @@ import {
 	APLValueMath_MathOperator as MathOperator,
 	APLValueMax,
 	APLValueMin,
+	APLValueVariable,
+	APLValueRandom,
+	APLValueChance,
 	APLValueConst,
 	APLValueCurrentTime,
 	APLValueCurrentTimePercent,
@@ -61,6 +64,12 @@ import {
 	APLValueAuraInternalCooldown,
 	APLValueAuraICDIsReadyWithReactionTime,
 	APLValueAuraShouldRefresh,
+	APLValueAuraAppliedBy,
+	APLValueRaidAuraCount,
+	APLValueRaidAuraAnyActive,
+	APLValueRaidAuraAllActive,
+	APLValueRaidAuraMinRemainingTime,
+	APLValueRaidSpellTimeToReady,
 	APLValueDotIsActive,
 	APLValueDotRemainingTime,
 	APLValueSequenceIsComplete,
@@ -75,7 +84,13 @@ import {
 	APLValueWarlockShouldRefreshCorruption,
 	APLValueCatNewSavageRoarDuration,
 	APLValueBossSpellTimeToReady,
+	APLValueBossNextDangerousEventTime,
+	APLValueBossNextDangerousEventDamage,
 	APLValueBossSpellIsCasting,
+	APLValueRogueIsStealthed,
+	APLValuePetIsActive,
+	APLValuePetRemainingTime,
+	APLValueRogueTimeSinceStealthBroke,
 } from '../../proto/apl.js';
 
 import { EventID } from '../../typed_event.js';
@@ -474,6 +489,43 @@ const valueKindFactories: {[f in NonNullable<APLValueKind>]: ValueKindConfig<APL
 			valueListFieldConfig('vals'),
 		],
 	}),
+	'variable': inputBuilder({
+		label: 'Variable',
+		submenu: ['Logic'],
+		shortDescription: 'Returns the value most recently stored under this name by a <b>Set Variable</b> action.',
+		newValue: APLValueVariable.create,
+		fields: [
+			AplHelpers.stringFieldConfig('name'),
+		],
+	}),
+	'random': inputBuilder({
+		label: 'Random',
+		submenu: ['Logic'],
+		shortDescription: 'A random number between 0 and 1, drawn each time it is evaluated.',
+		newValue: APLValueRandom.create,
+		fields: [],
+	}),
+	'chance': inputBuilder({
+		label: 'Chance',
+		submenu: ['Logic'],
+		shortDescription: '<b>True</b> with the given probability, drawn each time it is evaluated.',
+		fullDescription: `
+		<p>Useful for modelling imperfect play, e.g. only refreshing a buff early 90% of the time.</p>
+		`,
+		newValue: () => APLValueChance.create({
+			probability: {
+				value: {
+					oneofKind: 'const',
+					const: {
+						val: '90%',
+					},
+				},
+			},
+		}),
+		fields: [
+			valueFieldConfig('probability'),
+		],
+	}),
 	'and': inputBuilder({
 		label: 'All of',
 		submenu: ['Logic'],
@@ -576,6 +628,20 @@ const valueKindFactories: {[f in NonNullable<APLValueKind>]: ValueKindConfig<APL
 			AplHelpers.actionIdFieldConfig('spellId', 'spells', 'targetUnit', 'currentTarget'),
 		]
 	}),
+	'bossNextDangerousEventTime': inputBuilder({
+		label: 'Next Dangerous Ability Time',
+		submenu: ['Boss'],
+		shortDescription: 'Time until the next dangerous boss ability which hits this player, or <b>0</b> while it is active.',
+		newValue: APLValueBossNextDangerousEventTime.create,
+		fields: [],
+	}),
+	'bossNextDangerousEventDamage': inputBuilder({
+		label: 'Next Dangerous Ability Damage',
+		submenu: ['Boss'],
+		shortDescription: 'Expected damage of each hit from the next dangerous boss ability which hits this player.',
+		newValue: APLValueBossNextDangerousEventDamage.create,
+		fields: [],
+	}),
 
 	// Resources
 	'currentHealth': inputBuilder({
@@ -939,6 +1005,74 @@ const valueKindFactories: {[f in NonNullable<APLValueKind>]: ValueKindConfig<APL
 			}),
 		],
 	}),
+	'auraAppliedBy': inputBuilder({
+		label: 'Aura Applied By',
+		submenu: ['Aura'],
+		shortDescription: '<b>True</b> if the aura is active and was last applied or refreshed by the given unit, otherwise <b>False</b>.',
+		fullDescription: `
+		<p>Useful for debuffs shared by the raid, e.g. so that only one Warlock keeps Curse of the Elements up.</p>
+		`,
+		newValue: APLValueAuraAppliedBy.create,
+		fields: [
+			AplHelpers.unitFieldConfig('sourceUnit', 'aura_sources_targets_first'),
+			AplHelpers.actionIdFieldConfig('auraId', 'auras', 'sourceUnit', 'currentTarget'),
+			AplHelpers.unitFieldConfig('applier', 'aura_sources', {
+				label: 'Applier',
+			}),
+		],
+	}),
+
+	// Raid
+	'raidAuraCount': inputBuilder({
+		label: 'Raid Aura Count',
+		submenu: ['Raid'],
+		shortDescription: 'Number of raid members (or party members) with the aura active.',
+		newValue: APLValueRaidAuraCount.create,
+		fields: [
+			AplHelpers.actionIdFieldConfig('auraId', 'auras'),
+			AplHelpers.booleanFieldConfig('partyOnly', 'Party Only'),
+		],
+	}),
+	'raidAuraAnyActive': inputBuilder({
+		label: 'Raid Aura Any Active',
+		submenu: ['Raid'],
+		shortDescription: '<b>True</b> if any raid member (or party member) has the aura active, otherwise <b>False</b>.',
+		newValue: APLValueRaidAuraAnyActive.create,
+		fields: [
+			AplHelpers.actionIdFieldConfig('auraId', 'auras'),
+			AplHelpers.booleanFieldConfig('partyOnly', 'Party Only'),
+		],
+	}),
+	'raidAuraAllActive': inputBuilder({
+		label: 'Raid Aura All Active',
+		submenu: ['Raid'],
+		shortDescription: '<b>True</b> if every raid member (or party member) who can have the aura has it active, otherwise <b>False</b>.',
+		newValue: APLValueRaidAuraAllActive.create,
+		fields: [
+			AplHelpers.actionIdFieldConfig('auraId', 'auras'),
+			AplHelpers.booleanFieldConfig('partyOnly', 'Party Only'),
+		],
+	}),
+	'raidAuraMinRemainingTime': inputBuilder({
+		label: 'Raid Aura Min Remaining Time',
+		submenu: ['Raid'],
+		shortDescription: 'Lowest remaining time of the aura among raid members (or party members) who have it active, or 0 if nobody does.',
+		newValue: APLValueRaidAuraMinRemainingTime.create,
+		fields: [
+			AplHelpers.actionIdFieldConfig('auraId', 'auras'),
+			AplHelpers.booleanFieldConfig('partyOnly', 'Party Only'),
+		],
+	}),
+	'raidSpellTimeToReady': inputBuilder({
+		label: 'Raid Spell Time To Ready',
+		submenu: ['Raid'],
+		shortDescription: 'Time until any raid member (or party member) who knows the spell can cast it again, e.g. the raid\'s next Heroism.',
+		newValue: APLValueRaidSpellTimeToReady.create,
+		fields: [
+			AplHelpers.actionIdFieldConfig('spellId', 'spells'),
+			AplHelpers.booleanFieldConfig('partyOnly', 'Party Only'),
+		],
+	}),
 
 	// DoT
 	'dotIsActive': inputBuilder({
@@ -961,6 +1095,26 @@ const valueKindFactories: {[f in NonNullable<APLValueKind>]: ValueKindConfig<APL
 			AplHelpers.actionIdFieldConfig('spellId', 'dot_spells', ''),
 		],
 	}),
+	'petIsActive': inputBuilder({
+		label: 'Pet Is Active',
+		submenu: ['Pet'],
+		shortDescription: '<b>True</b> if the pet is currently summoned, otherwise <b>False</b>.',
+		newValue: APLValuePetIsActive.create,
+		includeIf: (player: Player<any>, isPrepull: boolean) => player.getPetMetadatas().asList().length > 0,
+		fields: [
+			AplHelpers.petFieldConfig('pet'),
+		],
+	}),
+	'petRemainingTime': inputBuilder({
+		label: 'Pet Remaining Time',
+		submenu: ['Pet'],
+		shortDescription: 'Time until the pet despawns, or 0 if it is not summoned. Permanent pets never despawn on their own.',
+		newValue: APLValuePetRemainingTime.create,
+		includeIf: (player: Player<any>, isPrepull: boolean) => player.getPetMetadatas().asList().length > 0,
+		fields: [
+			AplHelpers.petFieldConfig('pet'),
+		],
+	}),
 	'sequenceIsComplete': inputBuilder({
 		label: 'Sequence Is Complete',
 		submenu: ['Sequence'],
@@ -1037,4 +1191,22 @@ const valueKindFactories: {[f in NonNullable<APLValueKind>]: ValueKindConfig<APL
 			AplHelpers.unitFieldConfig('targetUnit', 'targets'),
 		],
 	}),
+	'rogueIsStealthed': inputBuilder({
+		label: 'Is Stealthed',
+		submenu: ['Rogue'],
+		shortDescription: 'Returns <b>True</b> if abilities requiring Stealth can be used, either from Stealth, Vanish or Shadow Dance.',
+		newValue: APLValueRogueIsStealthed.create,
+		includeIf: (player: Player<any>, isPrepull: boolean) => player.getClass() == Class.ClassRogue,
+		fields: [
+		],
+	}),
+	'rogueTimeSinceStealthBroke': inputBuilder({
+		label: 'Time Since Stealth Broke',
+		submenu: ['Rogue'],
+		shortDescription: 'Time since Stealth last faded, useful for timing Overkill and Master of Subtlety windows. Returns <b>0</b> while stealthed, and a very large value if the rogue has not been stealthed yet.',
+		newValue: APLValueRogueTimeSinceStealthBroke.create,
+		includeIf: (player: Player<any>, isPrepull: boolean) => player.getClass() == Class.ClassRogue,
+		fields: [
+		],
+	}),
 };
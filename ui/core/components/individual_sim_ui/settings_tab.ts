@@ -28,6 +28,7 @@ import { relevantStatOptions } from "../inputs/stat_options";
 import { ItemSwapPicker } from "../item_swap_picker";
 import { MultiIconPicker } from "../multi_icon_picker";
 import { NumberPicker } from "../number_picker";
+import * as OtherInputs from '../other_inputs.js';
 import { SavedDataManager } from "../saved_data_manager";
 import { SimTab } from "../sim_tab";
 import { ConsumesPicker } from "./consumes_picker";
@@ -179,7 +180,12 @@ export class SettingsTab extends SimTab {
 	}
 
 	private buildOtherSettings() {
-		const settings = this.simUI.individualConfig.otherInputs?.inputs.filter(inputs =>
+		// Latency applies to every spec, so it is shown alongside each spec's own inputs.
+		const otherInputs: InputSection = {
+			...this.simUI.individualConfig.otherInputs,
+			inputs: (this.simUI.individualConfig.otherInputs?.inputs || []).concat(OtherInputs.LatencyInputs),
+		};
+		const settings = otherInputs.inputs.filter(inputs =>
 			!inputs.extraCssClasses?.includes('within-raid-sim-hide') || true
 		)
 
@@ -190,7 +196,7 @@ export class SettingsTab extends SimTab {
 			});
 
 			if (settings.length > 0) {
-				this.configureInputSection(contentBlock.bodyElement, this.simUI.individualConfig.otherInputs);
+				this.configureInputSection(contentBlock.bodyElement, otherInputs);
 				contentBlock.bodyElement.querySelectorAll('.input-root').forEach(elem => {
 					elem.classList.add('input-inline');
 				})
@@ -93,6 +93,49 @@ export const ReactionTime = {
 	},
 };
 
+export const NetworkLatency = {
+	type: 'number' as const,
+	label: 'Network Latency',
+	labelTooltip: 'Round trip time to the server, in milliseconds. Delays every action following a GCD, cast or proc, less whatever the spell queue window hides.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatency().networkLatencyMs,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const latency = player.getLatency();
+		latency.networkLatencyMs = newValue;
+		player.setLatency(eventID, latency);
+	},
+};
+
+export const InputDelay = {
+	type: 'number' as const,
+	label: 'Input Delay',
+	labelTooltip: 'Average time for the player to press the next action once it is available, in milliseconds.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatency().inputDelayMeanMs,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const latency = player.getLatency();
+		latency.inputDelayMeanMs = newValue;
+		latency.inputDelayStdevMs = Math.round(newValue / 3);
+		player.setLatency(eventID, latency);
+	},
+};
+
+export const SpellQueueWindow = {
+	type: 'number' as const,
+	label: 'Spell Queue Window',
+	labelTooltip: 'How early the next action can be queued before a GCD or cast ends, in milliseconds. Only used when Network Latency or Input Delay is set. The game default is 400.',
+	changedEvent: (player: Player<any>) => player.miscOptionsChangeEmitter,
+	getValue: (player: Player<any>) => player.getLatency().spellQueueWindowMs,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const latency = player.getLatency();
+		latency.spellQueueWindowMs = newValue;
+		player.setLatency(eventID, latency);
+	},
+};
+
+// Added to the other settings of every spec.
+export const LatencyInputs = [NetworkLatency, InputDelay, SpellQueueWindow];
+
 export const ChannelClipDelay = {
 	type: 'number' as const,
 	label: 'Channel Clip Delay',
@@ -183,7 +226,24 @@ export const IncomingHps = {
 		healingModel.hps = newValue;
 		player.setHealingModel(eventID, healingModel);
 	},
-	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
+	enableWhen: (player: Player<any>) => player.getHealingModel().raidDtps > 0 || (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) != null,
+};
+
+export const IncomingRaidDamage = {
+	type: 'number' as const,
+	label: 'Incoming Raid DTPS',
+	labelTooltip: `
+		<p>Average amount of raid-wide damage taken per second while not tanking.</p>
+		<p>When set, health is tracked for this player so that self-heals and leech effects restore real health. Use <b>Incoming HPS</b> to model healing from the raid's healers.</p>
+	`,
+	changedEvent: (player: Player<any>) => player.getRaid()!.changeEmitter,
+	getValue: (player: Player<any>) => player.getHealingModel().raidDtps,
+	setValue: (eventID: EventID, player: Player<any>, newValue: number) => {
+		const healingModel = player.getHealingModel();
+		healingModel.raidDtps = newValue;
+		player.setHealingModel(eventID, healingModel);
+	},
+	enableWhen: (player: Player<any>) => (player.getRaid()?.getTanks() || []).find(tank => UnitReference.equals(tank, player.makeUnitReference())) == null,
 };
 
 export const HealingCadence = {
@@ -240,6 +240,10 @@ export abstract class IndividualSimUI<SpecType extends Spec> extends SimUI {
 				return `Only 3 Jewelcrafting Gems are allowed, but ${jcGems.length} are equipped.`;
 			},
 		});
+		this.addWarning({
+			updateOn: this.player.currentStatsEmitter,
+			getContent: () => this.player.getCurrentStats().warnings,
+		});
 		this.addWarning({
 			updateOn: this.player.talentsChangeEmitter,
 			getContent: () => {
@@ -40,7 +40,7 @@ import {
 } from './proto/ui.js';
 
 import { PlayerStats } from './proto/api.js';
-import { Player as PlayerProto } from './proto/api.js';
+import { LatencyProfile, Player as PlayerProto } from './proto/api.js';
 import { StatWeightsResult } from './proto/api.js';
 import { ActionId } from './proto_utils/action_id.js';
 import { EquippedItem, getWeaponDPS } from './proto_utils/equipped_item.js';
@@ -248,6 +248,7 @@ export class Player<SpecType extends Spec> {
 	private specOptions: SpecOptions<SpecType>;
 	private reactionTime: number = 0;
 	private channelClipDelay: number = 0;
+	private latency: LatencyProfile = LatencyProfile.create();
 	private inFrontOfTarget: boolean = false;
 	private distanceFromTarget: number = 0;
 	private nibelungAverageCasts: number = 11;
@@ -916,6 +917,20 @@ export class Player<SpecType extends Spec> {
 		this.miscOptionsChangeEmitter.emit(eventID);
 	}
 
+	getLatency(): LatencyProfile {
+		// Make a defensive copy
+		return LatencyProfile.clone(this.latency);
+	}
+
+	setLatency(eventID: EventID, newLatency: LatencyProfile) {
+		if (LatencyProfile.equals(this.latency, newLatency))
+			return;
+
+		// Make a defensive copy
+		this.latency = LatencyProfile.clone(newLatency);
+		this.miscOptionsChangeEmitter.emit(eventID);
+	}
+
 	getInFrontOfTarget(): boolean {
 		return this.inFrontOfTarget;
 	}
@@ -1354,6 +1369,7 @@ export class Player<SpecType extends Spec> {
 				profession2: this.getProfession2(),
 				reactionTimeMs: this.getReactionTime(),
 				channelClipDelayMs: this.getChannelClipDelay(),
+				latency: this.getLatency(),
 				inFrontOfTarget: this.getInFrontOfTarget(),
 				distanceFromTarget: this.getDistanceFromTarget(),
 				healingModel: this.getHealingModel(),
@@ -1413,6 +1429,7 @@ export class Player<SpecType extends Spec> {
 				this.setProfession2(eventID, proto.profession2);
 				this.setReactionTime(eventID, proto.reactionTimeMs);
 				this.setChannelClipDelay(eventID, proto.channelClipDelayMs);
+				this.setLatency(eventID, proto.latency || LatencyProfile.create());
 				this.setInFrontOfTarget(eventID, proto.inFrontOfTarget);
 				this.setDistanceFromTarget(eventID, proto.distanceFromTarget);
 				this.setNibelungAverageCastsSet(eventID, proto.nibelungAverageCastsSet);
@@ -109,6 +109,10 @@ export class ActionId {
 				baseName = 'Potion';
 				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/inv_alchemy_elixir_04.jpg';
 				break;
+			case OtherAction.OtherActionRaidDamage:
+				baseName = 'Raid Damage';
+				iconUrl = 'https://wow.zamimg.com/images/wow/icons/large/spell_shadow_shadowbolt.jpg';
+				break;
 		}
 		this.baseName = baseName;
 		this.name = name || baseName;
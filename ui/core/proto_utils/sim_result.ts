@@ -1033,6 +1033,7 @@ export class TargetedActionMetrics {
 				threat: sum(actions.map(a => a.data.threat)),
 				healing: sum(actions.map(a => a.data.healing)),
 				shielding: sum(actions.map(a => a.data.shielding)),
+				absorbed: sum(actions.map(a => a.data.absorbed)),
 				castTimeMs: sum(actions.map(a => a.data.castTimeMs)),
 			}));
 	}
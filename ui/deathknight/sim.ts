@@ -193,6 +193,8 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecDeathknight, {
 
 			OtherInputs.TankAssignment,
 			OtherInputs.InFrontOfTarget,
+			OtherInputs.IncomingRaidDamage,
+			OtherInputs.IncomingHps,
 		],
 	},
 	itemSwapSlots: [ItemSlot.ItemSlotMainHand, ItemSlot.ItemSlotOffHand],
@@ -149,6 +149,8 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecFeralDruid, {
 			DruidInputs.AssumeBleedActive,
 			OtherInputs.TankAssignment,
 			OtherInputs.InFrontOfTarget,
+			OtherInputs.IncomingRaidDamage,
+			OtherInputs.IncomingHps,
 		],
 	},
 	encounterPicker: {
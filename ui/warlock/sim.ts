@@ -121,6 +121,8 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecWarlock, {
 			WarlockInputs.DetonateSeed,
 			OtherInputs.DistanceFromTarget,
 			OtherInputs.TankAssignment,
+			OtherInputs.IncomingRaidDamage,
+			OtherInputs.IncomingHps,
 			OtherInputs.ChannelClipDelay,
 			OtherInputs.nibelungAverageCasts,
 		],